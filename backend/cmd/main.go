@@ -1,17 +1,24 @@
 package main
 
 import (
+    "context"
     "log"
     "os"
     "fmt"
+    "strconv"
+    "time"
 
     "github.com/gin-gonic/gin"
     "github.com/joho/godotenv"
     "github.com/myfarism/finance-tracker/internal/handler"
     "github.com/myfarism/finance-tracker/internal/middleware"
     "github.com/myfarism/finance-tracker/internal/repository"
+    "github.com/myfarism/finance-tracker/internal/scheduler"
     "github.com/myfarism/finance-tracker/internal/service"
     "github.com/myfarism/finance-tracker/pkg/database"
+    "github.com/myfarism/finance-tracker/pkg/mailer"
+    "github.com/myfarism/finance-tracker/pkg/otp"
+    "github.com/myfarism/finance-tracker/pkg/storage"
 )
 
 func main() {
@@ -26,19 +33,89 @@ func main() {
     catRepo  := repository.NewCategoryRepository(database.DB)
     txRepo   := repository.NewTransactionRepository(database.DB)
     budgetRepo := repository.NewBudgetRepository(database.DB)
+    sessionRepo := repository.NewSessionRepository(database.DB)
+    accountRepo := repository.NewAccountRepository(database.DB)
+    transferRepo := repository.NewTransferRepository(database.DB)
+    rateRepo := repository.NewExchangeRateRepository(database.DB)
+    recurringRepo := repository.NewRecurringRepository(database.DB)
+    tagRepo := repository.NewTagRepository(database.DB)
+    attachmentRepo := repository.NewAttachmentRepository(database.DB)
+    duplicateRepo := repository.NewDuplicateRepository(database.DB)
+    ruleRepo := repository.NewRuleRepository(database.DB)
 
+    // OTP store: "postgres" wajib dipakai jika backend jalan lebih dari satu replica
+    var otpStore otp.Store
+    switch os.Getenv("OTP_STORE") {
+    case "", "memory":
+        otpStore = otp.NewMemoryStore()
+    case "postgres":
+        otpStore = otp.NewPostgresStore(database.DB)
+    default:
+        log.Fatalf("Unknown OTP_STORE %q (use memory or postgres)", os.Getenv("OTP_STORE"))
+    }
+
+    // Mailer: resend (default), smtp, file (.eml) atau log
+    mail, err := mailer.NewFromEnv()
+    if err != nil {
+        log.Fatal("Failed to configure mailer:", err)
+    }
+
+    // Storage lampiran: local (default) atau s3
+    blobs, err := storage.NewFromEnv()
+    if err != nil {
+        log.Fatal("Failed to configure storage:", err)
+    }
+    attachmentLimits := service.DefaultAttachmentLimits
+    if v := os.Getenv("ATTACHMENT_MAX_MB"); v != "" {
+        attachmentLimits.MaxFileSize = envMegabytes("ATTACHMENT_MAX_MB", v)
+    }
+    if v := os.Getenv("ATTACHMENT_QUOTA_MB"); v != "" {
+        attachmentLimits.UserQuota = envMegabytes("ATTACHMENT_QUOTA_MB", v)
+    }
 
     // Services
-    authSvc := service.NewAuthService(userRepo)
+    authSvc := service.NewAuthService(userRepo, sessionRepo, otpStore, mail)
     catSvc  := service.NewCategoryService(catRepo)
-    txSvc   := service.NewTransactionService(txRepo, catRepo)
-    budgetSvc     := service.NewBudgetService(budgetRepo, txRepo)
+    txSvc   := service.NewTransactionService(txRepo, catRepo, accountRepo, userRepo, rateRepo, tagRepo, ruleRepo, blobs)
+    budgetSvc     := service.NewBudgetService(budgetRepo, txRepo, catRepo, userRepo, rateRepo)
+    accountSvc    := service.NewAccountService(accountRepo, userRepo)
+    transferSvc   := service.NewTransferService(transferRepo, accountRepo, catRepo, rateRepo)
+    rateSvc       := service.NewExchangeRateService(rateRepo, userRepo)
+    recurringSvc  := service.NewRecurringService(recurringRepo, catRepo, accountRepo, userRepo)
+    tagSvc        := service.NewTagService(tagRepo, userRepo, rateRepo)
+    attachmentSvc := service.NewAttachmentService(attachmentRepo, txRepo, blobs, attachmentLimits)
+    importSvc     := service.NewImportService(txRepo, catRepo, accountRepo, userRepo, ruleRepo)
+    duplicateSvc  := service.NewDuplicateService(txRepo, duplicateRepo)
+    ruleSvc       := service.NewRuleService(ruleRepo, txRepo, catRepo, accountRepo, tagRepo)
 
     // Handlers
     authHandler := handler.NewAuthHandler(authSvc)
     catHandler  := handler.NewCategoryHandler(catSvc)
     txHandler   := handler.NewTransactionHandler(txSvc)
     budgetHandler := handler.NewBudgetHandler(budgetSvc)
+    accountHandler := handler.NewAccountHandler(accountSvc)
+    transferHandler := handler.NewTransferHandler(transferSvc)
+    rateHandler := handler.NewExchangeRateHandler(rateSvc)
+    recurringHandler := handler.NewRecurringHandler(recurringSvc)
+    tagHandler := handler.NewTagHandler(tagSvc)
+    attachmentHandler := handler.NewAttachmentHandler(attachmentSvc, attachmentLimits.MaxFileSize)
+    importHandler := handler.NewImportHandler(importSvc)
+    duplicateHandler := handler.NewDuplicateHandler(duplicateSvc)
+    ruleHandler := handler.NewRuleHandler(ruleSvc)
+
+    // Scheduler transaksi berulang, RECURRING_SCHEDULER=off untuk mematikan
+    // (mis. jika dijalankan terpisah dari API)
+    if os.Getenv("RECURRING_SCHEDULER") != "off" {
+        interval := 15 * time.Minute
+        if v := os.Getenv("RECURRING_INTERVAL_MINUTES"); v != "" {
+            minutes, err := strconv.Atoi(v)
+            if err != nil || minutes < 1 {
+                log.Fatalf("Invalid RECURRING_INTERVAL_MINUTES %q", v)
+            }
+            interval = time.Duration(minutes) * time.Minute
+        }
+        scheduler.NewRecurringScheduler(recurringSvc, interval).Start(context.Background())
+    }
 
     r := gin.Default()
 
@@ -70,24 +147,94 @@ func main() {
         auth.POST("/verify-otp", authHandler.VerifyOTP)
         auth.POST("/resend-otp", authHandler.ResendOTP)
         auth.POST("/login", authHandler.Login)
+        auth.POST("/refresh", authHandler.Refresh)
+        auth.POST("/logout", authHandler.Logout)
+        auth.POST("/forgot-password", authHandler.ForgotPassword)
+        auth.POST("/reset-password", authHandler.ResetPassword)
 
         // Protected routes
         protected := api.Group("/")
-        protected.Use(middleware.AuthMiddleware())
+        protected.Use(middleware.AuthMiddleware(sessionRepo))
         {
             // Categories
             protected.GET("/categories", catHandler.GetAll)
             protected.POST("/categories", catHandler.Create)
+            protected.PUT("/categories/:id", catHandler.Update)
+            protected.DELETE("/categories/:id", catHandler.Delete)
+            protected.POST("/categories/:id/merge", catHandler.Merge)
+
+            // Accounts
+            protected.GET("/accounts", accountHandler.GetAll)
+            protected.POST("/accounts", accountHandler.Create)
+            protected.GET("/accounts/:id", accountHandler.GetByID)
+            protected.PUT("/accounts/:id", accountHandler.Update)
+            protected.DELETE("/accounts/:id", accountHandler.Delete)
+            protected.GET("/accounts/:id/balance", accountHandler.GetBalance)
+
+            // Transfers antar akun
+            protected.GET("/transfers", transferHandler.GetAll)
+            protected.POST("/transfers", transferHandler.Create)
+            protected.GET("/transfers/:id", transferHandler.GetByID)
+            protected.DELETE("/transfers/:id", transferHandler.Delete)
+
+            // Multi-currency: base currency & tabel kurs
+            protected.GET("/settings/currency", rateHandler.GetBaseCurrency)
+            protected.PUT("/settings/currency", rateHandler.SetBaseCurrency)
+            protected.GET("/exchange-rates", rateHandler.GetAll)
+            protected.POST("/exchange-rates", rateHandler.Upsert)
+            protected.POST("/exchange-rates/import", rateHandler.Import)
+            protected.DELETE("/exchange-rates/:id", rateHandler.Delete)
+
+            // Tags
+            protected.GET("/tags", tagHandler.GetAll)
+            protected.POST("/tags", tagHandler.Create)
+            protected.GET("/tags/report", tagHandler.GetReport)
+            protected.PUT("/tags/:id", tagHandler.Rename)
+            protected.DELETE("/tags/:id", tagHandler.Delete)
+            protected.POST("/tags/:id/merge", tagHandler.Merge)
+
+            // Rule kategorisasi otomatis
+            protected.GET("/rules", ruleHandler.GetAll)
+            protected.POST("/rules", ruleHandler.Create)
+            protected.POST("/rules/test", ruleHandler.Test)
+            protected.POST("/rules/apply", ruleHandler.Apply)
+            protected.PUT("/rules/:id", ruleHandler.Update)
+            protected.DELETE("/rules/:id", ruleHandler.Delete)
+
+            // Transaksi berulang
+            protected.GET("/recurring", recurringHandler.GetAll)
+            protected.POST("/recurring", recurringHandler.Create)
+            protected.GET("/recurring/:id", recurringHandler.GetByID)
+            protected.PUT("/recurring/:id", recurringHandler.Update)
+            protected.DELETE("/recurring/:id", recurringHandler.Delete)
+            protected.POST("/recurring/:id/pause", recurringHandler.Pause)
+            protected.POST("/recurring/:id/resume", recurringHandler.Resume)
+            protected.POST("/recurring/:id/skip", recurringHandler.Skip)
 
             // Transactions
             protected.POST("/transactions", txHandler.Create)
             protected.GET("/transactions", txHandler.GetAll)
+            protected.GET("/transactions/search", txHandler.Search)
+            protected.GET("/transactions/duplicates", duplicateHandler.GetAll)
+            protected.POST("/transactions/duplicates/merge", duplicateHandler.Merge)
+            protected.POST("/transactions/duplicates/dismiss", duplicateHandler.Dismiss)
             protected.GET("/transactions/:id", txHandler.GetByID)
             protected.PUT("/transactions/:id", txHandler.Update)
             protected.DELETE("/transactions/:id", txHandler.Delete)
 
+            // Lampiran struk per transaksi
+            protected.GET("/transactions/:id/attachments", attachmentHandler.GetAll)
+            protected.POST("/transactions/:id/attachments", attachmentHandler.Upload)
+            protected.GET("/transactions/:id/attachments/:attachmentId", attachmentHandler.Download)
+            protected.DELETE("/transactions/:id/attachments/:attachmentId", attachmentHandler.Delete)
+
+            // Import statement (preview dengan dry_run, lalu commit)
+            protected.GET("/imports/sources", importHandler.Sources)
+            protected.POST("/imports", importHandler.Import)
+
             // Summary (untuk dashboard chart)
             protected.GET("/transactions/summary", txHandler.GetSummary)
+            protected.GET("/transactions/summary/categories", txHandler.GetCategorySummary)
 
             // Budgets
             protected.GET("/budgets", budgetHandler.GetByMonth)
@@ -109,3 +256,11 @@ func main() {
     log.Printf("🚀 Server running on port %s", port)
     r.Run("0.0.0.0:" + port)
 }
+
+func envMegabytes(name, value string) int64 {
+    mb, err := strconv.Atoi(value)
+    if err != nil || mb < 1 {
+        log.Fatalf("Invalid %s %q", name, value)
+    }
+    return int64(mb) << 20
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+    "time"
+    "github.com/google/uuid"
+)
+
+type AccountType string
+
+const (
+    AccountBank       AccountType = "bank"
+    AccountCash       AccountType = "cash"
+    AccountEWallet    AccountType = "ewallet"
+    AccountCreditCard AccountType = "credit_card"
+)
+
+// DefaultAccountName dipakai untuk akun default yang dibuat otomatis,
+// termasuk saat migrasi transaksi lama yang belum punya akun
+const DefaultAccountName = "Akun Utama"
+
+// Account adalah sumber dana sebuah transaksi (rekening BCA, cash, GoPay, ...).
+// Saldo kartu kredit biasanya negatif (utang).
+type Account struct {
+    ID             uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID         uuid.UUID   `gorm:"type:uuid;not null;index;uniqueIndex:idx_accounts_default_per_user,where:is_default = true" json:"user_id"`
+    User           User        `json:"-"`
+    Name           string      `gorm:"not null" json:"name"`
+    Type           AccountType `gorm:"type:varchar(20);not null" json:"type"`
+    Currency       string      `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+    OpeningBalance Money       `gorm:"not null;default:0" json:"opening_balance"`
+    IsDefault      bool        `gorm:"not null;default:false" json:"is_default"`
+    IsArchived     bool        `gorm:"not null;default:false" json:"is_archived"`
+    CreatedAt      time.Time   `json:"created_at"`
+    UpdatedAt      time.Time   `json:"updated_at"`
+
+    Balance Money `gorm:"-" json:"balance"`
+}
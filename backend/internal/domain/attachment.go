@@ -0,0 +1,20 @@
+package domain
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// Attachment adalah file struk (foto/PDF) milik satu transaksi. Isi file ada
+// di storage dengan StorageKey, tabel ini hanya menyimpan metadata.
+type Attachment struct {
+    ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID        uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+    TransactionID uuid.UUID `gorm:"type:uuid;not null;index" json:"transaction_id"`
+    FileName      string    `gorm:"not null" json:"file_name"`
+    ContentType   string    `gorm:"type:varchar(100);not null" json:"content_type"`
+    Size          int64     `gorm:"not null" json:"size"`
+    StorageKey    string    `gorm:"not null" json:"-"`
+    CreatedAt     time.Time `json:"created_at"`
+}
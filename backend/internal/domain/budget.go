@@ -9,11 +9,12 @@ type Budget struct {
     UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_budget_unique" json:"user_id"`
     CategoryID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_budget_unique" json:"category_id"`
     Category   Category  `json:"category"`
-    Amount     float64   `gorm:"not null" json:"amount"`
+    Amount     Money     `gorm:"not null" json:"amount"`
     Month      int       `gorm:"not null;uniqueIndex:idx_budget_unique" json:"month"`
     Year       int       `gorm:"not null;uniqueIndex:idx_budget_unique" json:"year"`
 
-    Spent     float64 `gorm:"-" json:"spent"`
-    Remaining float64 `gorm:"-" json:"remaining"`
-    IsOver    bool    `gorm:"-" json:"is_over"`
+    Spent     Money  `gorm:"-" json:"spent"`
+    Remaining Money  `gorm:"-" json:"remaining"`
+    IsOver    bool   `gorm:"-" json:"is_over"`
+    Currency  string `gorm:"-" json:"currency"`
 }
@@ -0,0 +1,43 @@
+package domain
+
+import (
+    "errors"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// DefaultCurrency: semua nominal dari sebelum multi-currency dianggap IDR
+const DefaultCurrency = "IDR"
+
+var ErrInvalidCurrency = errors.New("invalid currency code, use ISO 4217 (e.g. IDR, USD)")
+
+// NormalizeCurrency mengubah kode mata uang ke huruf besar dan memastikan
+// formatnya 3 huruf ISO 4217
+func NormalizeCurrency(code string) (string, error) {
+    code = strings.ToUpper(strings.TrimSpace(code))
+    if len(code) != 3 {
+        return "", ErrInvalidCurrency
+    }
+    for _, r := range code {
+        if r < 'A' || r > 'Z' {
+            return "", ErrInvalidCurrency
+        }
+    }
+    return code, nil
+}
+
+// ExchangeRate: 1 FromCurrency = Rate ToCurrency pada tanggal Date. Kurs
+// dikelola sendiri oleh user (input manual atau import CSV); transaksi
+// memakai kurs terakhir pada atau sebelum tanggal transaksinya.
+type ExchangeRate struct {
+    ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_exchange_rate_unique" json:"user_id"`
+    FromCurrency string    `gorm:"type:varchar(3);not null;uniqueIndex:idx_exchange_rate_unique" json:"from_currency"`
+    ToCurrency   string    `gorm:"type:varchar(3);not null;uniqueIndex:idx_exchange_rate_unique" json:"to_currency"`
+    Rate         float64   `gorm:"type:numeric(20,10);not null" json:"rate"`
+    Date         time.Time `gorm:"type:date;not null;uniqueIndex:idx_exchange_rate_unique" json:"date"`
+    CreatedAt    time.Time `json:"created_at"`
+    UpdatedAt    time.Time `json:"updated_at"`
+}
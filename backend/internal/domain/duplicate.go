@@ -0,0 +1,97 @@
+package domain
+
+import (
+    "strings"
+    "time"
+    "unicode"
+
+    "github.com/google/uuid"
+)
+
+// DefaultDuplicateWindow: selisih tanggal maksimum dua transaksi yang
+// dianggap kemungkinan duplikat (mis. input manual vs tanggal posting bank)
+const DefaultDuplicateWindow = 3 * 24 * time.Hour
+
+// MinDescriptionSimilarity: ambang DescriptionSimilarity untuk duplikat
+const MinDescriptionSimilarity = 0.5
+
+// DuplicateDismissal menandai pasangan transaksi yang sudah dinyatakan
+// user bukan duplikat. Pasangan disimpan urut (TransactionID < OtherID)
+// supaya satu pasangan hanya punya satu baris.
+type DuplicateDismissal struct {
+    ID            uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID        uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
+    TransactionID uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_duplicate_dismissals_pair" json:"transaction_id"`
+    Transaction   Transaction `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+    OtherID       uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_duplicate_dismissals_pair;index" json:"other_id"`
+    Other         Transaction `gorm:"foreignKey:OtherID;constraint:OnDelete:CASCADE" json:"-"`
+    CreatedAt     time.Time   `json:"created_at"`
+}
+
+// NewDuplicateDismissal membuat dismissal dengan urutan pasangan yang baku
+func NewDuplicateDismissal(userID, a, b uuid.UUID) DuplicateDismissal {
+    if b.String() < a.String() {
+        a, b = b, a
+    }
+    return DuplicateDismissal{ID: uuid.New(), UserID: userID, TransactionID: a, OtherID: b}
+}
+
+// IsLikelyDuplicate: tipe, nominal dan mata uang sama, tanggal berselisih
+// paling lama window, dan deskripsi/payee mirip. Leg transfer tidak pernah
+// dianggap duplikat, begitu pula dua transaksi di akun yang sama dengan
+// FITID berbeda (bank sudah menyatakan keduanya berbeda).
+func IsLikelyDuplicate(a, b *Transaction, window time.Duration) bool {
+    if a.ID == b.ID || a.IsTransferLeg() || b.IsTransferLeg() {
+        return false
+    }
+    if a.Type != b.Type || a.Amount != b.Amount || a.Currency != b.Currency {
+        return false
+    }
+    diff := a.Date.Sub(b.Date)
+    if diff < 0 {
+        diff = -diff
+    }
+    if diff > window {
+        return false
+    }
+    if a.AccountID == b.AccountID && a.ExternalID != nil && b.ExternalID != nil && *a.ExternalID != *b.ExternalID {
+        return false
+    }
+    return DescriptionSimilarity(a.Payee+" "+a.Description, b.Payee+" "+b.Description) >= MinDescriptionSimilarity
+}
+
+// DescriptionSimilarity membandingkan kata (huruf/angka, tidak
+// case-sensitive) dengan overlap coefficient: "Kopi" vs "QRIS KOPI
+// KENANGAN" = 1, karena input manual biasanya lebih singkat dari
+// deskripsi bank. Dua teks kosong dianggap sama, satu kosong = 0.
+func DescriptionSimilarity(a, b string) float64 {
+    wordsA, wordsB := descriptionWords(a), descriptionWords(b)
+    if len(wordsA) == 0 && len(wordsB) == 0 {
+        return 1
+    }
+    if len(wordsA) == 0 || len(wordsB) == 0 {
+        return 0
+    }
+
+    common := 0
+    for w := range wordsA {
+        if wordsB[w] {
+            common++
+        }
+    }
+    smaller := len(wordsA)
+    if len(wordsB) < smaller {
+        smaller = len(wordsB)
+    }
+    return float64(common) / float64(smaller)
+}
+
+func descriptionWords(s string) map[string]bool {
+    words := map[string]bool{}
+    for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+        return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+    }) {
+        words[w] = true
+    }
+    return words
+}
@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+    "testing"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/assert"
+)
+
+func TestDescriptionSimilarity(t *testing.T) {
+    assert.Equal(t, 1.0, domain.DescriptionSimilarity("Kopi", "QRIS KOPI KENANGAN"))
+    assert.Equal(t, 0.5, domain.DescriptionSimilarity("Kopi susu", "kopi-kenangan"))
+    assert.Equal(t, 0.0, domain.DescriptionSimilarity("Parkir", "Bensin"))
+    assert.Equal(t, 1.0, domain.DescriptionSimilarity(" ", ""))
+    assert.Equal(t, 0.0, domain.DescriptionSimilarity("Parkir", ""))
+}
+
+func TestIsLikelyDuplicate(t *testing.T) {
+    account := uuid.New()
+    base := func() *domain.Transaction {
+        return &domain.Transaction{ID: uuid.New(), AccountID: account, Type: domain.Expense,
+            Amount: domain.NewMoney(25000), Currency: "IDR", Description: "Kopi", Date: date("2026-02-01")}
+    }
+    a := base()
+
+    b := base()
+    b.Description = "QRIS Kopi Kenangan"
+    b.Date = date("2026-02-03")
+    assert.True(t, domain.IsLikelyDuplicate(a, b, domain.DefaultDuplicateWindow))
+
+    b.Date = date("2026-02-05")
+    assert.False(t, domain.IsLikelyDuplicate(a, b, domain.DefaultDuplicateWindow))
+
+    c := base()
+    c.Amount = domain.NewMoney(26000)
+    assert.False(t, domain.IsLikelyDuplicate(a, c, domain.DefaultDuplicateWindow))
+
+    // FITID berbeda di akun yang sama: dua transaksi yang memang terpisah
+    d, e := base(), base()
+    fitA, fitB := "A1", "A2"
+    d.ExternalID, e.ExternalID = &fitA, &fitB
+    assert.False(t, domain.IsLikelyDuplicate(d, e, domain.DefaultDuplicateWindow))
+
+    transferID := uuid.New()
+    f := base()
+    f.TransferID = &transferID
+    assert.False(t, domain.IsLikelyDuplicate(a, f, domain.DefaultDuplicateWindow))
+}
+
+func TestNewDuplicateDismissal_OrdersPair(t *testing.T) {
+    a, b := uuid.New(), uuid.New()
+    ab := domain.NewDuplicateDismissal(uuid.Nil, a, b)
+    ba := domain.NewDuplicateDismissal(uuid.Nil, b, a)
+
+    assert.Equal(t, ab.TransactionID, ba.TransactionID)
+    assert.Equal(t, ab.OtherID, ba.OtherID)
+}
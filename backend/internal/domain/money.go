@@ -0,0 +1,175 @@
+package domain
+
+import (
+    "database/sql/driver"
+    "errors"
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+)
+
+// Money adalah nominal uang dalam satuan terkecil (1/100, mis. sen) supaya
+// penjumlahan selalu eksak. Di database disimpan sebagai NUMERIC(18,2),
+// di JSON tetap berupa angka biasa (50000, 12.5) seperti saat masih float64.
+type Money int64
+
+const moneyScale = 100
+
+var ErrInvalidMoney = errors.New("invalid money amount, max 2 decimal places")
+
+// NewMoney membuat Money dari nominal utuh, mis. NewMoney(50000) = 50.000,00
+func NewMoney(major int64) Money {
+    return Money(major * moneyScale)
+}
+
+// MoneyFromFloat dibulatkan ke sen terdekat; hanya untuk data yang
+// memang berasal dari float (mis. hasil konversi kurs)
+func MoneyFromFloat(f float64) Money {
+    return Money(math.Round(f * moneyScale))
+}
+
+// ParseMoney mem-parse angka desimal ("12500", "-12.5", "0.05") tanpa
+// melewati float agar tidak ada pembulatan
+func ParseMoney(s string) (Money, error) {
+    s = strings.TrimSpace(s)
+    if s == "" {
+        return 0, ErrInvalidMoney
+    }
+
+    negative := false
+    switch s[0] {
+    case '-':
+        negative = true
+        s = s[1:]
+    case '+':
+        s = s[1:]
+    }
+    if s == "" {
+        return 0, ErrInvalidMoney
+    }
+
+    // Setelah tanda di depan hanya boleh digit, supaya ParseInt tidak
+    // menerima tanda kedua ("--5.5", "5.+5")
+    whole, frac, _ := strings.Cut(s, ".")
+    if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+        return 0, ErrInvalidMoney
+    }
+    if whole == "" {
+        whole = "0"
+    }
+    if len(frac) > 2 {
+        // Terima nol berlebih dari NUMERIC/JSON, mis. "12.500"
+        if strings.Trim(frac[2:], "0") != "" {
+            return 0, ErrInvalidMoney
+        }
+        frac = frac[:2]
+    }
+    for len(frac) < 2 {
+        frac += "0"
+    }
+
+    units, err := strconv.ParseInt(whole, 10, 64)
+    if err != nil || units > math.MaxInt64/moneyScale {
+        return 0, ErrInvalidMoney
+    }
+    cents, err := strconv.ParseInt(frac, 10, 64)
+    if err != nil || cents < 0 {
+        return 0, ErrInvalidMoney
+    }
+
+    m := Money(units*moneyScale + cents)
+    if negative {
+        m = -m
+    }
+    return m, nil
+}
+
+// Convert mengalikan dengan kurs lalu membulatkan ke sen terdekat
+func (m Money) Convert(rate float64) Money {
+    return Money(math.Round(float64(m) * rate))
+}
+
+// Float64 hanya untuk tampilan/grafik, jangan dipakai untuk hitungan
+func (m Money) Float64() float64 {
+    return float64(m) / moneyScale
+}
+
+// String menghasilkan angka tanpa nol berlebih: "50000", "12.5", "-0.05"
+func (m Money) String() string {
+    sign := ""
+    v := int64(m)
+    if v < 0 {
+        sign = "-"
+        v = -v
+    }
+
+    whole, cents := v/moneyScale, v%moneyScale
+    if cents == 0 {
+        return fmt.Sprintf("%s%d", sign, whole)
+    }
+    return strings.TrimRight(fmt.Sprintf("%s%d.%02d", sign, whole, cents), "0")
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+    return []byte(m.String()), nil
+}
+
+// UnmarshalJSON menerima angka (50000, 12.5) maupun string ("12.50")
+func (m *Money) UnmarshalJSON(data []byte) error {
+    s := strings.Trim(string(data), `"`)
+    if s == "null" {
+        return nil
+    }
+    parsed, err := ParseMoney(s)
+    if err != nil {
+        return err
+    }
+    *m = parsed
+    return nil
+}
+
+func (m *Money) Scan(src interface{}) error {
+    switch v := src.(type) {
+    case nil:
+        *m = 0
+        return nil
+    case int64:
+        *m = NewMoney(v)
+        return nil
+    case float64:
+        *m = MoneyFromFloat(v)
+        return nil
+    case []byte:
+        return m.scanString(string(v))
+    case string:
+        return m.scanString(v)
+    }
+    return fmt.Errorf("cannot scan %T into Money", src)
+}
+
+func (m *Money) scanString(s string) error {
+    parsed, err := ParseMoney(s)
+    if err != nil {
+        return err
+    }
+    *m = parsed
+    return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+    return m.String(), nil
+}
+
+func (Money) GormDataType() string {
+    return "numeric(18,2)"
+}
+
+func isDigits(s string) bool {
+    for i := 0; i < len(s); i++ {
+        if s[i] < '0' || s[i] > '9' {
+            return false
+        }
+    }
+    return true
+}
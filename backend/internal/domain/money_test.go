@@ -0,0 +1,78 @@
+package domain_test
+
+import (
+    "encoding/json"
+    "testing"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+    cases := map[string]domain.Money{
+        "50000":     domain.NewMoney(50000),
+        "12.5":      1250,
+        "12.50":     1250,
+        "0.05":      5,
+        "-3.1":      -310,
+        ".75":       75,
+        "100.000":   domain.NewMoney(100), // NUMERIC dengan scale lebih besar
+        "1000.10 ":  100010,
+    }
+    for in, want := range cases {
+        got, err := domain.ParseMoney(in)
+        assert.NoError(t, err, in)
+        assert.Equal(t, want, got, in)
+    }
+
+    for _, in := range []string{"", "-", "abc", "1.234", "1,5", "1.2.3", ".", "-.", "--5.5", "+-5", "5.+5", "5.-5", "1 000"} {
+        _, err := domain.ParseMoney(in)
+        assert.Error(t, err, in)
+    }
+}
+
+func TestMoney_SumIsExact(t *testing.T) {
+    // 0.1 + 0.2 di float64 menghasilkan 0.30000000000000004
+    var total domain.Money
+    for i := 0; i < 1000; i++ {
+        m, _ := domain.ParseMoney("0.1")
+        total += m
+    }
+    assert.Equal(t, domain.NewMoney(100), total)
+    assert.Equal(t, "100", total.String())
+}
+
+func TestMoney_JSONBackwardCompatible(t *testing.T) {
+    data, err := json.Marshal(struct {
+        Amount domain.Money `json:"amount"`
+        Fee    domain.Money `json:"fee"`
+    }{domain.NewMoney(50000), 1250})
+
+    assert.NoError(t, err)
+    assert.JSONEq(t, `{"amount": 50000, "fee": 12.5}`, string(data))
+
+    var input struct {
+        Amount domain.Money `json:"amount"`
+        Fee    domain.Money `json:"fee"`
+    }
+    err = json.Unmarshal([]byte(`{"amount": 75000, "fee": "2.50"}`), &input)
+    assert.NoError(t, err)
+    assert.Equal(t, domain.NewMoney(75000), input.Amount)
+    assert.Equal(t, domain.Money(250), input.Fee)
+
+    err = json.Unmarshal([]byte(`{"amount": 1.005}`), &input)
+    assert.Error(t, err)
+}
+
+func TestMoney_Scan(t *testing.T) {
+    var m domain.Money
+
+    assert.NoError(t, m.Scan([]byte("123456.78")))
+    assert.Equal(t, domain.Money(12345678), m)
+
+    assert.NoError(t, m.Scan(int64(0)))
+    assert.Equal(t, domain.Money(0), m)
+
+    assert.NoError(t, m.Scan(nil))
+    assert.Equal(t, domain.Money(0), m)
+}
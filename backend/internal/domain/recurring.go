@@ -0,0 +1,163 @@
+package domain
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+)
+
+type RecurrenceFrequency string
+
+const (
+    FrequencyDaily   RecurrenceFrequency = "daily"
+    FrequencyWeekly  RecurrenceFrequency = "weekly"
+    FrequencyMonthly RecurrenceFrequency = "monthly"
+    FrequencyYearly  RecurrenceFrequency = "yearly"
+)
+
+// RecurringRule membuat transaksi berulang (sewa, gaji, langganan) secara
+// otomatis lewat scheduler. Jadwal dihitung dari AnchorDate: occurrence ke-n
+// adalah AnchorDate + n × Interval, sehingga tanggal tidak bergeser walaupun
+// ada bulan yang lebih pendek. OccurrenceCount hanya menghitung transaksi
+// yang benar-benar dibuat; occurrence yang di-skip atau terlewat selama
+// pause tidak mengurangi jatah MaxOccurrences.
+type RecurringRule struct {
+    ID          uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID      uuid.UUID       `gorm:"type:uuid;not null;index" json:"user_id"`
+    User        User            `json:"-"`
+    AccountID   uuid.UUID       `gorm:"type:uuid;not null" json:"account_id"`
+    Account     Account         `json:"account"`
+    CategoryID  uuid.UUID       `gorm:"type:uuid;not null" json:"category_id"`
+    Category    Category        `json:"category"`
+    Type        TransactionType `gorm:"type:varchar(20);not null" json:"type"`
+    Amount      Money           `gorm:"not null" json:"amount"`
+    Description string          `json:"description"`
+
+    Frequency RecurrenceFrequency `gorm:"type:varchar(10);not null" json:"frequency"`
+    Interval  int                 `gorm:"not null;default:1" json:"interval"`
+    // DayOfMonth untuk monthly/yearly (1-31), otomatis jadi tanggal terakhir
+    // di bulan yang lebih pendek; 0 = ikut tanggal mulai
+    DayOfMonth     int        `gorm:"not null;default:0" json:"day_of_month"`
+    StartDate      time.Time  `gorm:"type:date;not null" json:"start_date"`
+    EndDate        *time.Time `gorm:"type:date" json:"end_date"`
+    MaxOccurrences *int       `json:"max_occurrences"`
+
+    AnchorDate      time.Time `gorm:"type:date;not null" json:"-"`
+    AnchorIndex     int       `gorm:"not null;default:0" json:"-"`
+    OccurrenceCount int       `gorm:"not null;default:0" json:"occurrence_count"`
+    NextRunDate     time.Time `gorm:"type:date;not null;index" json:"next_run_date"`
+    IsPaused        bool      `gorm:"not null;default:false" json:"is_paused"`
+    IsFinished      bool      `gorm:"not null;default:false" json:"is_finished"`
+    CreatedAt       time.Time `json:"created_at"`
+    UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// DateOnly membuang jam, semua tanggal jadwal disimpan sebagai UTC 00:00
+func DateOnly(t time.Time) time.Time {
+    return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysIn(year int, month time.Month) int {
+    return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// dayInMonth membuat tanggal dengan day di-clamp ke akhir bulan (31 → 28 Feb)
+func dayInMonth(year int, month time.Month, day int) time.Time {
+    first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+    if max := daysIn(first.Year(), first.Month()); day > max {
+        day = max
+    }
+    return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func (r *RecurringRule) interval() int {
+    if r.Interval < 1 {
+        return 1
+    }
+    return r.Interval
+}
+
+// OccurrenceDate menghitung tanggal occurrence ke-n sejak AnchorDate
+func (r *RecurringRule) OccurrenceDate(n int) time.Time {
+    anchor := DateOnly(r.AnchorDate)
+    step := n * r.interval()
+
+    switch r.Frequency {
+    case FrequencyDaily:
+        return anchor.AddDate(0, 0, step)
+    case FrequencyWeekly:
+        return anchor.AddDate(0, 0, 7*step)
+    case FrequencyYearly:
+        day := r.DayOfMonth
+        if day == 0 {
+            day = anchor.Day()
+        }
+        first := dayInMonth(anchor.Year(), anchor.Month(), day)
+        if first.Before(anchor) {
+            first = dayInMonth(anchor.Year()+1, anchor.Month(), day)
+        }
+        return dayInMonth(first.Year()+step, first.Month(), day)
+    default: // monthly
+        day := r.DayOfMonth
+        if day == 0 {
+            day = anchor.Day()
+        }
+        first := dayInMonth(anchor.Year(), anchor.Month(), day)
+        if first.Before(anchor) {
+            first = dayInMonth(anchor.Year(), anchor.Month()+1, day)
+        }
+        return dayInMonth(first.Year(), first.Month()+time.Month(step), day)
+    }
+}
+
+// Reschedule memulai ulang jadwal dari from, dipakai saat rule dibuat atau
+// pola jadwalnya diubah
+func (r *RecurringRule) Reschedule(from time.Time) {
+    r.AnchorDate = DateOnly(from)
+    r.AnchorIndex = 0
+    r.NextRunDate = r.OccurrenceDate(0)
+    r.CheckFinished()
+}
+
+// Advance melewati occurrence saat ini tanpa menghitungnya (skip, pause)
+func (r *RecurringRule) Advance() {
+    r.AnchorIndex++
+    r.NextRunDate = r.OccurrenceDate(r.AnchorIndex)
+    r.CheckFinished()
+}
+
+// Materialized mencatat occurrence saat ini sudah dibuat sebagai transaksi
+// lalu maju ke occurrence berikutnya
+func (r *RecurringRule) Materialized() {
+    r.OccurrenceCount++
+    r.Advance()
+}
+
+// CheckFinished menandai rule selesai jika sudah lewat EndDate atau
+// mencapai MaxOccurrences
+func (r *RecurringRule) CheckFinished() {
+    r.IsFinished = (r.EndDate != nil && r.NextRunDate.After(DateOnly(*r.EndDate))) ||
+        (r.MaxOccurrences != nil && r.OccurrenceCount >= *r.MaxOccurrences)
+}
+
+// Due: masih ada occurrence yang harus dibuat sampai hari ini
+func (r *RecurringRule) Due(today time.Time) bool {
+    return !r.IsPaused && !r.IsFinished && !r.NextRunDate.After(DateOnly(today))
+}
+
+// NextTransaction membuat transaksi untuk occurrence NextRunDate
+func (r *RecurringRule) NextTransaction(currency string) Transaction {
+    categoryID, ruleID := r.CategoryID, r.ID
+    return Transaction{
+        ID:              uuid.New(),
+        UserID:          r.UserID,
+        CategoryID:      &categoryID,
+        AccountID:       r.AccountID,
+        RecurringRuleID: &ruleID,
+        Type:            r.Type,
+        Amount:          r.Amount,
+        Currency:        currency,
+        Description:     r.Description,
+        Date:            r.NextRunDate,
+    }
+}
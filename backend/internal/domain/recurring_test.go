@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+    "testing"
+    "time"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+    d, _ := time.Parse("2006-01-02", s)
+    return d
+}
+
+func TestRecurringRule_MonthlyClampsToMonthEnd(t *testing.T) {
+    rule := &domain.RecurringRule{Frequency: domain.FrequencyMonthly, Interval: 1, DayOfMonth: 31}
+    rule.Reschedule(date("2026-01-31"))
+
+    var got []string
+    for i := 0; i < 4; i++ {
+        got = append(got, rule.NextRunDate.Format("2006-01-02"))
+        rule.Advance()
+    }
+    // Tanggal kembali ke 31 setelah Februari, tidak ikut bergeser ke 28
+    assert.Equal(t, []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30"}, got)
+}
+
+func TestRecurringRule_MonthlyDayBeforeStartMovesToNextMonth(t *testing.T) {
+    rule := &domain.RecurringRule{Frequency: domain.FrequencyMonthly, Interval: 1, DayOfMonth: 5}
+    rule.Reschedule(date("2026-03-10"))
+
+    assert.Equal(t, date("2026-04-05"), rule.NextRunDate)
+}
+
+func TestRecurringRule_WeeklyInterval(t *testing.T) {
+    rule := &domain.RecurringRule{Frequency: domain.FrequencyWeekly, Interval: 2}
+    rule.Reschedule(date("2026-03-02"))
+    rule.Advance()
+
+    assert.Equal(t, date("2026-03-16"), rule.NextRunDate)
+}
+
+func TestRecurringRule_YearlyLeapDay(t *testing.T) {
+    rule := &domain.RecurringRule{Frequency: domain.FrequencyYearly, Interval: 1, DayOfMonth: 29}
+    rule.Reschedule(date("2028-02-29"))
+    rule.Advance()
+
+    assert.Equal(t, date("2029-02-28"), rule.NextRunDate)
+}
+
+func TestRecurringRule_FinishesAtMaxOccurrences(t *testing.T) {
+    max := 2
+    rule := &domain.RecurringRule{Frequency: domain.FrequencyDaily, Interval: 1, MaxOccurrences: &max}
+    rule.Reschedule(date("2026-03-01"))
+
+    rule.Materialized()
+    assert.False(t, rule.IsFinished)
+    rule.Materialized()
+    assert.True(t, rule.IsFinished)
+    assert.False(t, rule.Due(date("2026-12-31")))
+}
+
+func TestRecurringRule_SkippedOccurrencesDoNotCount(t *testing.T) {
+    max := 2
+    rule := &domain.RecurringRule{Frequency: domain.FrequencyDaily, Interval: 1, MaxOccurrences: &max}
+    rule.Reschedule(date("2026-03-01"))
+
+    rule.Advance()
+    rule.Advance()
+    rule.Materialized()
+
+    assert.Equal(t, 1, rule.OccurrenceCount)
+    assert.False(t, rule.IsFinished)
+    assert.Equal(t, date("2026-03-04"), rule.NextRunDate)
+}
+
+func TestRecurringRule_FinishesAfterEndDate(t *testing.T) {
+    end := date("2026-03-03")
+    rule := &domain.RecurringRule{Frequency: domain.FrequencyDaily, Interval: 1, EndDate: &end}
+    rule.Reschedule(date("2026-03-01"))
+
+    count := 0
+    for rule.Due(date("2026-03-31")) {
+        count++
+        rule.Advance()
+    }
+    assert.Equal(t, 3, count)
+    assert.True(t, rule.IsFinished)
+}
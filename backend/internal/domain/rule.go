@@ -0,0 +1,152 @@
+package domain
+
+import (
+    "errors"
+    "regexp"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// MaxRuleRegexLength membatasi panjang description_regex
+const MaxRuleRegexLength = 200
+
+var (
+    ErrRuleNoCondition = errors.New("rule needs at least one condition")
+    ErrRuleNoAction    = errors.New("rule needs at least one action: category, tags or payee")
+)
+
+// TransactionRule mengisi kategori, tag dan payee transaksi secara
+// otomatis. Semua kondisi yang diisi harus cocok. Rule dievaluasi urut
+// Priority (kecil dulu); untuk kategori dan payee, rule pertama yang
+// cocok yang menang, tag dari semua rule yang cocok digabung.
+type TransactionRule struct {
+    ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID   uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+    Name     string    `gorm:"type:varchar(100);not null" json:"name"`
+    Priority int       `gorm:"not null;default:0" json:"priority"`
+    IsActive bool      `gorm:"not null;default:true" json:"is_active"`
+
+    // Kondisi. Contains/regex dicocokkan dengan deskripsi maupun payee;
+    // contains tidak case-sensitive, regex pakai (?i) jika perlu
+    DescriptionContains string          `gorm:"type:varchar(255)" json:"description_contains"`
+    DescriptionRegex    string          `gorm:"type:varchar(200)" json:"description_regex"`
+    MinAmount           *Money          `json:"min_amount"`
+    MaxAmount           *Money          `json:"max_amount"`
+    AccountID           *uuid.UUID      `gorm:"type:uuid" json:"account_id"`
+    Type                TransactionType `gorm:"type:varchar(20)" json:"type"` // kosong = income & expense
+
+    // Aksi
+    CategoryID *uuid.UUID `gorm:"type:uuid;index" json:"category_id"`
+    Category   *Category  `gorm:"constraint:OnDelete:SET NULL" json:"category,omitempty"`
+    Tags       []Tag      `gorm:"many2many:transaction_rule_tags;constraint:OnDelete:CASCADE" json:"tags"`
+    SetPayee   string     `gorm:"type:varchar(255)" json:"set_payee"`
+
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+
+    pattern *regexp.Regexp
+}
+
+// Validate cek rule punya kondisi & aksi, dan regex-nya valid
+func (r *TransactionRule) Validate() error {
+    if r.DescriptionContains == "" && r.DescriptionRegex == "" && r.MinAmount == nil &&
+        r.MaxAmount == nil && r.AccountID == nil && r.Type == "" {
+        return ErrRuleNoCondition
+    }
+    if r.CategoryID == nil && len(r.Tags) == 0 && r.SetPayee == "" {
+        return ErrRuleNoAction
+    }
+    if r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount > *r.MaxAmount {
+        return errors.New("min_amount must not be greater than max_amount")
+    }
+    if r.DescriptionRegex != "" {
+        if len(r.DescriptionRegex) > MaxRuleRegexLength {
+            return errors.New("description_regex is too long, max 200 characters")
+        }
+        pattern, err := regexp.Compile(r.DescriptionRegex)
+        if err != nil {
+            return errors.New("invalid description_regex: " + err.Error())
+        }
+        r.pattern = pattern
+    }
+    return nil
+}
+
+// Matches cek semua kondisi rule terhadap transaksi. Leg transfer tidak
+// pernah cocok.
+func (r *TransactionRule) Matches(tx *Transaction) bool {
+    if tx.IsTransferLeg() || tx.Type == TransferIn || tx.Type == TransferOut {
+        return false
+    }
+    if r.Type != "" && r.Type != tx.Type {
+        return false
+    }
+    if r.AccountID != nil && *r.AccountID != tx.AccountID {
+        return false
+    }
+    if r.MinAmount != nil && tx.Amount < *r.MinAmount {
+        return false
+    }
+    if r.MaxAmount != nil && tx.Amount > *r.MaxAmount {
+        return false
+    }
+    if r.DescriptionContains != "" {
+        needle := strings.ToLower(r.DescriptionContains)
+        if !strings.Contains(strings.ToLower(tx.Description), needle) &&
+            !strings.Contains(strings.ToLower(tx.Payee), needle) {
+            return false
+        }
+    }
+    if r.DescriptionRegex != "" {
+        if r.pattern == nil {
+            pattern, err := regexp.Compile(r.DescriptionRegex)
+            if err != nil {
+                return false
+            }
+            r.pattern = pattern
+        }
+        if !r.pattern.MatchString(tx.Description) && !r.pattern.MatchString(tx.Payee) {
+            return false
+        }
+    }
+    return true
+}
+
+// RuleOutcome: hasil gabungan semua rule yang cocok dengan satu transaksi
+type RuleOutcome struct {
+    Category *Category
+    Payee    string
+    Tags     []Tag
+    Rules    []string // nama rule yang cocok, urut prioritas
+}
+
+// ApplyRules mengevaluasi rules (sudah urut prioritas) terhadap tx tanpa
+// mengubahnya. Kategori rule yang di-archive atau tidak cocok dengan tipe
+// transaksi dilewati, sehingga rule berikutnya yang dipakai.
+func ApplyRules(rules []TransactionRule, tx *Transaction) RuleOutcome {
+    var outcome RuleOutcome
+    seenTags := map[uuid.UUID]bool{}
+    for i := range rules {
+        rule := &rules[i]
+        if !rule.IsActive || !rule.Matches(tx) {
+            continue
+        }
+        outcome.Rules = append(outcome.Rules, rule.Name)
+
+        if outcome.Category == nil && rule.Category != nil && !rule.Category.IsArchived && rule.Category.Allows(tx.Type) {
+            outcome.Category = rule.Category
+        }
+        if outcome.Payee == "" {
+            outcome.Payee = rule.SetPayee
+        }
+        for _, tag := range rule.Tags {
+            if !seenTags[tag.ID] {
+                seenTags[tag.ID] = true
+                outcome.Tags = append(outcome.Tags, tag)
+            }
+        }
+    }
+    return outcome
+}
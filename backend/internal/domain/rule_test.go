@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+    "testing"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/assert"
+)
+
+func money(v int64) *domain.Money {
+    m := domain.NewMoney(v)
+    return &m
+}
+
+func TestTransactionRule_Validate(t *testing.T) {
+    catID := uuid.New()
+
+    assert.ErrorIs(t, (&domain.TransactionRule{CategoryID: &catID}).Validate(), domain.ErrRuleNoCondition)
+    assert.ErrorIs(t, (&domain.TransactionRule{DescriptionContains: "grab"}).Validate(), domain.ErrRuleNoAction)
+    assert.ErrorContains(t, (&domain.TransactionRule{DescriptionRegex: "(grab", SetPayee: "Grab"}).Validate(), "invalid description_regex")
+    assert.ErrorContains(t, (&domain.TransactionRule{MinAmount: money(10), MaxAmount: money(5), SetPayee: "X"}).Validate(), "min_amount")
+    assert.NoError(t, (&domain.TransactionRule{DescriptionRegex: `(?i)^grab\*`, SetPayee: "Grab"}).Validate())
+}
+
+func TestTransactionRule_Matches(t *testing.T) {
+    account := uuid.New()
+    tx := &domain.Transaction{AccountID: account, Type: domain.Expense, Amount: domain.NewMoney(32000),
+        Description: "Pembayaran", Payee: "GRAB* A-5XYZ"}
+
+    // Contains dicocokkan juga dengan payee, tidak case-sensitive
+    assert.True(t, (&domain.TransactionRule{DescriptionContains: "grab"}).Matches(tx))
+    assert.True(t, (&domain.TransactionRule{DescriptionRegex: `^GRAB\*`, AccountID: &account}).Matches(tx))
+    assert.True(t, (&domain.TransactionRule{MinAmount: money(30000), MaxAmount: money(32000)}).Matches(tx))
+    assert.False(t, (&domain.TransactionRule{MaxAmount: money(31999)}).Matches(tx))
+    assert.False(t, (&domain.TransactionRule{Type: domain.Income}).Matches(tx))
+    assert.False(t, (&domain.TransactionRule{DescriptionContains: "gojek"}).Matches(tx))
+
+    transferID := uuid.New()
+    leg := &domain.Transaction{TransferID: &transferID, Type: domain.TransferOut, Description: "grab"}
+    assert.False(t, (&domain.TransactionRule{DescriptionContains: "grab"}).Matches(leg))
+}
+
+func TestApplyRules_PriorityAndTags(t *testing.T) {
+    transport := &domain.Category{ID: uuid.New(), Name: "Transport", Kind: domain.CategoryKindExpense}
+    gaji      := &domain.Category{ID: uuid.New(), Name: "Gaji", Kind: domain.CategoryKindIncome}
+    kantor    := domain.Tag{ID: uuid.New(), Name: "kantor"}
+    ojol      := domain.Tag{ID: uuid.New(), Name: "ojol"}
+
+    rules := []domain.TransactionRule{
+        // Kategori income tidak bisa dipakai transaksi expense: dilewati
+        {Name: "salah kategori", IsActive: true, DescriptionContains: "grab", CategoryID: &gaji.ID, Category: gaji},
+        {Name: "nonaktif", DescriptionContains: "grab", SetPayee: "Nonaktif"},
+        {Name: "grab", IsActive: true, DescriptionContains: "grab", CategoryID: &transport.ID, Category: transport,
+            SetPayee: "Grab", Tags: []domain.Tag{ojol}},
+        {Name: "kantor", IsActive: true, MinAmount: money(1), SetPayee: "Lain", Tags: []domain.Tag{kantor, ojol}},
+    }
+
+    outcome := domain.ApplyRules(rules, &domain.Transaction{Type: domain.Expense, Amount: domain.NewMoney(32000), Payee: "GRAB* A-5XYZ"})
+
+    assert.Equal(t, transport, outcome.Category)
+    assert.Equal(t, "Grab", outcome.Payee)
+    assert.Equal(t, []domain.Tag{ojol, kantor}, outcome.Tags)
+    assert.Equal(t, []string{"salah kategori", "grab", "kantor"}, outcome.Rules)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+    "time"
+    "github.com/google/uuid"
+)
+
+// Session mewakili satu "family" login. Semua refresh token hasil rotasi
+// dari satu login berbagi SessionID yang sama, sehingga revoke session
+// otomatis mematikan seluruh rantai token-nya.
+type Session struct {
+    ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+    User      User       `json:"-"`
+    RevokedAt *time.Time `json:"revoked_at"`
+    CreatedAt time.Time  `json:"created_at"`
+    UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (s *Session) IsRevoked() bool {
+    return s.RevokedAt != nil
+}
+
+// RefreshToken hanya disimpan dalam bentuk hash; token asli hanya dikirim
+// sekali ke client.
+type RefreshToken struct {
+    ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    SessionID uuid.UUID  `gorm:"type:uuid;not null;index" json:"session_id"`
+    Session   Session    `json:"-"`
+    TokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+    ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+    UsedAt    *time.Time `json:"used_at"`
+    CreatedAt time.Time  `json:"created_at"`
+}
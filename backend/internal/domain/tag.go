@@ -0,0 +1,33 @@
+package domain
+
+import (
+    "errors"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// MaxTagLength membatasi panjang nama tag
+const MaxTagLength = 50
+
+var ErrInvalidTag = errors.New("tag name must be 1-50 characters")
+
+// Tag adalah label bebas lintas kategori, mis. "trip-bali-2026" atau
+// "reimbursable". Nama disimpan lowercase dan unik per user.
+type Tag struct {
+    ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tags_user_name" json:"user_id"`
+    Name      string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_tags_user_name" json:"name"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NormalizeTagName merapikan nama tag: trim, lowercase, spasi jadi "-"
+func NormalizeTagName(name string) (string, error) {
+    name = strings.Join(strings.Fields(strings.ToLower(name)), "-")
+    if name == "" || len([]rune(name)) > MaxTagLength {
+        return "", ErrInvalidTag
+    }
+    return name, nil
+}
@@ -10,24 +10,104 @@ type TransactionType string
 const (
     Income  TransactionType = "income"
     Expense TransactionType = "expense"
+    // Leg transfer antar akun: hanya menggeser saldo akun, tidak dihitung
+    // sebagai pemasukan/pengeluaran di summary maupun budget
+    TransferOut TransactionType = "transfer_out"
+    TransferIn  TransactionType = "transfer_in"
 )
 
+// CategoryKind menentukan tipe transaksi yang boleh memakai kategori
+type CategoryKind string
+
+const (
+    CategoryKindIncome  CategoryKind = "income"
+    CategoryKindExpense CategoryKind = "expense"
+    CategoryKindBoth    CategoryKind = "both"
+)
+
+// MaxCategoryDepth membatasi kedalaman sub-kategori, mis. "Makanan > Restoran"
+const MaxCategoryDepth = 2
+
+// Category dengan UserID nil adalah kategori sistem (hasil seed) yang
+// bisa dipakai semua user tapi tidak bisa diubah; selain itu milik satu user.
+// Kategori yang di-archive disembunyikan dari picker tapi histori
+// transaksinya tetap ada.
 type Category struct {
-    ID   uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-    Name string    `gorm:"not null" json:"name"`
-    Icon string    `json:"icon"`
+    ID         uuid.UUID    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID     *uuid.UUID   `gorm:"type:uuid;index" json:"user_id"`
+    ParentID   *uuid.UUID   `gorm:"type:uuid;index" json:"parent_id"`
+    Name       string       `gorm:"not null" json:"name"`
+    Icon       string       `json:"icon"`
+    Kind       CategoryKind `gorm:"type:varchar(10);not null;default:'both'" json:"kind"`
+    IsArchived bool         `gorm:"not null;default:false" json:"is_archived"`
+}
+
+func (c *Category) IsSystem() bool {
+    return c.UserID == nil
+}
+
+// Allows cek apakah kategori boleh dipakai untuk tipe transaksi tersebut
+func (c *Category) Allows(t TransactionType) bool {
+    return c.Kind == "" || c.Kind == CategoryKindBoth || string(c.Kind) == string(t)
 }
 
 type Transaction struct {
     ID          uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-    UserID      uuid.UUID       `gorm:"type:uuid;not null" json:"user_id"`
+    UserID      uuid.UUID       `gorm:"type:uuid;not null;index:idx_transactions_user_date,priority:1" json:"user_id"`
     User        User            `json:"-"`
-    CategoryID  uuid.UUID       `gorm:"type:uuid;not null" json:"category_id"`
+    CategoryID  *uuid.UUID      `gorm:"type:uuid" json:"category_id"` // nil untuk leg transfer
     Category    Category        `json:"category"`
-    Type        TransactionType `gorm:"type:varchar(10);not null" json:"type"`
-    Amount      float64         `gorm:"not null" json:"amount"`
+    AccountID   uuid.UUID       `gorm:"type:uuid;not null;index;uniqueIndex:idx_transactions_external_id,priority:1" json:"account_id"`
+    Account     Account         `json:"account"`
+    TransferID  *uuid.UUID      `gorm:"type:uuid;index" json:"transfer_id"`
+    // RecurringRuleID + Date unik supaya scheduler tidak membuat occurrence ganda
+    RecurringRuleID *uuid.UUID  `gorm:"type:uuid;uniqueIndex:idx_recurring_occurrence" json:"recurring_rule_id"`
+    Type        TransactionType `gorm:"type:varchar(20);not null" json:"type"`
+    Amount      Money           `gorm:"not null" json:"amount"`
+    Currency    string          `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"` // selalu sama dengan akunnya
     Description string          `json:"description"`
-    Date        time.Time       `gorm:"not null;default:now()" json:"date"`
+    Payee       string          `gorm:"type:varchar(255)" json:"payee"` // penerima/merchant, opsional
+    // ExternalID: ID transaksi dari bank (FITID), unik per akun supaya
+    // import ulang statement yang sama tidak menggandakan transaksi
+    ExternalID  *string         `gorm:"type:varchar(255);uniqueIndex:idx_transactions_external_id,priority:2" json:"external_id,omitempty"`
+    Date        time.Time       `gorm:"not null;default:now();uniqueIndex:idx_recurring_occurrence;index:idx_transactions_user_date,priority:2" json:"date"`
+    // Splits membagi satu transaksi ke beberapa kategori (mis. satu struk
+    // supermarket); jumlahnya selalu sama dengan Amount
+    Splits      []TransactionSplit `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE" json:"splits"`
+    Tags        []Tag           `gorm:"many2many:transaction_tags;constraint:OnDelete:CASCADE" json:"tags"`
+    Attachments []Attachment    `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE" json:"attachments"`
     CreatedAt   time.Time       `json:"created_at"`
     UpdatedAt   time.Time       `json:"updated_at"`
 }
+
+// IsTransferLeg: leg transfer hanya boleh diubah lewat Transfer-nya
+func (t *Transaction) IsTransferLeg() bool {
+    return t.TransferID != nil
+}
+
+// TransactionSplit adalah satu baris pembagian kategori dalam transaksi
+type TransactionSplit struct {
+    ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    TransactionID uuid.UUID `gorm:"type:uuid;not null;index" json:"transaction_id"`
+    CategoryID    uuid.UUID `gorm:"type:uuid;not null;index" json:"category_id"`
+    Category      Category  `json:"category"`
+    Amount        Money     `gorm:"not null" json:"amount"`
+    Note          string    `json:"note"`
+    CreatedAt     time.Time `json:"created_at"`
+}
+
+// CategoryAmounts membagi Amount ke kategori: per split jika ada,
+// selain itu seluruhnya ke CategoryID
+func (t *Transaction) CategoryAmounts() map[uuid.UUID]Money {
+    amounts := map[uuid.UUID]Money{}
+    if len(t.Splits) > 0 {
+        for _, split := range t.Splits {
+            amounts[split.CategoryID] += split.Amount
+        }
+        return amounts
+    }
+    if t.CategoryID != nil {
+        amounts[*t.CategoryID] = t.Amount
+    }
+    return amounts
+}
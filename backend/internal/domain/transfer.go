@@ -0,0 +1,31 @@
+package domain
+
+import (
+    "time"
+    "github.com/google/uuid"
+)
+
+// Transfer memindahkan uang antar akun milik user yang sama. Transfer dicatat
+// sebagai pasangan leg transaksi (transfer_out di akun asal, transfer_in di
+// akun tujuan) yang dibuat dan dihapus bersamaan. Biaya transfer (Fee)
+// dicatat sebagai leg pengeluaran biasa di akun asal, sehingga tetap masuk
+// summary dan budget kategori biayanya.
+type Transfer struct {
+    ID            uuid.UUID     `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+    UserID        uuid.UUID     `gorm:"type:uuid;not null;index" json:"user_id"`
+    User          User          `json:"-"`
+    FromAccountID uuid.UUID     `gorm:"type:uuid;not null" json:"from_account_id"`
+    FromAccount   Account       `json:"from_account"`
+    ToAccountID   uuid.UUID     `gorm:"type:uuid;not null" json:"to_account_id"`
+    ToAccount     Account       `json:"to_account"`
+    Amount        Money         `gorm:"not null" json:"amount"`
+    // ToAmount: nominal yang diterima akun tujuan (beda dari Amount jika
+    // mata uang kedua akun berbeda)
+    ToAmount      Money         `gorm:"not null;default:0" json:"to_amount"`
+    Fee           Money         `gorm:"not null;default:0" json:"fee"`
+    Description   string        `json:"description"`
+    Date          time.Time     `gorm:"not null" json:"date"`
+    Legs          []Transaction `gorm:"foreignKey:TransferID;constraint:OnDelete:CASCADE" json:"legs"`
+    CreatedAt     time.Time     `json:"created_at"`
+    UpdatedAt     time.Time     `json:"updated_at"`
+}
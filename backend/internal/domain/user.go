@@ -11,6 +11,8 @@ type User struct {
     Email     string    `gorm:"uniqueIndex;not null" json:"email"`
     Password  string    `gorm:"not null" json:"-"`
     IsVerified bool      `gorm:"default:false" json:"is_verified"`
+    // BaseCurrency: mata uang untuk summary dan budget
+    BaseCurrency string `gorm:"type:varchar(3);not null;default:'IDR'" json:"base_currency"`
     CreatedAt time.Time `json:"created_at"`
     UpdatedAt time.Time `json:"updated_at"`
 }
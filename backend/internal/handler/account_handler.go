@@ -0,0 +1,95 @@
+package handler
+
+import (
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type AccountHandler struct {
+    accountService service.AccountService
+}
+
+func NewAccountHandler(accountService service.AccountService) *AccountHandler {
+    return &AccountHandler{accountService}
+}
+
+func (h *AccountHandler) GetAll(c *gin.Context) {
+    accounts, err := h.accountService.GetAll(getUserID(c), c.Query("include_archived") == "true")
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    response.OK(c, "Accounts fetched", accounts)
+}
+
+func (h *AccountHandler) GetByID(c *gin.Context) {
+    account, err := h.accountService.GetByID(c.Param("id"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Account fetched", account)
+}
+
+func (h *AccountHandler) Create(c *gin.Context) {
+    var input service.CreateAccountInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    account, err := h.accountService.Create(getUserID(c), input)
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+
+    response.Created(c, "Account created", account)
+}
+
+func (h *AccountHandler) Update(c *gin.Context) {
+    var input service.UpdateAccountInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    account, err := h.accountService.Update(c.Param("id"), getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    response.OK(c, "Account updated", account)
+}
+
+func (h *AccountHandler) Delete(c *gin.Context) {
+    if err := h.accountService.Delete(c.Param("id"), getUserID(c)); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Account deleted", nil)
+}
+
+// GetBalance: ?as_of=YYYY-MM-DD untuk saldo per tanggal tertentu
+func (h *AccountHandler) GetBalance(c *gin.Context) {
+    var asOf *time.Time
+    if v := c.Query("as_of"); v != "" {
+        t, err := time.Parse("2006-01-02", v)
+        if err != nil {
+            response.BadRequest(c, "invalid as_of format, use YYYY-MM-DD")
+            return
+        }
+        asOf = &t
+    }
+
+    balance, err := h.accountService.GetBalance(c.Param("id"), getUserID(c), asOf)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Account balance fetched", balance)
+}
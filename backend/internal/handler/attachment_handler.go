@@ -0,0 +1,93 @@
+package handler
+
+import (
+    "errors"
+    "io"
+    "mime"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type AttachmentHandler struct {
+    attachmentService service.AttachmentService
+    maxFileSize       int64
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService, maxFileSize int64) *AttachmentHandler {
+    return &AttachmentHandler{attachmentService, maxFileSize}
+}
+
+func (h *AttachmentHandler) GetAll(c *gin.Context) {
+    attachments, err := h.attachmentService.GetAll(c.Param("id"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Attachments fetched", attachments)
+}
+
+// Upload menerima multipart form dengan field "file"
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxFileSize+64<<10)
+
+    header, err := c.FormFile("file")
+    if err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            response.PayloadTooLarge(c, service.ErrAttachmentTooLarge.Error())
+            return
+        }
+        response.BadRequest(c, "file is required")
+        return
+    }
+
+    file, err := header.Open()
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    defer file.Close()
+
+    attachment, err := h.attachmentService.Upload(c.Param("id"), getUserID(c), service.UploadAttachmentInput{
+        FileName: header.Filename,
+        Size:     header.Size,
+        File:     file,
+    })
+    if err != nil {
+        if errors.Is(err, service.ErrAttachmentTooLarge) || errors.Is(err, service.ErrAttachmentQuota) {
+            response.PayloadTooLarge(c, err.Error())
+            return
+        }
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.Created(c, "Attachment uploaded", attachment)
+}
+
+func (h *AttachmentHandler) Download(c *gin.Context) {
+    attachment, file, err := h.attachmentService.Open(c.Param("id"), c.Param("attachmentId"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    defer file.Close()
+
+    c.Header("Content-Type", attachment.ContentType)
+    c.Header("Content-Length", strconv.FormatInt(attachment.Size, 10))
+    c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+    c.Header("X-Content-Type-Options", "nosniff")
+    c.Status(http.StatusOK)
+    io.Copy(c.Writer, file)
+}
+
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+    if err := h.attachmentService.Delete(c.Param("id"), c.Param("attachmentId"), getUserID(c)); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Attachment deleted", nil)
+}
@@ -1,8 +1,12 @@
 package handler
 
 import (
+    "errors"
+    "strconv"
+
     "github.com/gin-gonic/gin"
     "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/otp"
     "github.com/myfarism/finance-tracker/pkg/response"
 )
 
@@ -14,6 +18,19 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
     return &AuthHandler{authService}
 }
 
+// otpError memisahkan error rate limit OTP (429 + retry_after) dari
+// error validasi biasa supaya frontend bisa menampilkan hitung mundur
+func otpError(c *gin.Context, err error) {
+    var rateLimited *otp.RateLimitError
+    if errors.As(err, &rateLimited) {
+        retryAfter := int(rateLimited.RetryAfter.Seconds())
+        c.Header("Retry-After", strconv.Itoa(retryAfter))
+        response.TooManyRequests(c, err.Error(), gin.H{"retry_after": retryAfter})
+        return
+    }
+    response.BadRequest(c, err.Error())
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
     var input service.RegisterInput
     if err := c.ShouldBindJSON(&input); err != nil {
@@ -22,7 +39,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
     }
 
     if err := h.authService.Register(input); err != nil {
-        response.BadRequest(c, err.Error())
+        otpError(c, err)
         return
     }
 
@@ -38,7 +55,7 @@ func (h *AuthHandler) VerifyOTP(c *gin.Context) {
 
     result, err := h.authService.VerifyOTP(input)
     if err != nil {
-        response.BadRequest(c, err.Error())
+        otpError(c, err)
         return
     }
 
@@ -53,7 +70,7 @@ func (h *AuthHandler) ResendOTP(c *gin.Context) {
     }
 
     if err := h.authService.ResendOTP(input); err != nil {
-        response.BadRequest(c, err.Error())
+        otpError(c, err)
         return
     }
 
@@ -76,3 +93,64 @@ func (h *AuthHandler) Login(c *gin.Context) {
     result.User.Password = ""
     response.OK(c, "Login berhasil", result)
 }
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+    var input service.RefreshTokenInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    result, err := h.authService.Refresh(input)
+    if err != nil {
+        response.Unauthorized(c, err.Error())
+        return
+    }
+
+    response.OK(c, "Token diperbarui", result)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+    var input service.RefreshTokenInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    if err := h.authService.Logout(input); err != nil {
+        response.Unauthorized(c, err.Error())
+        return
+    }
+
+    response.OK(c, "Logout berhasil", nil)
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+    var input service.ForgotPasswordInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    if err := h.authService.ForgotPassword(input); err != nil {
+        otpError(c, err)
+        return
+    }
+
+    response.OK(c, "Jika email terdaftar, kode reset password telah dikirim", nil)
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+    var input service.ResetPasswordInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    if err := h.authService.ResetPassword(input); err != nil {
+        otpError(c, err)
+        return
+    }
+
+    response.OK(c, "Password berhasil direset, silakan login ulang", nil)
+}
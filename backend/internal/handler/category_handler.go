@@ -2,8 +2,8 @@ package handler
 
 import (
     "github.com/gin-gonic/gin"
-    "github.com/google/uuid"
     "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
     "github.com/myfarism/finance-tracker/internal/service"
     "github.com/myfarism/finance-tracker/pkg/response"
 )
@@ -17,7 +17,16 @@ func NewCategoryHandler(catService service.CategoryService) *CategoryHandler {
 }
 
 func (h *CategoryHandler) GetAll(c *gin.Context) {
-    categories, err := h.catService.GetAll()
+    filter := repository.CategoryFilter{
+        IncludeArchived: c.Query("include_archived") == "true",
+        Type:            c.Query("type"),
+    }
+    if filter.Type != "" && filter.Type != string(domain.Income) && filter.Type != string(domain.Expense) {
+        response.BadRequest(c, "type must be income or expense")
+        return
+    }
+
+    categories, err := h.catService.GetAll(getUserID(c), filter)
     if err != nil {
         response.InternalError(c, err.Error())
         return
@@ -26,25 +35,57 @@ func (h *CategoryHandler) GetAll(c *gin.Context) {
 }
 
 func (h *CategoryHandler) Create(c *gin.Context) {
-    var input struct {
-        Name string `json:"name" binding:"required"`
-        Icon string `json:"icon"`
+    var input service.CreateCategoryInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    cat, err := h.catService.Create(getUserID(c), input)
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
     }
+
+    response.Created(c, "Category created", cat)
+}
+
+func (h *CategoryHandler) Update(c *gin.Context) {
+    var input service.UpdateCategoryInput
     if err := c.ShouldBindJSON(&input); err != nil {
         response.BadRequest(c, err.Error())
         return
     }
 
-    cat := &domain.Category{
-        ID:   uuid.New(),
-        Name: input.Name,
-        Icon: input.Icon,
+    cat, err := h.catService.Update(c.Param("id"), getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
     }
 
-    if err := h.catService.Create(cat); err != nil {
-        response.InternalError(c, err.Error())
+    response.OK(c, "Category updated", cat)
+}
+
+func (h *CategoryHandler) Delete(c *gin.Context) {
+    if err := h.catService.Delete(c.Param("id"), getUserID(c), c.Query("reassign_to")); err != nil {
+        response.BadRequest(c, err.Error())
         return
     }
+    response.OK(c, "Category deleted", nil)
+}
 
-    response.Created(c, "Category created", cat)
+func (h *CategoryHandler) Merge(c *gin.Context) {
+    var input service.MergeCategoryInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    cat, err := h.catService.Merge(c.Param("id"), getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    response.OK(c, "Category merged", cat)
 }
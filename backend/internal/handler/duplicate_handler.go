@@ -0,0 +1,84 @@
+package handler
+
+import (
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type DuplicateHandler struct {
+    duplicateService service.DuplicateService
+}
+
+func NewDuplicateHandler(duplicateService service.DuplicateService) *DuplicateHandler {
+    return &DuplicateHandler{duplicateService}
+}
+
+// GetAll: default 90 hari terakhir, atau ?start_date=&end_date=;
+// ?window_days= selisih tanggal maksimum (default 3)
+func (h *DuplicateHandler) GetAll(c *gin.Context) {
+    start, end, err := queryDateRange(c, "start_date", "end_date")
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    query := service.DuplicateQuery{}
+    if end != nil {
+        query.EndDate = *end
+    } else {
+        query.EndDate = time.Now()
+    }
+    if start != nil {
+        query.StartDate = *start
+    } else {
+        query.StartDate = query.EndDate.AddDate(0, 0, -service.DefaultDuplicateLookbackDays)
+    }
+    if v := c.Query("window_days"); v != "" {
+        days, err := strconv.Atoi(v)
+        if err != nil || days < 1 {
+            response.BadRequest(c, "window_days must be a positive number")
+            return
+        }
+        query.Window = time.Duration(days) * 24 * time.Hour
+    }
+
+    groups, err := h.duplicateService.FindGroups(getUserID(c), query)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Duplicate transactions retrieved", groups)
+}
+
+func (h *DuplicateHandler) Merge(c *gin.Context) {
+    var input service.MergeDuplicatesInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    tx, err := h.duplicateService.Merge(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Transactions merged", tx)
+}
+
+func (h *DuplicateHandler) Dismiss(c *gin.Context) {
+    var input service.DismissDuplicatesInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    if err := h.duplicateService.Dismiss(getUserID(c), input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Duplicates dismissed", nil)
+}
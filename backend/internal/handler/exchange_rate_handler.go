@@ -0,0 +1,115 @@
+package handler
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+// maxRateImportSize membatasi ukuran file CSV kurs (1 MB)
+const maxRateImportSize = 1 << 20
+
+type ExchangeRateHandler struct {
+    rateService service.ExchangeRateService
+}
+
+func NewExchangeRateHandler(rateService service.ExchangeRateService) *ExchangeRateHandler {
+    return &ExchangeRateHandler{rateService}
+}
+
+func (h *ExchangeRateHandler) GetAll(c *gin.Context) {
+    filter := repository.ExchangeRateFilter{
+        FromCurrency: c.Query("from"),
+        ToCurrency:   c.Query("to"),
+    }
+
+    rates, err := h.rateService.GetAll(getUserID(c), filter)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Exchange rates fetched", rates)
+}
+
+func (h *ExchangeRateHandler) Upsert(c *gin.Context) {
+    var input service.UpsertExchangeRateInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    rate, err := h.rateService.Upsert(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Exchange rate saved", rate)
+}
+
+func (h *ExchangeRateHandler) Delete(c *gin.Context) {
+    if err := h.rateService.Delete(c.Param("id"), getUserID(c)); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Exchange rate deleted", nil)
+}
+
+// Import menerima multipart form dengan field "file" berisi CSV
+func (h *ExchangeRateHandler) Import(c *gin.Context) {
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRateImportSize+4096)
+
+    header, err := c.FormFile("file")
+    if err != nil {
+        response.BadRequest(c, "file is required")
+        return
+    }
+    if header.Size > maxRateImportSize {
+        response.BadRequest(c, "file is too large (max 1 MB)")
+        return
+    }
+
+    file, err := header.Open()
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    defer file.Close()
+
+    result, err := h.rateService.ImportCSV(getUserID(c), file)
+    if err != nil {
+        if result != nil {
+            response.BadRequestWithData(c, err.Error(), result)
+            return
+        }
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Exchange rates imported", result)
+}
+
+func (h *ExchangeRateHandler) GetBaseCurrency(c *gin.Context) {
+    currency, err := h.rateService.GetBaseCurrency(getUserID(c))
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    response.OK(c, "Base currency fetched", gin.H{"base_currency": currency})
+}
+
+func (h *ExchangeRateHandler) SetBaseCurrency(c *gin.Context) {
+    var input service.SetBaseCurrencyInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    currency, err := h.rateService.SetBaseCurrency(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Base currency updated", gin.H{"base_currency": currency})
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+    "encoding/json"
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/importer"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type ImportHandler struct {
+    importService service.ImportService
+}
+
+func NewImportHandler(importService service.ImportService) *ImportHandler {
+    return &ImportHandler{importService}
+}
+
+// Import menerima multipart form: file, source (lihat GET /imports/sources;
+// default csv), account_id, default_category_id, mapping (JSON), dry_run (default true)
+// dan skip_invalid. Dry run mengembalikan preview per baris; commit
+// menyimpan semuanya dalam satu DB transaction.
+func (h *ImportHandler) Import(c *gin.Context) {
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxImportFileSize+64<<10)
+
+    header, err := c.FormFile("file")
+    if err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            response.PayloadTooLarge(c, "file is too large")
+            return
+        }
+        response.BadRequest(c, "file is required")
+        return
+    }
+
+    input := service.ImportInput{
+        Source:            c.DefaultPostForm("source", service.ImportSourceCSV),
+        AccountID:         c.PostForm("account_id"),
+        DefaultCategoryID: c.PostForm("default_category_id"),
+        DryRun:            true,
+    }
+    if v := c.PostForm("dry_run"); v != "" {
+        if input.DryRun, err = strconv.ParseBool(v); err != nil {
+            response.BadRequest(c, "dry_run must be true or false")
+            return
+        }
+    }
+    if v := c.PostForm("skip_invalid"); v != "" {
+        if input.SkipInvalid, err = strconv.ParseBool(v); err != nil {
+            response.BadRequest(c, "skip_invalid must be true or false")
+            return
+        }
+    }
+    // mapping berisi opsi khusus format: kolom untuk csv, format tanggal
+    // & desimal untuk qif; source lain tidak butuh mapping
+    if v := c.PostForm("mapping"); v != "" {
+        if !json.Valid([]byte(v)) {
+            response.BadRequest(c, "invalid mapping: must be a JSON object")
+            return
+        }
+        input.Options = json.RawMessage(v)
+    }
+
+    file, err := header.Open()
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    defer file.Close()
+    input.File = file
+
+    result, err := h.importService.Import(getUserID(c), input)
+    if err != nil {
+        if result != nil {
+            response.BadRequestWithData(c, err.Error(), result)
+            return
+        }
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    if result.DryRun {
+        response.OK(c, "Import preview", result)
+        return
+    }
+    response.Created(c, "Transactions imported", result)
+}
+
+// Sources mengembalikan format statement yang didukung beserta hint akun
+// tujuan otomatisnya
+func (h *ImportHandler) Sources(c *gin.Context) {
+    response.OK(c, "Import sources", importer.Sources())
+}
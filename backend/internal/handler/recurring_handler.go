@@ -0,0 +1,98 @@
+package handler
+
+import (
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type RecurringHandler struct {
+    recurringService service.RecurringService
+}
+
+func NewRecurringHandler(recurringService service.RecurringService) *RecurringHandler {
+    return &RecurringHandler{recurringService}
+}
+
+func (h *RecurringHandler) GetAll(c *gin.Context) {
+    rules, err := h.recurringService.GetAll(getUserID(c))
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    response.OK(c, "Recurring rules fetched", rules)
+}
+
+func (h *RecurringHandler) GetByID(c *gin.Context) {
+    rule, err := h.recurringService.GetByID(c.Param("id"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Recurring rule fetched", rule)
+}
+
+func (h *RecurringHandler) Create(c *gin.Context) {
+    var input service.CreateRecurringInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    rule, err := h.recurringService.Create(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.Created(c, "Recurring rule created", rule)
+}
+
+func (h *RecurringHandler) Update(c *gin.Context) {
+    var input service.UpdateRecurringInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    rule, err := h.recurringService.Update(c.Param("id"), getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Recurring rule updated", rule)
+}
+
+func (h *RecurringHandler) Delete(c *gin.Context) {
+    if err := h.recurringService.Delete(c.Param("id"), getUserID(c)); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Recurring rule deleted", nil)
+}
+
+func (h *RecurringHandler) Pause(c *gin.Context) {
+    rule, err := h.recurringService.Pause(c.Param("id"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Recurring rule paused", rule)
+}
+
+func (h *RecurringHandler) Resume(c *gin.Context) {
+    rule, err := h.recurringService.Resume(c.Param("id"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Recurring rule resumed", rule)
+}
+
+func (h *RecurringHandler) Skip(c *gin.Context) {
+    rule, err := h.recurringService.Skip(c.Param("id"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Next occurrence skipped", rule)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type RuleHandler struct {
+    ruleService service.RuleService
+}
+
+func NewRuleHandler(ruleService service.RuleService) *RuleHandler {
+    return &RuleHandler{ruleService}
+}
+
+func (h *RuleHandler) GetAll(c *gin.Context) {
+    rules, err := h.ruleService.GetAll(getUserID(c))
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    response.OK(c, "Rules fetched", rules)
+}
+
+func (h *RuleHandler) Create(c *gin.Context) {
+    var input service.RuleInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    rule, err := h.ruleService.Create(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.Created(c, "Rule created", rule)
+}
+
+func (h *RuleHandler) Update(c *gin.Context) {
+    var input service.RuleInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    rule, err := h.ruleService.Update(c.Param("id"), getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Rule updated", rule)
+}
+
+func (h *RuleHandler) Delete(c *gin.Context) {
+    if err := h.ruleService.Delete(c.Param("id"), getUserID(c)); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Rule deleted", nil)
+}
+
+// Test: body sama dengan create, rentang histori lewat ?start_date=
+// &end_date= (default 12 bulan terakhir). Rule tidak disimpan.
+func (h *RuleHandler) Test(c *gin.Context) {
+    var input service.RuleInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    start, end, err := queryDateRange(c, "start_date", "end_date")
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    if end == nil {
+        now := time.Now()
+        end = &now
+    }
+    if start == nil {
+        from := end.AddDate(-1, 0, 0)
+        start = &from
+    }
+
+    result, err := h.ruleService.Test(getUserID(c), input, *start, *end)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Rule tested", result)
+}
+
+// Apply menerapkan rule aktif ke transaksi lama; dry_run hanya menghitung
+func (h *RuleHandler) Apply(c *gin.Context) {
+    var input service.ApplyRulesInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    result, err := h.ruleService.Apply(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Rules applied", result)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type TagHandler struct {
+    tagService service.TagService
+}
+
+func NewTagHandler(tagService service.TagService) *TagHandler {
+    return &TagHandler{tagService}
+}
+
+func (h *TagHandler) GetAll(c *gin.Context) {
+    tags, err := h.tagService.GetAll(getUserID(c))
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    response.OK(c, "Tags fetched", tags)
+}
+
+func (h *TagHandler) Create(c *gin.Context) {
+    var input service.CreateTagInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    tag, err := h.tagService.Create(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.Created(c, "Tag created", tag)
+}
+
+func (h *TagHandler) Rename(c *gin.Context) {
+    var input service.RenameTagInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    tag, err := h.tagService.Rename(c.Param("id"), getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Tag renamed", tag)
+}
+
+func (h *TagHandler) Delete(c *gin.Context) {
+    if err := h.tagService.Delete(c.Param("id"), getUserID(c)); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Tag deleted", nil)
+}
+
+func (h *TagHandler) Merge(c *gin.Context) {
+    var input service.MergeTagInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    tag, err := h.tagService.Merge(c.Param("id"), getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Tag merged", tag)
+}
+
+// GetReport: default bulan berjalan, atau ?start_date=&end_date=
+func (h *TagHandler) GetReport(c *gin.Context) {
+    now := time.Now()
+    start, end := monthRangeOf(now)
+
+    if v := c.Query("start_date"); v != "" {
+        t, err := time.Parse("2006-01-02", v)
+        if err != nil {
+            response.BadRequest(c, "invalid start_date format, use YYYY-MM-DD")
+            return
+        }
+        start = t
+    }
+    if v := c.Query("end_date"); v != "" {
+        t, err := time.Parse("2006-01-02", v)
+        if err != nil {
+            response.BadRequest(c, "invalid end_date format, use YYYY-MM-DD")
+            return
+        }
+        end = t
+    }
+
+    report, err := h.tagService.GetReport(getUserID(c), start, end)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Tag report fetched", report)
+}
+
+func monthRangeOf(t time.Time) (time.Time, time.Time) {
+    start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+    return start, start.AddDate(0, 1, -1)
+}
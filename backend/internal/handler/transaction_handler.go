@@ -1,11 +1,15 @@
 package handler
 
 import (
+    "errors"
+    "fmt"
     "strconv"
+    "strings"
     "time"
 
     "github.com/gin-gonic/gin"
     "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
     "github.com/myfarism/finance-tracker/internal/repository"
     "github.com/myfarism/finance-tracker/internal/service"
     "github.com/myfarism/finance-tracker/pkg/response"
@@ -41,33 +45,250 @@ func (h *TransactionHandler) Create(c *gin.Context) {
 }
 
 func (h *TransactionHandler) GetAll(c *gin.Context) {
+    filter, err := parseTransactionFilter(c)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    page, err := parsePageQuery(c)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    result, err := h.txService.GetPage(getUserID(c), filter, page)
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+
+    meta := response.Page{TotalCount: result.TotalCount, Totals: result.Totals}
+    if result.NextCursor != "" {
+        meta.NextCursor = &result.NextCursor
+    }
+    response.Paginated(c, "Transactions fetched", result.Items, meta)
+}
+
+// parseTransactionFilter membaca query filter list transaksi. Daftar ID
+// dipisah koma (?category_id=a,b), tanggal berformat 2006-01-02 dan
+// inklusif; parameter yang tidak valid ditolak, bukan diabaikan.
+func parseTransactionFilter(c *gin.Context) (repository.TransactionFilter, error) {
     filter := repository.TransactionFilter{
-        Type:       c.Query("type"),
-        CategoryID: c.Query("category_id"),
-        Search:     c.Query("search"),
+        Type:     c.Query("type"),
+        Search:   c.Query("search"),
+        TagMatch: c.DefaultQuery("tag_match", repository.TagMatchAny),
+    }
+
+    switch domain.TransactionType(filter.Type) {
+    case "", domain.Income, domain.Expense, domain.TransferIn, domain.TransferOut:
+    default:
+        return filter, errors.New("type must be income, expense, transfer_in or transfer_out")
+    }
+    if filter.TagMatch != repository.TagMatchAny && filter.TagMatch != repository.TagMatchAll {
+        return filter, errors.New("tag_match must be any or all")
     }
 
-    // Parse tanggal jika ada
-    if start := c.Query("start_date"); start != "" {
-        t, err := time.Parse("2006-01-02", start)
-        if err == nil {
-            filter.StartDate = &t
+    var err error
+    if filter.CategoryIDs, err = queryIDs(c, "category_id"); err != nil {
+        return filter, err
+    }
+    if filter.ExcludeCategoryIDs, err = queryIDs(c, "exclude_category_id"); err != nil {
+        return filter, err
+    }
+    if filter.AccountIDs, err = queryIDs(c, "account_id"); err != nil {
+        return filter, err
+    }
+    if filter.Tags, err = queryIDs(c, "tags"); err != nil {
+        return filter, err
+    }
+
+    if filter.MinAmount, err = queryMoney(c, "min_amount"); err != nil {
+        return filter, err
+    }
+    if filter.MaxAmount, err = queryMoney(c, "max_amount"); err != nil {
+        return filter, err
+    }
+    if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+        return filter, errors.New("min_amount must not be greater than max_amount")
+    }
+
+    if has := c.Query("has_attachment"); has != "" {
+        v, err := strconv.ParseBool(has)
+        if err != nil {
+            return filter, errors.New("has_attachment must be true or false")
         }
+        filter.HasAttachment = &v
+    }
+
+    if filter.StartDate, filter.EndDate, err = queryDateRange(c, "start_date", "end_date"); err != nil {
+        return filter, err
+    }
+    if filter.CreatedFrom, filter.CreatedTo, err = queryDateRange(c, "created_from", "created_to"); err != nil {
+        return filter, err
+    }
+    if filter.UpdatedFrom, filter.UpdatedTo, err = queryDateRange(c, "updated_from", "updated_to"); err != nil {
+        return filter, err
+    }
+    // created_at/updated_at berupa timestamp: batas akhir jadi awal hari
+    // berikutnya (eksklusif) supaya seluruh hari terakhir ikut
+    if filter.CreatedTo != nil {
+        next := filter.CreatedTo.AddDate(0, 0, 1)
+        filter.CreatedTo = &next
     }
-    if end := c.Query("end_date"); end != "" {
-        t, err := time.Parse("2006-01-02", end)
-        if err == nil {
-            filter.EndDate = &t
+    if filter.UpdatedTo != nil {
+        next := filter.UpdatedTo.AddDate(0, 0, 1)
+        filter.UpdatedTo = &next
+    }
+
+    return filter, nil
+}
+
+// queryIDs membaca daftar UUID dipisah koma; ID ganda dibuang supaya
+// tag_match=all tetap bisa membandingkan jumlah tag yang cocok
+func queryIDs(c *gin.Context, key string) ([]uuid.UUID, error) {
+    raw := c.Query(key)
+    if raw == "" {
+        return nil, nil
+    }
+    var ids []uuid.UUID
+    seen := map[uuid.UUID]bool{}
+    for _, part := range strings.Split(raw, ",") {
+        id, err := uuid.Parse(strings.TrimSpace(part))
+        if err != nil {
+            return nil, fmt.Errorf("invalid %s %q", key, part)
+        }
+        if !seen[id] {
+            seen[id] = true
+            ids = append(ids, id)
         }
     }
+    return ids, nil
+}
 
-    transactions, err := h.txService.GetAll(getUserID(c), filter)
+func queryMoney(c *gin.Context, key string) (*domain.Money, error) {
+    raw := c.Query(key)
+    if raw == "" {
+        return nil, nil
+    }
+    amount, err := domain.ParseMoney(raw)
+    if err != nil || amount < 0 {
+        return nil, fmt.Errorf("%s must be a non-negative amount", key)
+    }
+    return &amount, nil
+}
+
+func queryDateRange(c *gin.Context, fromKey, toKey string) (*time.Time, *time.Time, error) {
+    from, err := queryDate(c, fromKey)
     if err != nil {
-        response.InternalError(c, err.Error())
+        return nil, nil, err
+    }
+    to, err := queryDate(c, toKey)
+    if err != nil {
+        return nil, nil, err
+    }
+    if from != nil && to != nil && from.After(*to) {
+        return nil, nil, fmt.Errorf("%s must not be after %s", fromKey, toKey)
+    }
+    return from, to, nil
+}
+
+func queryDate(c *gin.Context, key string) (*time.Time, error) {
+    raw := c.Query(key)
+    if raw == "" {
+        return nil, nil
+    }
+    t, err := time.Parse("2006-01-02", raw)
+    if err != nil {
+        return nil, fmt.Errorf("%s must be in YYYY-MM-DD format", key)
+    }
+    return &t, nil
+}
+
+const (
+    defaultPageLimit = 50
+    maxPageLimit     = 200
+)
+
+// parsePageQuery membaca ?limit=&cursor=&sort=date|amount|created_at
+// &order=asc|desc. ?all=true mematikan paging, dipakai untuk export.
+func parsePageQuery(c *gin.Context) (repository.PageQuery, error) {
+    page := repository.PageQuery{
+        Limit: defaultPageLimit,
+        Sort: repository.TransactionSort{
+            Field: c.DefaultQuery("sort", repository.SortByDate),
+            Desc:  true,
+        },
+    }
+
+    if !repository.ValidSortField(page.Sort.Field) {
+        return page, errors.New("sort must be date, amount or created_at")
+    }
+    switch c.DefaultQuery("order", "desc") {
+    case "desc":
+    case "asc":
+        page.Sort.Desc = false
+    default:
+        return page, errors.New("order must be asc or desc")
+    }
+
+    if all := c.Query("all"); all != "" {
+        v, err := strconv.ParseBool(all)
+        if err != nil {
+            return page, errors.New("all must be true or false")
+        }
+        if v {
+            page.Limit = 0
+        }
+    }
+    if limit := c.Query("limit"); limit != "" && page.Limit > 0 {
+        n, err := strconv.Atoi(limit)
+        if err != nil || n < 1 || n > maxPageLimit {
+            return page, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+        }
+        page.Limit = n
+    }
+
+    if cursor := c.Query("cursor"); cursor != "" {
+        after, err := repository.DecodeCursor(cursor, page.Sort)
+        if err != nil {
+            return page, err
+        }
+        page.After = after
+    }
+    return page, nil
+}
+
+const (
+    defaultSearchLimit = 20
+    maxSearchLimit     = 50
+)
+
+// Search: ?q= dicari dengan prefix matching (cocok untuk search-as-you-type),
+// diurutkan menurut relevansi. Filter list transaksi lainnya tetap berlaku.
+func (h *TransactionHandler) Search(c *gin.Context) {
+    filter, err := parseTransactionFilter(c)
+    if err != nil {
+        response.BadRequest(c, err.Error())
         return
     }
 
-    response.OK(c, "Transactions fetched", transactions)
+    limit := defaultSearchLimit
+    if raw := c.Query("limit"); raw != "" {
+        n, err := strconv.Atoi(raw)
+        if err != nil || n < 1 || n > maxSearchLimit {
+            response.BadRequest(c, fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit))
+            return
+        }
+        limit = n
+    }
+
+    hits, err := h.txService.Search(getUserID(c), c.Query("q"), filter, limit)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Search results fetched", hits)
 }
 
 func (h *TransactionHandler) GetByID(c *gin.Context) {
@@ -127,3 +348,37 @@ func (h *TransactionHandler) GetSummary(c *gin.Context) {
 
     response.OK(c, "Summary fetched", summary)
 }
+
+func (h *TransactionHandler) GetCategorySummary(c *gin.Context) {
+    now := time.Now()
+    month := int(now.Month())
+    year := now.Year()
+
+    if m := c.Query("month"); m != "" {
+        if val, err := strconv.Atoi(m); err == nil {
+            month = val
+        }
+    }
+    if y := c.Query("year"); y != "" {
+        if val, err := strconv.Atoi(y); err == nil {
+            year = val
+        }
+    }
+
+    txType := domain.Expense
+    if t := c.Query("type"); t != "" {
+        txType = domain.TransactionType(t)
+    }
+    if txType != domain.Income && txType != domain.Expense {
+        response.BadRequest(c, "type must be income or expense")
+        return
+    }
+
+    summary, err := h.txService.GetCategorySummary(getUserID(c), txType, month, year)
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+
+    response.OK(c, "Category summary fetched", summary)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+    "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/response"
+)
+
+type TransferHandler struct {
+    transferService service.TransferService
+}
+
+func NewTransferHandler(transferService service.TransferService) *TransferHandler {
+    return &TransferHandler{transferService}
+}
+
+func (h *TransferHandler) Create(c *gin.Context) {
+    var input service.CreateTransferInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    transfer, err := h.transferService.Create(getUserID(c), input)
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+
+    response.Created(c, "Transfer created", transfer)
+}
+
+func (h *TransferHandler) GetAll(c *gin.Context) {
+    transfers, err := h.transferService.GetAll(getUserID(c))
+    if err != nil {
+        response.InternalError(c, err.Error())
+        return
+    }
+    response.OK(c, "Transfers fetched", transfers)
+}
+
+func (h *TransferHandler) GetByID(c *gin.Context) {
+    transfer, err := h.transferService.GetByID(c.Param("id"), getUserID(c))
+    if err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Transfer fetched", transfer)
+}
+
+func (h *TransferHandler) Delete(c *gin.Context) {
+    if err := h.transferService.Delete(c.Param("id"), getUserID(c)); err != nil {
+        response.BadRequest(c, err.Error())
+        return
+    }
+    response.OK(c, "Transfer deleted", nil)
+}
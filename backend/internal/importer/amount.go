@@ -0,0 +1,62 @@
+package importer
+
+import (
+    "errors"
+    "fmt"
+    "regexp"
+    "strings"
+    "unicode"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+var currencyPrefix = regexp.MustCompile(`(?i)^(rp\.?|idr|usd|sgd|eur|\$|€|£)`)
+
+// ParseAmount mem-parse nominal dengan pemisah desimal & ribuan yang
+// diberikan, mis. "1.250.000,00" dengan decimal "," dan thousand ".".
+// Tanda negatif boleh di depan, di belakang ("5.000-") atau berupa kurung
+// ("(5.000)"); prefix mata uang seperti "Rp" diabaikan.
+func ParseAmount(raw, decimalSep, thousandSep string) (domain.Money, error) {
+    s := strings.TrimSpace(raw)
+    if s == "" {
+        return 0, errors.New("amount is empty")
+    }
+
+    negative := false
+    if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+        negative = true
+        s = strings.TrimSpace(s[1 : len(s)-1])
+    }
+    if strings.HasPrefix(s, "-") {
+        negative = true
+        s = strings.TrimSpace(s[1:])
+    } else if strings.HasSuffix(s, "-") {
+        negative = true
+        s = strings.TrimSpace(s[:len(s)-1])
+    }
+    s = strings.TrimPrefix(s, "+")
+    s = currencyPrefix.ReplaceAllString(s, "")
+
+    // Spasi (termasuk non-breaking space) kadang dipakai sebagai pemisah ribuan
+    s = strings.Map(func(r rune) rune {
+        if unicode.IsSpace(r) {
+            return -1
+        }
+        return r
+    }, s)
+    if thousandSep != "" {
+        s = strings.ReplaceAll(s, thousandSep, "")
+    }
+    if decimalSep != "" && decimalSep != "." {
+        s = strings.ReplaceAll(s, decimalSep, ".")
+    }
+
+    amount, err := domain.ParseMoney(s)
+    if err != nil || strings.ContainsAny(s, "+-") {
+        return 0, fmt.Errorf("invalid amount %q", raw)
+    }
+    if negative {
+        amount = -amount
+    }
+    return amount, nil
+}
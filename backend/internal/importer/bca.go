@@ -0,0 +1,135 @@
+package importer
+
+import (
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+type bcaParser struct{}
+
+// NewBCAParser: CSV mutasi rekening KlikBCA. Tanggal transaksi hanya
+// berisi "DD/MM", tahunnya diambil dari baris "Periode"; kolom setelah
+// Jumlah berisi DB (debit) atau CR (kredit). Transaksi "PEND" (belum
+// dibukukan) dilewati.
+func NewBCAParser() Parser {
+    return bcaParser{}
+}
+
+var bcaPeriod = regexp.MustCompile(`(\d{2}/\d{2}/\d{4})\s*-\s*(\d{2}/\d{2}/\d{4})`)
+
+func (bcaParser) Parse(r io.Reader) ([]Draft, error) {
+    reader := csv.NewReader(r)
+    reader.FieldsPerRecord = -1
+    reader.LazyQuotes = true
+    reader.TrimLeadingSpace = true
+
+    var start, end time.Time
+    inTable := false
+    var drafts []Draft
+    for {
+        record, err := reader.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, fmt.Errorf("invalid CSV: %w", err)
+        }
+        first := strings.TrimSpace(record[0])
+
+        switch {
+        case !inTable && strings.HasPrefix(first, "Periode"):
+            m := bcaPeriod.FindStringSubmatch(strings.Join(record, ","))
+            if m == nil {
+                return nil, errors.New("invalid BCA statement period")
+            }
+            start, _ = time.Parse("02/01/2006", m[1])
+            end, _ = time.Parse("02/01/2006", m[2])
+        case !inTable && strings.EqualFold(first, "Tanggal Transaksi"):
+            if start.IsZero() {
+                return nil, errors.New("not a BCA statement: period line not found")
+            }
+            inTable = true
+        case inTable && (blank(record) || strings.HasPrefix(first, "Saldo") || strings.HasPrefix(first, "Mutasi")):
+            // Ringkasan saldo di akhir file
+            inTable = false
+        case inTable:
+            if len(drafts) == MaxRows {
+                return nil, fmt.Errorf("%w, the limit is %d", ErrTooManyRows, MaxRows)
+            }
+            line, _ := reader.FieldPos(0)
+            drafts = append(drafts, bcaDraft(record, line, start, end))
+        }
+    }
+
+    if start.IsZero() {
+        return nil, errors.New("not a BCA statement: period line not found")
+    }
+    if len(drafts) == 0 {
+        return nil, ErrNoRows
+    }
+    return drafts, nil
+}
+
+func bcaDraft(record []string, line int, start, end time.Time) Draft {
+    d := Draft{
+        Row:         line,
+        Description: strings.Join(strings.Fields(field(record, 1)), " "),
+    }
+
+    rawDate := strings.TrimPrefix(field(record, 0), "'")
+    if strings.EqualFold(rawDate, "PEND") {
+        d.SkipReason = "pending transaction, import it after it is posted"
+        d.Date = end
+    } else if date, err := bcaDate(rawDate, start, end); err != nil {
+        d.fail(err)
+    } else {
+        d.Date = date
+    }
+
+    amount, err := ParseAmount(field(record, 3), ".", ",")
+    switch {
+    case err != nil:
+        d.fail(err)
+    case amount <= 0:
+        d.fail(errors.New("amount must be greater than 0"))
+    }
+    d.Amount = amount
+
+    switch strings.ToUpper(field(record, 4)) {
+    case "DB":
+        d.Type = domain.Expense
+    case "CR":
+        d.Type = domain.Income
+    default:
+        d.fail(fmt.Errorf("unknown debit/credit marker %q", field(record, 4)))
+    }
+    return d
+}
+
+// bcaDate melengkapi "DD/MM" dengan tahun dari periode statement; periode
+// yang melewati tahun baru (mis. 25/12/2025 - 05/01/2026) ditangani
+// dengan memilih tahun yang membuat tanggal jatuh di dalam periode
+func bcaDate(value string, start, end time.Time) (time.Time, error) {
+    day, month, ok := strings.Cut(value, "/")
+    d, errDay := strconv.Atoi(day)
+    m, errMonth := strconv.Atoi(month)
+    if !ok || errDay != nil || errMonth != nil {
+        return time.Time{}, fmt.Errorf("invalid date %q", value)
+    }
+
+    for year := start.Year(); year <= end.Year(); year++ {
+        date := time.Date(year, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+        if date.Day() == d && !date.Before(start) && !date.After(end) {
+            return date, nil
+        }
+    }
+    return time.Time{}, fmt.Errorf("date %q is outside the statement period", value)
+}
@@ -0,0 +1,369 @@
+package importer
+
+import (
+    "bufio"
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+const (
+    // SignNegativeExpense: nominal negatif = pengeluaran (umumnya rekening bank)
+    SignNegativeExpense = "negative_expense"
+    // SignPositiveExpense: nominal positif = pengeluaran (umumnya kartu kredit)
+    SignPositiveExpense = "positive_expense"
+)
+
+var (
+    defaultIncomeValues  = []string{"income", "pemasukan", "masuk", "credit", "kredit", "cr", "in"}
+    defaultExpenseValues = []string{"expense", "pengeluaran", "keluar", "debit", "debet", "db", "dr", "out"}
+)
+
+// CSVMapping menjelaskan layout file CSV. Kolom disebut dengan nama header
+// (tidak case-sensitive) atau nomor kolom mulai dari 1. Nominal diambil
+// dari Amount (arah uang dari kolom Type atau tanda +/-) atau dari
+// pasangan kolom Debit/Credit.
+type CSVMapping struct {
+    Delimiter         string   `json:"delimiter"` // default ","
+    NoHeader          bool     `json:"no_header"`
+    SkipRows          int      `json:"skip_rows"` // baris pembuka (info rekening dsb.) sebelum header
+    Date              string   `json:"date"`
+    Amount            string   `json:"amount"`
+    Debit             string   `json:"debit"`
+    Credit            string   `json:"credit"`
+    Type              string   `json:"type"`
+    IncomeValues      []string `json:"income_values"`
+    ExpenseValues     []string `json:"expense_values"`
+    SignConvention    string   `json:"sign_convention"` // negative_expense (default) | positive_expense
+    Description       string   `json:"description"`
+    Payee             string   `json:"payee"`
+    Category          string   `json:"category"`
+    DateFormat        string   `json:"date_format"`        // mis. "DD/MM/YYYY", default "YYYY-MM-DD"
+    DecimalSeparator  string   `json:"decimal_separator"`  // default "."
+    ThousandSeparator string   `json:"thousand_separator"` // default "," atau "." jika decimal ","
+}
+
+type csvParser struct {
+    mapping    CSVMapping
+    delimiter  rune
+    dateLayout string
+    decimal    string
+    thousand   string
+    income     map[string]bool
+    expense    map[string]bool
+}
+
+// NewCSVParser memvalidasi mapping; kesalahan mapping dilaporkan di sini,
+// bukan per baris
+func NewCSVParser(m CSVMapping) (Parser, error) {
+    p := &csvParser{mapping: m, delimiter: ','}
+
+    if m.Delimiter != "" {
+        r, size := utf8.DecodeRuneInString(m.Delimiter)
+        if size != len(m.Delimiter) || r == '"' || r == '\r' || r == '\n' {
+            return nil, errors.New("delimiter must be a single character")
+        }
+        p.delimiter = r
+    }
+
+    if m.Date == "" {
+        return nil, errors.New("mapping for date column is required")
+    }
+    if m.SkipRows < 0 {
+        return nil, errors.New("skip_rows must not be negative")
+    }
+    hasAmount := m.Amount != ""
+    hasDebitCredit := m.Debit != "" || m.Credit != ""
+    switch {
+    case hasAmount && hasDebitCredit:
+        return nil, errors.New("map either amount or debit/credit columns, not both")
+    case !hasAmount && !hasDebitCredit:
+        return nil, errors.New("mapping for amount or debit/credit columns is required")
+    case hasDebitCredit && (m.Debit == "" || m.Credit == ""):
+        return nil, errors.New("debit and credit columns must be mapped together")
+    case m.Type != "" && !hasAmount:
+        return nil, errors.New("type column can only be used with the amount column")
+    }
+
+    switch m.SignConvention {
+    case "", SignNegativeExpense, SignPositiveExpense:
+    default:
+        return nil, errors.New("sign_convention must be negative_expense or positive_expense")
+    }
+
+    format := m.DateFormat
+    if format == "" {
+        format = DefaultDateFormat
+    }
+    layout, err := DateLayout(format)
+    if err != nil {
+        return nil, err
+    }
+    p.dateLayout = layout
+
+    p.decimal = m.DecimalSeparator
+    if p.decimal == "" {
+        p.decimal = "."
+    }
+    p.thousand = m.ThousandSeparator
+    if p.thousand == "" {
+        p.thousand = ","
+        if p.decimal == "," {
+            p.thousand = "."
+        }
+    }
+    if p.decimal != "." && p.decimal != "," {
+        return nil, errors.New("decimal_separator must be . or ,")
+    }
+    if p.thousand == p.decimal {
+        return nil, errors.New("decimal and thousand separators must differ")
+    }
+
+    p.income = valueSet(m.IncomeValues, defaultIncomeValues)
+    p.expense = valueSet(m.ExpenseValues, defaultExpenseValues)
+    return p, nil
+}
+
+func valueSet(values, defaults []string) map[string]bool {
+    if len(values) == 0 {
+        values = defaults
+    }
+    set := make(map[string]bool, len(values))
+    for _, v := range values {
+        set[strings.ToLower(strings.TrimSpace(v))] = true
+    }
+    return set
+}
+
+// csvColumns: indeks kolom hasil resolve mapping, -1 = tidak dipetakan
+type csvColumns struct {
+    date, amount, debit, credit, txType, description, payee, category int
+}
+
+func (p *csvParser) Parse(r io.Reader) ([]Draft, error) {
+    br := bufio.NewReader(r)
+    // Excel sering menambahkan BOM UTF-8 di awal file
+    if bom, err := br.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+        br.Discard(3)
+    }
+
+    reader := csv.NewReader(br)
+    reader.Comma = p.delimiter
+    reader.FieldsPerRecord = -1
+    reader.LazyQuotes = true
+    reader.TrimLeadingSpace = true
+
+    for i := 0; i < p.mapping.SkipRows; i++ {
+        if _, err := reader.Read(); err != nil {
+            if err == io.EOF {
+                return nil, ErrNoRows
+            }
+            return nil, fmt.Errorf("invalid CSV: %w", err)
+        }
+    }
+
+    var header []string
+    if !p.mapping.NoHeader {
+        record, err := reader.Read()
+        if err == io.EOF {
+            return nil, ErrNoRows
+        }
+        if err != nil {
+            return nil, fmt.Errorf("invalid CSV: %w", err)
+        }
+        header = record
+    }
+    cols, err := p.resolveColumns(header)
+    if err != nil {
+        return nil, err
+    }
+
+    var drafts []Draft
+    for {
+        record, err := reader.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, fmt.Errorf("invalid CSV: %w", err)
+        }
+        if blank(record) {
+            continue
+        }
+        if len(drafts) == MaxRows {
+            return nil, fmt.Errorf("%w, the limit is %d", ErrTooManyRows, MaxRows)
+        }
+        line, _ := reader.FieldPos(0)
+        drafts = append(drafts, p.parseRecord(record, cols, line))
+    }
+
+    if len(drafts) == 0 {
+        return nil, ErrNoRows
+    }
+    return drafts, nil
+}
+
+func (p *csvParser) resolveColumns(header []string) (csvColumns, error) {
+    m := p.mapping
+    var cols csvColumns
+    targets := []struct {
+        name string
+        ref  string
+        idx  *int
+    }{
+        {"date", m.Date, &cols.date},
+        {"amount", m.Amount, &cols.amount},
+        {"debit", m.Debit, &cols.debit},
+        {"credit", m.Credit, &cols.credit},
+        {"type", m.Type, &cols.txType},
+        {"description", m.Description, &cols.description},
+        {"payee", m.Payee, &cols.payee},
+        {"category", m.Category, &cols.category},
+    }
+    for _, t := range targets {
+        idx, err := columnIndex(header, t.ref)
+        if err != nil {
+            return cols, fmt.Errorf("%s column: %w", t.name, err)
+        }
+        *t.idx = idx
+    }
+    return cols, nil
+}
+
+func columnIndex(header []string, ref string) (int, error) {
+    ref = strings.TrimSpace(ref)
+    if ref == "" {
+        return -1, nil
+    }
+    if n, err := strconv.Atoi(ref); err == nil {
+        if n < 1 {
+            return -1, errors.New("column number starts at 1")
+        }
+        return n - 1, nil
+    }
+    for i, name := range header {
+        if strings.EqualFold(strings.TrimSpace(name), ref) {
+            return i, nil
+        }
+    }
+    if header == nil {
+        return -1, fmt.Errorf("use column numbers when the file has no header, got %q", ref)
+    }
+    return -1, fmt.Errorf("%q not found in header", ref)
+}
+
+func blank(record []string) bool {
+    for _, field := range record {
+        if strings.TrimSpace(field) != "" {
+            return false
+        }
+    }
+    return true
+}
+
+func field(record []string, idx int) string {
+    if idx < 0 || idx >= len(record) {
+        return ""
+    }
+    return strings.TrimSpace(record[idx])
+}
+
+func (p *csvParser) parseRecord(record []string, cols csvColumns, line int) Draft {
+    d := Draft{
+        Row:          line,
+        Description:  field(record, cols.description),
+        Payee:        field(record, cols.payee),
+        CategoryName: field(record, cols.category),
+    }
+
+    date, err := ParseDate(field(record, cols.date), p.dateLayout)
+    if err != nil {
+        d.fail(err)
+    }
+    d.Date = date
+
+    if cols.amount >= 0 {
+        p.parseSignedAmount(&d, record, cols)
+    } else {
+        p.parseDebitCredit(&d, record, cols)
+    }
+    return d
+}
+
+func (p *csvParser) parseSignedAmount(d *Draft, record []string, cols csvColumns) {
+    amount, err := ParseAmount(field(record, cols.amount), p.decimal, p.thousand)
+    if err != nil {
+        d.fail(err)
+        return
+    }
+
+    if cols.txType >= 0 {
+        value := strings.ToLower(field(record, cols.txType))
+        switch {
+        case p.income[value]:
+            d.Type = domain.Income
+        case p.expense[value]:
+            d.Type = domain.Expense
+        default:
+            d.fail(fmt.Errorf("unknown transaction type %q", value))
+        }
+        d.Amount = abs(amount)
+    } else {
+        negativeIsExpense := p.mapping.SignConvention != SignPositiveExpense
+        if (amount < 0) == negativeIsExpense {
+            d.Type = domain.Expense
+        } else {
+            d.Type = domain.Income
+        }
+        d.Amount = abs(amount)
+    }
+
+    if d.Amount == 0 {
+        d.fail(errors.New("amount must not be zero"))
+    }
+}
+
+// parseDebitCredit: debit = uang keluar, credit = uang masuk; hanya salah
+// satu yang boleh terisi
+func (p *csvParser) parseDebitCredit(d *Draft, record []string, cols csvColumns) {
+    debitRaw, creditRaw := field(record, cols.debit), field(record, cols.credit)
+
+    var debit, credit domain.Money
+    var err error
+    if debitRaw != "" {
+        if debit, err = ParseAmount(debitRaw, p.decimal, p.thousand); err != nil {
+            d.fail(err)
+            return
+        }
+    }
+    if creditRaw != "" {
+        if credit, err = ParseAmount(creditRaw, p.decimal, p.thousand); err != nil {
+            d.fail(err)
+            return
+        }
+    }
+
+    switch {
+    case debit != 0 && credit != 0:
+        d.fail(errors.New("row has both debit and credit"))
+    case debit != 0:
+        d.Type, d.Amount = domain.Expense, abs(debit)
+    case credit != 0:
+        d.Type, d.Amount = domain.Income, abs(credit)
+    default:
+        d.fail(errors.New("row has no debit or credit amount"))
+    }
+}
+
+func abs(m domain.Money) domain.Money {
+    if m < 0 {
+        return -m
+    }
+    return m
+}
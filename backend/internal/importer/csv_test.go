@@ -0,0 +1,162 @@
+package importer_test
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/importer"
+    "github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+    d, _ := time.Parse("2006-01-02", s)
+    return d
+}
+
+func TestParseAmount(t *testing.T) {
+    cases := []struct {
+        raw, decimal, thousand string
+        want                   domain.Money
+    }{
+        {"1.250.000,00", ",", ".", domain.NewMoney(1250000)},
+        {"Rp 1.250.000", ",", ".", domain.NewMoney(1250000)},
+        {"-25.500,50", ",", ".", domain.MoneyFromFloat(-25500.50)},
+        {"(5,000.00)", ".", ",", domain.NewMoney(-5000)},
+        {"5.000-", ",", ".", domain.NewMoney(-5000)},
+        {"1 250 000", ",", " ", domain.NewMoney(1250000)},
+        {"12.5", ".", ",", domain.MoneyFromFloat(12.5)},
+    }
+    for _, c := range cases {
+        got, err := importer.ParseAmount(c.raw, c.decimal, c.thousand)
+        assert.NoError(t, err, c.raw)
+        assert.Equal(t, c.want, got, c.raw)
+    }
+
+    for _, raw := range []string{"", "abc", "1,2,3.4.5", "--5"} {
+        _, err := importer.ParseAmount(raw, ".", ",")
+        assert.Error(t, err, raw)
+    }
+}
+
+func TestParseDate_IndonesianMonths(t *testing.T) {
+    layout, err := importer.DateLayout("D MMM YYYY")
+    assert.NoError(t, err)
+
+    got, err := importer.ParseDate("5 Mei 2026", layout)
+    assert.NoError(t, err)
+    assert.Equal(t, date("2026-05-05"), got)
+
+    got, err = importer.ParseDate("17 agu 2026", layout)
+    assert.NoError(t, err)
+    assert.Equal(t, date("2026-08-17"), got)
+
+    _, err = importer.DateLayout("MM/YYYY")
+    assert.Error(t, err)
+}
+
+// ──────────────────────────────────────────
+// CSV TESTS
+// ──────────────────────────────────────────
+
+func TestCSV_SignConventionAndIndonesianNumbers(t *testing.T) {
+    parser, err := importer.NewCSVParser(importer.CSVMapping{
+        Delimiter:        ";",
+        Date:             "Tanggal",
+        Amount:           "Jumlah",
+        Description:      "Keterangan",
+        Category:         "Kategori",
+        DateFormat:       "DD/MM/YYYY",
+        DecimalSeparator: ",",
+    })
+    assert.NoError(t, err)
+
+    file := "\xef\xbb\xbfTanggal;Keterangan;Jumlah;Kategori\n" +
+        "01/02/2026;Gaji Februari;10.000.000,00;Gaji\n" +
+        "03/02/2026;Makan siang;-45.500,00;Makanan\n" +
+        ";;;\n" +
+        "31/02/2026;Tanggal salah;-1.000;\n"
+
+    drafts, err := parser.Parse(strings.NewReader(file))
+    assert.NoError(t, err)
+    assert.Len(t, drafts, 3)
+
+    assert.Equal(t, domain.Income, drafts[0].Type)
+    assert.Equal(t, domain.NewMoney(10000000), drafts[0].Amount)
+    assert.Equal(t, date("2026-02-01"), drafts[0].Date)
+    assert.Equal(t, "Gaji", drafts[0].CategoryName)
+
+    assert.Equal(t, domain.Expense, drafts[1].Type)
+    assert.Equal(t, domain.MoneyFromFloat(45500), drafts[1].Amount)
+    assert.True(t, drafts[1].Valid())
+
+    // Nomor baris mengikuti file, termasuk header dan baris kosong
+    assert.Equal(t, 5, drafts[2].Row)
+    assert.False(t, drafts[2].Valid())
+}
+
+func TestCSV_TypeColumnAndColumnNumbers(t *testing.T) {
+    parser, err := importer.NewCSVParser(importer.CSVMapping{
+        NoHeader:    true,
+        Date:        "1",
+        Type:        "2",
+        Amount:      "3",
+        Description: "4",
+    })
+    assert.NoError(t, err)
+
+    drafts, err := parser.Parse(strings.NewReader(
+        "2026-02-01,Pemasukan,500000,Bonus\n" +
+            "2026-02-02,keluar,-20000,Parkir\n" +
+            "2026-02-03,transfer,1000,?\n"))
+    assert.NoError(t, err)
+
+    assert.Equal(t, domain.Income, drafts[0].Type)
+    assert.Equal(t, domain.Expense, drafts[1].Type)
+    assert.Equal(t, domain.NewMoney(20000), drafts[1].Amount)
+    assert.Contains(t, drafts[2].Errors[0], "unknown transaction type")
+}
+
+func TestCSV_DebitCreditColumns(t *testing.T) {
+    parser, err := importer.NewCSVParser(importer.CSVMapping{
+        Date:   "date",
+        Debit:  "debit",
+        Credit: "credit",
+    })
+    assert.NoError(t, err)
+
+    drafts, err := parser.Parse(strings.NewReader(
+        "date,debit,credit\n" +
+            "2026-02-01,25000,\n" +
+            "2026-02-02,,1000000\n" +
+            "2026-02-03,1,1\n"))
+    assert.NoError(t, err)
+
+    assert.Equal(t, domain.Expense, drafts[0].Type)
+    assert.Equal(t, domain.Income, drafts[1].Type)
+    assert.False(t, drafts[2].Valid())
+}
+
+func TestCSV_InvalidMapping(t *testing.T) {
+    mappings := []importer.CSVMapping{
+        {Amount: "amount"},
+        {Date: "date"},
+        {Date: "date", Amount: "amount", Debit: "debit", Credit: "credit"},
+        {Date: "date", Debit: "debit"},
+        {Date: "date", Amount: "amount", SignConvention: "whatever"},
+        {Date: "date", Amount: "amount", DecimalSeparator: ",", ThousandSeparator: ","},
+    }
+    for _, m := range mappings {
+        _, err := importer.NewCSVParser(m)
+        assert.Error(t, err, m)
+    }
+
+    parser, err := importer.NewCSVParser(importer.CSVMapping{Date: "tanggal", Amount: "amount"})
+    assert.NoError(t, err)
+    _, err = parser.Parse(strings.NewReader("date,amount\n2026-02-01,1\n"))
+    assert.ErrorContains(t, err, `"tanggal" not found`)
+
+    _, err = parser.Parse(strings.NewReader("tanggal,amount\n"))
+    assert.ErrorIs(t, err, importer.ErrNoRows)
+}
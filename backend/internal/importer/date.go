@@ -0,0 +1,101 @@
+package importer
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// DefaultDateFormat dipakai jika mapping tidak menyebut date_format
+const DefaultDateFormat = "YYYY-MM-DD"
+
+// dateTokens diurutkan dari yang terpanjang supaya "MMMM" tidak terbaca
+// sebagai "MM" dua kali
+var dateTokens = []struct{ token, layout string }{
+    {"YYYY", "2006"},
+    {"MMMM", "January"},
+    {"MMM", "Jan"},
+    {"YY", "06"},
+    {"MM", "01"},
+    {"DD", "02"},
+    {"HH", "15"},
+    {"mm", "04"},
+    {"ss", "05"},
+    {"M", "1"},
+    {"D", "2"},
+}
+
+// DateLayout mengubah format seperti "DD/MM/YYYY" atau "D MMM YYYY"
+// menjadi layout time.Parse
+func DateLayout(format string) (string, error) {
+    var layout strings.Builder
+    hasYear, hasMonth, hasDay := false, false, false
+
+    for i := 0; i < len(format); {
+        matched := false
+        for _, t := range dateTokens {
+            if strings.HasPrefix(format[i:], t.token) {
+                layout.WriteString(t.layout)
+                switch t.token[0] {
+                case 'Y':
+                    hasYear = true
+                case 'M':
+                    hasMonth = true
+                case 'D':
+                    hasDay = true
+                }
+                i += len(t.token)
+                matched = true
+                break
+            }
+        }
+        if !matched {
+            layout.WriteByte(format[i])
+            i++
+        }
+    }
+
+    if !hasYear || !hasMonth || !hasDay {
+        return "", fmt.Errorf("date_format %q must contain year, month and day", format)
+    }
+    return layout.String(), nil
+}
+
+var wordPattern = regexp.MustCompile(`\p{L}+`)
+
+// indonesianMonths: nama bulan Indonesia yang berbeda dari bahasa Inggris
+var indonesianMonths = map[string]string{
+    "januari":  "January",
+    "februari": "February",
+    "maret":    "March",
+    "mei":      "May",
+    "juni":     "June",
+    "juli":     "July",
+    "agustus":  "August",
+    "agu":      "Aug",
+    "agt":      "Aug",
+    "ags":      "Aug",
+    "oktober":  "October",
+    "okt":      "Oct",
+    "desember": "December",
+    "des":      "Dec",
+}
+
+// ParseDate mem-parse tanggal dengan layout dari DateLayout. Nama bulan
+// boleh dalam bahasa Indonesia ("5 Mei 2026", "17 Agu 2026").
+func ParseDate(value, layout string) (time.Time, error) {
+    normalized := wordPattern.ReplaceAllStringFunc(strings.TrimSpace(value), func(word string) string {
+        if english, ok := indonesianMonths[strings.ToLower(word)]; ok {
+            return english
+        }
+        return word
+    })
+
+    t, err := time.Parse(layout, normalized)
+    if err != nil {
+        return time.Time{}, fmt.Errorf("invalid date %q", value)
+    }
+    // Transaksi hanya menyimpan tanggal
+    return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
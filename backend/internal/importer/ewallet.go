@@ -0,0 +1,127 @@
+package importer
+
+import (
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+// NewGoPayParser: riwayat transaksi GoPay yang diekspor ke PDF lalu
+// dikonversi ke teks, mis. "01 Feb 2026 12:30 Bayar ke Kopi Kenangan -Rp25.000"
+func NewGoPayParser() Parser {
+    return newTextStatementParser(`\d{1,2} \p{L}{3,9} \d{4}`, "D MMM YYYY",
+        []string{"Bayar ke ", "Transfer ke ", "Transfer dari ", "GoFood - ", "GoMart - "})
+}
+
+// NewShopeePayParser: riwayat ShopeePay dalam bentuk teks, mis.
+// "2026-02-07 19:22 Pembayaran ke Indomaret Kemang -Rp32.400"
+func NewShopeePayParser() Parser {
+    return newTextStatementParser(`\d{4}-\d{2}-\d{2}`, "YYYY-MM-DD",
+        []string{"Pembayaran ke ", "Transfer ke ", "Transfer dari "})
+}
+
+var (
+    ovoIncome  = map[string]bool{"top up": true, "transfer masuk": true, "cashback": true, "refund": true, "pengembalian dana": true}
+    ovoExpense = map[string]bool{"pembayaran": true, "transfer keluar": true, "tarik tunai": true, "biaya": true}
+)
+
+type ovoParser struct{}
+
+// NewOVOParser: CSV riwayat OVO dengan kolom Tanggal, Waktu, Jenis
+// Transaksi, Keterangan, Nominal, Status. Nominal tidak bertanda, arah
+// uang dari Jenis Transaksi; transaksi yang tidak berhasil dilewati.
+func NewOVOParser() Parser {
+    return ovoParser{}
+}
+
+func (ovoParser) Parse(r io.Reader) ([]Draft, error) {
+    reader := csv.NewReader(r)
+    reader.FieldsPerRecord = -1
+    reader.TrimLeadingSpace = true
+
+    header, err := reader.Read()
+    if err == io.EOF {
+        return nil, ErrNoRows
+    }
+    if err != nil {
+        return nil, fmt.Errorf("invalid CSV: %w", err)
+    }
+    cols := map[string]int{}
+    for i, name := range header {
+        cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\xef\xbb\xbf")))] = i
+    }
+    for _, required := range []string{"tanggal", "jenis transaksi", "keterangan", "nominal", "status"} {
+        if _, ok := cols[required]; !ok {
+            return nil, fmt.Errorf("not an OVO statement: column %q not found", required)
+        }
+    }
+    layout, _ := DateLayout("DD/MM/YYYY")
+
+    var drafts []Draft
+    for {
+        record, err := reader.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, fmt.Errorf("invalid CSV: %w", err)
+        }
+        if blank(record) {
+            continue
+        }
+        if len(drafts) == MaxRows {
+            return nil, fmt.Errorf("%w, the limit is %d", ErrTooManyRows, MaxRows)
+        }
+        line, _ := reader.FieldPos(0)
+        drafts = append(drafts, ovoDraft(record, cols, layout, line))
+    }
+
+    if len(drafts) == 0 {
+        return nil, ErrNoRows
+    }
+    return drafts, nil
+}
+
+func ovoDraft(record []string, cols map[string]int, layout string, line int) Draft {
+    kind := strings.ToLower(field(record, cols["jenis transaksi"]))
+    d := Draft{
+        Row:         line,
+        Description: field(record, cols["keterangan"]),
+    }
+    if kind == "pembayaran" {
+        d.Payee = d.Description
+    }
+
+    if status := field(record, cols["status"]); !strings.EqualFold(status, "berhasil") {
+        d.SkipReason = "transaction status is " + status
+    }
+
+    date, err := ParseDate(field(record, cols["tanggal"]), layout)
+    if err != nil {
+        d.fail(err)
+    }
+    d.Date = date
+
+    switch {
+    case ovoIncome[kind]:
+        d.Type = domain.Income
+    case ovoExpense[kind]:
+        d.Type = domain.Expense
+    default:
+        d.fail(fmt.Errorf("unknown transaction type %q", kind))
+    }
+
+    amount, err := ParseAmount(field(record, cols["nominal"]), ",", ".")
+    switch {
+    case err != nil:
+        d.fail(err)
+    case amount <= 0:
+        d.fail(errors.New("amount must be greater than 0"))
+    }
+    d.Amount = amount
+    return d
+}
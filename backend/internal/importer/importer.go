@@ -0,0 +1,53 @@
+// Package importer mem-parse file statement (CSV, dst.) menjadi Draft
+// transaksi. Package ini tidak menyentuh database: pencocokan kategori,
+// akun dan penyimpanan dilakukan service.ImportService.
+package importer
+
+import (
+    "errors"
+    "io"
+    "time"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+// MaxRows membatasi jumlah baris per file import
+const MaxRows = 5000
+
+var (
+    ErrNoRows      = errors.New("file has no transactions")
+    ErrTooManyRows = errors.New("file has too many rows")
+)
+
+// Draft: satu baris statement yang sudah di-parse tapi belum menjadi
+// transaksi. Amount selalu positif, arah uang ada di Type. Baris yang
+// gagal di-parse tetap dikembalikan dengan Errors terisi supaya preview
+// bisa menunjukkan baris mana yang salah.
+type Draft struct {
+    Row          int // nomor baris di file, mulai dari 1
+    Date         time.Time
+    Type         domain.TransactionType
+    Amount       domain.Money
+    Description  string
+    Payee        string
+    CategoryName string
+    ExternalID   string // ID transaksi dari bank (FITID OFX), kosong jika tidak ada
+    // SkipReason: baris yang sengaja tidak di-import (pending, gagal)
+    // tapi tetap ditampilkan di preview
+    SkipReason   string
+    Errors       []string
+}
+
+func (d *Draft) Valid() bool {
+    return len(d.Errors) == 0
+}
+
+func (d *Draft) fail(err error) {
+    d.Errors = append(d.Errors, err.Error())
+}
+
+// Parser membaca satu file statement. Error hanya untuk file yang tidak
+// bisa dibaca sama sekali; kesalahan per baris ada di Draft.Errors.
+type Parser interface {
+    Parse(r io.Reader) ([]Draft, error)
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+    "bufio"
+    "io"
+    "strings"
+)
+
+type mandiriParser struct {
+    csv Parser
+}
+
+// NewMandiriParser: CSV mutasi rekening Livin'/Mandiri Online, dipisah
+// titik koma dengan angka format Indonesia ("1.250.000,00") dan kolom
+// Debit/Kredit terpisah. Info rekening di atas header dan ringkasan saldo
+// di bawah tabel dilewati.
+func NewMandiriParser() Parser {
+    parser, err := NewCSVParser(CSVMapping{
+        Delimiter:        ";",
+        Date:             "Tanggal",
+        Description:      "Keterangan",
+        Debit:            "Debit",
+        Credit:           "Kredit",
+        DateFormat:       "DD/MM/YYYY",
+        DecimalSeparator: ",",
+    })
+    if err != nil {
+        panic(err) // mapping tetap di kode, bukan input user
+    }
+    return mandiriParser{csv: parser}
+}
+
+func (p mandiriParser) Parse(r io.Reader) ([]Draft, error) {
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64<<10), 1<<20)
+
+    // Ambil hanya tabel transaksi: dari header "Tanggal;..." sampai baris
+    // kosong pertama. Baris di atasnya diganti baris kosong (dilewati
+    // encoding/csv) supaya nomor baris di preview sama dengan file aslinya.
+    var table strings.Builder
+    inTable := false
+    for scanner.Scan() {
+        line := strings.TrimPrefix(strings.TrimRight(scanner.Text(), "\r"), "\xef\xbb\xbf")
+        if !inTable {
+            if strings.HasPrefix(strings.ToLower(line), "tanggal;") {
+                inTable = true
+            } else {
+                table.WriteString("\n")
+                continue
+            }
+        }
+        if strings.Trim(line, "; ") == "" {
+            break
+        }
+        table.WriteString(line + "\n")
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+    if !inTable {
+        return nil, ErrNoRows
+    }
+    return p.csv.Parse(strings.NewReader(table.String()))
+}
@@ -0,0 +1,143 @@
+package importer
+
+import (
+    "errors"
+    "fmt"
+    "html"
+    "io"
+    "strings"
+    "time"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+type ofxParser struct{}
+
+// NewOFXParser membaca OFX 1.x (SGML, tag tanpa penutup) maupun 2.x (XML).
+// Setiap <STMTTRN> menjadi satu Draft; TRNAMT negatif = pengeluaran dan
+// FITID disimpan sebagai ExternalID.
+func NewOFXParser() Parser {
+    return ofxParser{}
+}
+
+func (ofxParser) Parse(r io.Reader) ([]Draft, error) {
+    data, err := io.ReadAll(io.LimitReader(r, 64<<20))
+    if err != nil {
+        return nil, err
+    }
+    content := string(data)
+    start := strings.Index(strings.ToUpper(content), "<OFX>")
+    if start < 0 {
+        return nil, errors.New("invalid OFX: <OFX> element not found")
+    }
+
+    var drafts []Draft
+    var current map[string]string
+    row := 0
+    for _, el := range ofxElements(content[start:]) {
+        switch el.tag {
+        case "STMTTRN":
+            current = map[string]string{}
+        case "/STMTTRN":
+            if current == nil {
+                continue
+            }
+            if len(drafts) == MaxRows {
+                return nil, fmt.Errorf("%w, the limit is %d", ErrTooManyRows, MaxRows)
+            }
+            row++
+            drafts = append(drafts, ofxDraft(row, current))
+            current = nil
+        default:
+            if current != nil && !strings.HasPrefix(el.tag, "/") {
+                current[el.tag] = el.value
+            }
+        }
+    }
+
+    if len(drafts) == 0 {
+        return nil, ErrNoRows
+    }
+    return drafts, nil
+}
+
+type ofxElement struct {
+    tag   string
+    value string
+}
+
+// ofxElements memecah isi OFX menjadi pasangan tag + teks sesudahnya
+// (sampai tag berikutnya). Cara ini cocok untuk SGML maupun XML karena
+// tag penutup di XML cukup diperlakukan sebagai elemen kosong.
+func ofxElements(content string) []ofxElement {
+    var elements []ofxElement
+    for {
+        open := strings.IndexByte(content, '<')
+        if open < 0 {
+            return elements
+        }
+        end := strings.IndexByte(content[open:], '>')
+        if end < 0 {
+            return elements
+        }
+        tag := strings.ToUpper(strings.TrimSpace(content[open+1 : open+end]))
+        content = content[open+end+1:]
+
+        next := strings.IndexByte(content, '<')
+        if next < 0 {
+            next = len(content)
+        }
+        value := html.UnescapeString(strings.TrimSpace(content[:next]))
+        elements = append(elements, ofxElement{tag: tag, value: value})
+    }
+}
+
+func ofxDraft(row int, fields map[string]string) Draft {
+    d := Draft{
+        Row:         row,
+        ExternalID:  fields["FITID"],
+        Payee:       fields["NAME"],
+        Description: fields["MEMO"],
+    }
+    if d.Description == "" {
+        d.Description = d.Payee
+    }
+
+    date, err := parseOFXDate(fields["DTPOSTED"])
+    if err != nil {
+        d.fail(err)
+    }
+    d.Date = date
+
+    // Sebagian bank memakai koma sebagai pemisah desimal
+    raw := fields["TRNAMT"]
+    decimal, thousand := ".", ","
+    if strings.Contains(raw, ",") && !strings.Contains(raw, ".") {
+        decimal, thousand = ",", "."
+    }
+    amount, err := ParseAmount(raw, decimal, thousand)
+    switch {
+    case err != nil:
+        d.fail(err)
+    case amount == 0:
+        d.fail(errors.New("amount must not be zero"))
+    case amount < 0:
+        d.Type, d.Amount = domain.Expense, -amount
+    default:
+        d.Type, d.Amount = domain.Income, amount
+    }
+    return d
+}
+
+// parseOFXDate: YYYYMMDD diikuti jam & zona waktu opsional, mis.
+// "20260201120000.000[+7:WIB]"; hanya tanggalnya yang dipakai
+func parseOFXDate(value string) (time.Time, error) {
+    if len(value) < 8 {
+        return time.Time{}, fmt.Errorf("invalid date %q", value)
+    }
+    t, err := time.Parse("20060102", value[:8])
+    if err != nil {
+        return time.Time{}, fmt.Errorf("invalid date %q", value)
+    }
+    return t, nil
+}
@@ -0,0 +1,85 @@
+package importer_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/importer"
+    "github.com/stretchr/testify/assert"
+)
+
+const sgmlOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>IDR
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260203120000.000[+7:WIB]
+<TRNAMT>-45500.00
+<FITID>202602030001
+<NAME>WARUNG PADANG
+<MEMO>Makan siang &amp; minum
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260201
+<TRNAMT>10000000,00
+<FITID>202602010001
+<NAME>PT MAJU JAYA
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+const xmlOFX = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>POS</TRNTYPE><DTPOSTED>20260205</DTPOSTED><TRNAMT>-12.50</TRNAMT><FITID>X-1</FITID><NAME>Coffee</NAME></STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+func TestOFX_SGML(t *testing.T) {
+    drafts, err := importer.NewOFXParser().Parse(strings.NewReader(sgmlOFX))
+
+    assert.NoError(t, err)
+    assert.Len(t, drafts, 2)
+
+    assert.Equal(t, domain.Expense, drafts[0].Type)
+    assert.Equal(t, domain.MoneyFromFloat(45500), drafts[0].Amount)
+    assert.Equal(t, date("2026-02-03"), drafts[0].Date)
+    assert.Equal(t, "202602030001", drafts[0].ExternalID)
+    assert.Equal(t, "WARUNG PADANG", drafts[0].Payee)
+    assert.Equal(t, "Makan siang & minum", drafts[0].Description)
+
+    assert.Equal(t, domain.Income, drafts[1].Type)
+    assert.Equal(t, domain.NewMoney(10000000), drafts[1].Amount)
+    // Tanpa MEMO, deskripsi diambil dari NAME
+    assert.Equal(t, "PT MAJU JAYA", drafts[1].Description)
+}
+
+func TestOFX_XML(t *testing.T) {
+    drafts, err := importer.NewOFXParser().Parse(strings.NewReader(xmlOFX))
+
+    assert.NoError(t, err)
+    assert.Len(t, drafts, 1)
+    assert.Equal(t, domain.MoneyFromFloat(12.5), drafts[0].Amount)
+    assert.Equal(t, "X-1", drafts[0].ExternalID)
+    assert.True(t, drafts[0].Valid())
+}
+
+func TestOFX_Invalid(t *testing.T) {
+    _, err := importer.NewOFXParser().Parse(strings.NewReader("date,amount\n"))
+    assert.Error(t, err)
+
+    _, err = importer.NewOFXParser().Parse(strings.NewReader("<OFX></OFX>"))
+    assert.ErrorIs(t, err, importer.ErrNoRows)
+
+    drafts, err := importer.NewOFXParser().Parse(strings.NewReader(
+        "<OFX><STMTTRN><DTPOSTED>2026<TRNAMT>abc<FITID>1</STMTTRN></OFX>"))
+    assert.NoError(t, err)
+    assert.Len(t, drafts[0].Errors, 2)
+}
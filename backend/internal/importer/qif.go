@@ -0,0 +1,195 @@
+package importer
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "io"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+const (
+    QIFDateMonthFirst = "MM/DD/YYYY" // default Quicken
+    QIFDateDayFirst   = "DD/MM/YYYY"
+)
+
+// QIFOptions: QIF tidak menyimpan format tanggal maupun pemisah desimal,
+// jadi keduanya harus disebutkan jika bukan format Quicken US
+type QIFOptions struct {
+    DateFormat       string `json:"date_format"`       // MM/DD/YYYY (default) | DD/MM/YYYY
+    DecimalSeparator string `json:"decimal_separator"` // "." (default) | ","
+}
+
+type qifParser struct {
+    dayFirst bool
+    decimal  string
+    thousand string
+}
+
+// NewQIFParser membaca record rekening bank, kas dan kartu kredit
+// (!Type:Bank, Cash, CCard, Oth A, Oth L); bagian lain seperti investasi
+// dilewati. Baris split (S/E/$) diabaikan, kategori diambil dari L dengan
+// "Parent:Child" diubah menjadi "Parent > Child".
+func NewQIFParser(opts QIFOptions) (Parser, error) {
+    p := &qifParser{decimal: ".", thousand: ","}
+    switch opts.DateFormat {
+    case "", QIFDateMonthFirst:
+    case QIFDateDayFirst:
+        p.dayFirst = true
+    default:
+        return nil, errors.New("date_format must be MM/DD/YYYY or DD/MM/YYYY for QIF")
+    }
+    switch opts.DecimalSeparator {
+    case "", ".":
+    case ",":
+        p.decimal, p.thousand = ",", "."
+    default:
+        return nil, errors.New("decimal_separator must be . or ,")
+    }
+    return p, nil
+}
+
+var qifCashTypes = map[string]bool{
+    "bank": true, "cash": true, "ccard": true, "oth a": true, "oth l": true,
+}
+
+func (p *qifParser) Parse(r io.Reader) ([]Draft, error) {
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64<<10), 1<<20)
+
+    var drafts []Draft
+    var fields map[byte]string
+    inCashSection := false
+    recordStart, line := 0, 0
+
+    for scanner.Scan() {
+        line++
+        text := strings.TrimRight(scanner.Text(), "\r")
+        if line == 1 {
+            text = strings.TrimPrefix(text, "\xef\xbb\xbf")
+        }
+        if strings.TrimSpace(text) == "" {
+            continue
+        }
+
+        if strings.HasPrefix(text, "!") {
+            header := strings.ToLower(strings.TrimSpace(text[1:]))
+            if strings.HasPrefix(header, "type:") {
+                inCashSection = qifCashTypes[strings.TrimSpace(header[len("type:"):])]
+            }
+            // !Account, !Option, dst. tidak mengubah section aktif
+            continue
+        }
+        if !inCashSection {
+            continue
+        }
+
+        if text[0] == '^' {
+            if fields != nil {
+                if len(drafts) == MaxRows {
+                    return nil, fmt.Errorf("%w, the limit is %d", ErrTooManyRows, MaxRows)
+                }
+                drafts = append(drafts, p.draft(recordStart, fields))
+            }
+            fields = nil
+            continue
+        }
+        if fields == nil {
+            fields = map[byte]string{}
+            recordStart = line
+        }
+        code := text[0]
+        if _, seen := fields[code]; !seen {
+            fields[code] = strings.TrimSpace(text[1:])
+        }
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, fmt.Errorf("invalid QIF: %w", err)
+    }
+    // Record terakhir tanpa "^" tetap dihitung
+    if fields != nil {
+        drafts = append(drafts, p.draft(recordStart, fields))
+    }
+
+    if len(drafts) == 0 {
+        return nil, ErrNoRows
+    }
+    return drafts, nil
+}
+
+func (p *qifParser) draft(row int, fields map[byte]string) Draft {
+    d := Draft{
+        Row:         row,
+        Payee:       fields['P'],
+        Description: fields['M'],
+    }
+    if d.Description == "" {
+        d.Description = d.Payee
+    }
+
+    // "[Nama Akun]" menandakan transfer, bukan kategori
+    if category := fields['L']; category != "" && !strings.HasPrefix(category, "[") {
+        category, _, _ = strings.Cut(category, "/") // buang class
+        d.CategoryName = strings.ReplaceAll(category, ":", " > ")
+    }
+
+    date, err := p.parseDate(fields['D'])
+    if err != nil {
+        d.fail(err)
+    }
+    d.Date = date
+
+    raw := fields['T']
+    if raw == "" {
+        raw = fields['U']
+    }
+    amount, err := ParseAmount(raw, p.decimal, p.thousand)
+    switch {
+    case err != nil:
+        d.fail(err)
+    case amount == 0:
+        d.fail(errors.New("amount must not be zero"))
+    case amount < 0:
+        d.Type, d.Amount = domain.Expense, -amount
+    default:
+        d.Type, d.Amount = domain.Income, amount
+    }
+    return d
+}
+
+var qifDateParts = regexp.MustCompile(`\d+`)
+
+// parseDate menerima variasi Quicken seperti "1/5/2026", "01/05/26" dan
+// "1/ 5'26" (apostrof = tahun 2000-an)
+func (p *qifParser) parseDate(value string) (time.Time, error) {
+    parts := qifDateParts.FindAllString(value, -1)
+    if len(parts) != 3 {
+        return time.Time{}, fmt.Errorf("invalid date %q", value)
+    }
+    first, _ := strconv.Atoi(parts[0])
+    second, _ := strconv.Atoi(parts[1])
+    year, _ := strconv.Atoi(parts[2])
+
+    month, day := first, second
+    if p.dayFirst {
+        month, day = second, first
+    }
+    if len(parts[2]) <= 2 {
+        if strings.Contains(value, "'") || year < 70 {
+            year += 2000
+        } else {
+            year += 1900
+        }
+    }
+
+    t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+    if t.Month() != time.Month(month) || t.Day() != day {
+        return time.Time{}, fmt.Errorf("invalid date %q", value)
+    }
+    return t, nil
+}
@@ -0,0 +1,80 @@
+package importer_test
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/importer"
+    "github.com/stretchr/testify/assert"
+)
+
+const bankQIF = `!Type:Bank
+D1/ 5'26
+T-1,250.00
+PToko Buku
+MBuku pelajaran
+LPendidikan:Buku
+^
+D01/31/2026
+T5,000,000.00
+PKantor
+LGaji
+^
+D2/1/2026
+T-500.00
+L[Tabungan]
+^
+!Type:Invst
+D2/2/2026
+NBuy
+T-100.00
+^
+`
+
+func TestQIF_Bank(t *testing.T) {
+    parser, err := importer.NewQIFParser(importer.QIFOptions{})
+    assert.NoError(t, err)
+
+    drafts, err := parser.Parse(strings.NewReader(bankQIF))
+
+    assert.NoError(t, err)
+    // Record investasi dilewati
+    assert.Len(t, drafts, 3)
+
+    assert.Equal(t, date("2026-01-05"), drafts[0].Date)
+    assert.Equal(t, domain.Expense, drafts[0].Type)
+    assert.Equal(t, domain.NewMoney(1250), drafts[0].Amount)
+    assert.Equal(t, "Toko Buku", drafts[0].Payee)
+    assert.Equal(t, "Buku pelajaran", drafts[0].Description)
+    assert.Equal(t, "Pendidikan > Buku", drafts[0].CategoryName)
+    assert.Equal(t, 2, drafts[0].Row)
+
+    assert.Equal(t, domain.Income, drafts[1].Type)
+    assert.Equal(t, "Kantor", drafts[1].Description)
+
+    // Transfer ke akun lain tidak dianggap kategori
+    assert.Empty(t, drafts[2].CategoryName)
+}
+
+func TestQIF_DayFirstAndCommaDecimal(t *testing.T) {
+    parser, err := importer.NewQIFParser(importer.QIFOptions{
+        DateFormat:       importer.QIFDateDayFirst,
+        DecimalSeparator: ",",
+    })
+    assert.NoError(t, err)
+
+    drafts, err := parser.Parse(strings.NewReader("!Type:CCard\nD31/01/2026\nT-1.250,50\n^\nD30/02/2026\nT-1\n"))
+
+    assert.NoError(t, err)
+    assert.Equal(t, date("2026-01-31"), drafts[0].Date)
+    assert.Equal(t, domain.MoneyFromFloat(1250.5), drafts[0].Amount)
+    // Record terakhir tanpa "^" tetap dibaca, tanggalnya invalid
+    assert.Len(t, drafts, 2)
+    assert.False(t, drafts[1].Valid())
+}
+
+func TestQIF_InvalidOptions(t *testing.T) {
+    _, err := importer.NewQIFParser(importer.QIFOptions{DateFormat: "YYYY-MM-DD"})
+    assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package importer
+
+import (
+    "encoding/json"
+    "fmt"
+    "sort"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+// Source: satu format statement yang bisa di-import. Source bank/e-wallet
+// punya AccountHint untuk memilih akun tujuan otomatis (akun yang namanya
+// mengandung hint tersebut) dan selalu dalam Currency tertentu.
+type Source struct {
+    Name         string             `json:"name"`
+    Label        string             `json:"label"`
+    NeedsMapping bool               `json:"needs_mapping"`
+    AccountType  domain.AccountType `json:"account_type,omitempty"`
+    AccountHint  string             `json:"account_hint,omitempty"`
+    Currency     string             `json:"currency,omitempty"`
+    // New membuat parser; options berisi JSON "mapping" dari request dan
+    // hanya dipakai format generik (csv, qif)
+    New func(options json.RawMessage) (Parser, error) `json:"-"`
+}
+
+var sources = map[string]Source{
+    "csv": {
+        Name:         "csv",
+        Label:        "CSV (mapping kolom manual)",
+        NeedsMapping: true,
+        New: func(options json.RawMessage) (Parser, error) {
+            var m CSVMapping
+            if err := decodeOptions(options, &m); err != nil {
+                return nil, err
+            }
+            return NewCSVParser(m)
+        },
+    },
+    "ofx": {
+        Name:  "ofx",
+        Label: "OFX",
+        New: func(json.RawMessage) (Parser, error) {
+            return NewOFXParser(), nil
+        },
+    },
+    "qif": {
+        Name:  "qif",
+        Label: "QIF (Quicken)",
+        New: func(options json.RawMessage) (Parser, error) {
+            var opts QIFOptions
+            if err := decodeOptions(options, &opts); err != nil {
+                return nil, err
+            }
+            return NewQIFParser(opts)
+        },
+    },
+    "bca": {
+        Name:        "bca",
+        Label:       "BCA — mutasi rekening KlikBCA (CSV)",
+        AccountType: domain.AccountBank,
+        AccountHint: "BCA",
+        Currency:    "IDR",
+        New:         withoutOptions(NewBCAParser),
+    },
+    "mandiri": {
+        Name:        "mandiri",
+        Label:       "Mandiri — mutasi rekening Livin'/Mandiri Online (CSV)",
+        AccountType: domain.AccountBank,
+        AccountHint: "Mandiri",
+        Currency:    "IDR",
+        New:         withoutOptions(NewMandiriParser),
+    },
+    "gopay": {
+        Name:        "gopay",
+        Label:       "GoPay — riwayat transaksi (PDF ke teks)",
+        AccountType: domain.AccountEWallet,
+        AccountHint: "GoPay",
+        Currency:    "IDR",
+        New:         withoutOptions(NewGoPayParser),
+    },
+    "ovo": {
+        Name:        "ovo",
+        Label:       "OVO — riwayat transaksi (CSV)",
+        AccountType: domain.AccountEWallet,
+        AccountHint: "OVO",
+        Currency:    "IDR",
+        New:         withoutOptions(NewOVOParser),
+    },
+    "shopeepay": {
+        Name:        "shopeepay",
+        Label:       "ShopeePay — riwayat transaksi (PDF ke teks)",
+        AccountType: domain.AccountEWallet,
+        AccountHint: "ShopeePay",
+        Currency:    "IDR",
+        New:         withoutOptions(NewShopeePayParser),
+    },
+}
+
+// Lookup mencari source berdasarkan nama, mis. "bca"
+func Lookup(name string) (Source, bool) {
+    source, ok := sources[name]
+    return source, ok
+}
+
+// Sources mengembalikan semua source, urut nama
+func Sources() []Source {
+    list := make([]Source, 0, len(sources))
+    for _, source := range sources {
+        list = append(list, source)
+    }
+    sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+    return list
+}
+
+func withoutOptions(newParser func() Parser) func(json.RawMessage) (Parser, error) {
+    return func(json.RawMessage) (Parser, error) {
+        return newParser(), nil
+    }
+}
+
+func decodeOptions(options json.RawMessage, target interface{}) error {
+    if len(options) == 0 {
+        return nil
+    }
+    if err := json.Unmarshal(options, target); err != nil {
+        return fmt.Errorf("invalid mapping: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,104 @@
+package importer
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "io"
+    "regexp"
+    "strings"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+// textStatementParser membaca statement e-wallet hasil konversi PDF ke
+// teks: satu transaksi per baris berformat
+//
+//  <tanggal> [jam] <keterangan> <+|->Rp<nominal>
+//
+// Baris lain (judul, header tabel, nomor halaman) dilewati, tetapi baris
+// yang diawali tanggal dan tidak cocok format dilaporkan sebagai error.
+type textStatementParser struct {
+    start  *regexp.Regexp
+    line   *regexp.Regexp
+    layout string
+    payee  []string // prefix keterangan sebelum nama merchant/penerima
+}
+
+func newTextStatementParser(datePattern, dateFormat string, payeePrefixes []string) *textStatementParser {
+    layout, err := DateLayout(dateFormat)
+    if err != nil {
+        panic(err) // format tetap di kode, bukan input user
+    }
+    return &textStatementParser{
+        start:  regexp.MustCompile(`^` + datePattern + `\b`),
+        line:   regexp.MustCompile(`^(` + datePattern + `)(?:\s+\d{1,2}[:.]\d{2}(?:[:.]\d{2})?)?\s+(.+?)\s+([+-])\s*Rp\.?\s?([\d.]+(?:,\d{1,2})?)$`),
+        layout: layout,
+        payee:  payeePrefixes,
+    }
+}
+
+func (p *textStatementParser) Parse(r io.Reader) ([]Draft, error) {
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64<<10), 1<<20)
+
+    var drafts []Draft
+    line := 0
+    for scanner.Scan() {
+        line++
+        text := strings.Join(strings.Fields(scanner.Text()), " ")
+        if !p.start.MatchString(text) {
+            continue
+        }
+        if len(drafts) == MaxRows {
+            return nil, fmt.Errorf("%w, the limit is %d", ErrTooManyRows, MaxRows)
+        }
+
+        d := Draft{Row: line}
+        m := p.line.FindStringSubmatch(text)
+        if m == nil {
+            d.fail(fmt.Errorf("unrecognized statement line %q", text))
+            drafts = append(drafts, d)
+            continue
+        }
+
+        if d.Date, _ = ParseDate(m[1], p.layout); d.Date.IsZero() {
+            d.fail(fmt.Errorf("invalid date %q", m[1]))
+        }
+        d.Description = m[2]
+        d.Payee = payeeOf(m[2], p.payee)
+        amount, err := ParseAmount(m[4], ",", ".")
+        switch {
+        case err != nil:
+            d.fail(err)
+        case amount == 0:
+            d.fail(errors.New("amount must not be zero"))
+        }
+        d.Amount = amount
+        d.Type = domain.Income
+        if m[3] == "-" {
+            d.Type = domain.Expense
+        }
+        drafts = append(drafts, d)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, err
+    }
+
+    if len(drafts) == 0 {
+        return nil, ErrNoRows
+    }
+    return drafts, nil
+}
+
+// payeeOf mengambil nama merchant/penerima dari keterangan, mis.
+// "Bayar ke Kopi Kenangan" → "Kopi Kenangan"; kosong jika tidak dikenali
+func payeeOf(description string, prefixes []string) string {
+    lower := strings.ToLower(description)
+    for _, prefix := range prefixes {
+        if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+            return strings.TrimSpace(description[len(prefix):])
+        }
+    }
+    return ""
+}
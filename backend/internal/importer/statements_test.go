@@ -0,0 +1,143 @@
+package importer_test
+
+import (
+    "os"
+    "strings"
+    "testing"
+
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/importer"
+    "github.com/stretchr/testify/assert"
+)
+
+func parseFixture(t *testing.T, source, file string) []importer.Draft {
+    t.Helper()
+    src, ok := importer.Lookup(source)
+    if !assert.True(t, ok, source) {
+        t.FailNow()
+    }
+    parser, err := src.New(nil)
+    assert.NoError(t, err)
+
+    f, err := os.Open("testdata/" + file)
+    if !assert.NoError(t, err) {
+        t.FailNow()
+    }
+    defer f.Close()
+
+    drafts, err := parser.Parse(f)
+    if !assert.NoError(t, err) {
+        t.FailNow()
+    }
+    return drafts
+}
+
+func TestBCA(t *testing.T) {
+    drafts := parseFixture(t, "bca", "bca.csv")
+
+    assert.Len(t, drafts, 5)
+    assert.Equal(t, date("2025-12-25"), drafts[0].Date)
+    assert.Equal(t, domain.Expense, drafts[0].Type)
+    assert.Equal(t, domain.NewMoney(150000), drafts[0].Amount)
+    assert.Equal(t, "TRSF E-BANKING DB 2512/FTSCY/WS95051 150000.00 TOKO BAHAGIA", drafts[0].Description)
+    assert.Equal(t, 7, drafts[0].Row)
+
+    assert.Equal(t, domain.Income, drafts[1].Type)
+    assert.Equal(t, domain.NewMoney(10000000), drafts[1].Amount)
+
+    // Periode melewati tahun baru: 02/01 jatuh di 2026
+    assert.Equal(t, date("2026-01-02"), drafts[2].Date)
+
+    // PEND dilewati, bukan error
+    assert.True(t, drafts[4].Valid())
+    assert.NotEmpty(t, drafts[4].SkipReason)
+    for _, d := range drafts[:4] {
+        assert.True(t, d.Valid(), d.Errors)
+        assert.Empty(t, d.SkipReason)
+    }
+}
+
+func TestBCA_RequiresPeriod(t *testing.T) {
+    _, err := importer.NewBCAParser().Parse(strings.NewReader("Tanggal Transaksi,Keterangan,Cabang,Jumlah,,Saldo\n'01/02,X,'0000,1.00,DB,1.00\n"))
+    assert.ErrorContains(t, err, "period")
+}
+
+func TestMandiri(t *testing.T) {
+    drafts := parseFixture(t, "mandiri", "mandiri.csv")
+
+    // Ringkasan saldo setelah tabel tidak ikut
+    assert.Len(t, drafts, 4)
+    assert.Equal(t, 6, drafts[0].Row)
+    assert.Equal(t, domain.Income, drafts[0].Type)
+    assert.Equal(t, domain.NewMoney(10000000), drafts[0].Amount)
+    assert.Equal(t, date("2026-02-01"), drafts[0].Date)
+
+    assert.Equal(t, domain.Expense, drafts[1].Type)
+    assert.Equal(t, domain.MoneyFromFloat(202500), drafts[1].Amount)
+    assert.Equal(t, "Pembayaran PLN Prabayar 5123456789", drafts[1].Description)
+    for _, d := range drafts {
+        assert.True(t, d.Valid(), d.Errors)
+    }
+}
+
+func TestGoPay(t *testing.T) {
+    drafts := parseFixture(t, "gopay", "gopay.txt")
+
+    assert.Len(t, drafts, 6)
+    assert.Equal(t, date("2026-02-01"), drafts[0].Date)
+    assert.Equal(t, domain.Expense, drafts[0].Type)
+    assert.Equal(t, domain.NewMoney(25000), drafts[0].Amount)
+    assert.Equal(t, "Kopi Kenangan", drafts[0].Payee)
+    assert.Equal(t, 6, drafts[0].Row)
+
+    assert.Equal(t, domain.Income, drafts[1].Type)
+    assert.Equal(t, domain.NewMoney(500000), drafts[1].Amount)
+    assert.Equal(t, "Sate Khas Senayan", drafts[2].Payee)
+    assert.Empty(t, drafts[3].Payee)
+    assert.Equal(t, "Andi", drafts[4].Payee)
+    for _, d := range drafts {
+        assert.True(t, d.Valid(), d.Errors)
+    }
+}
+
+func TestOVO(t *testing.T) {
+    drafts := parseFixture(t, "ovo", "ovo.csv")
+
+    assert.Len(t, drafts, 5)
+    assert.Equal(t, domain.Expense, drafts[0].Type)
+    assert.Equal(t, domain.NewMoney(32000), drafts[0].Amount)
+    assert.Equal(t, "GRAB* A-5XYZ", drafts[0].Payee)
+    assert.Equal(t, date("2026-02-03"), drafts[0].Date)
+
+    assert.Equal(t, domain.Income, drafts[1].Type)
+    // Transaksi gagal dilewati
+    assert.Equal(t, "transaction status is Gagal", drafts[2].SkipReason)
+    assert.Equal(t, domain.Expense, drafts[3].Type)
+    assert.Equal(t, domain.MoneyFromFloat(1600), drafts[4].Amount)
+}
+
+func TestShopeePay(t *testing.T) {
+    drafts := parseFixture(t, "shopeepay", "shopeepay.txt")
+
+    assert.Len(t, drafts, 5)
+    assert.Equal(t, date("2026-02-07"), drafts[0].Date)
+    assert.Equal(t, domain.MoneyFromFloat(123500), drafts[0].Amount)
+    assert.Equal(t, "Shopee Order 2602070ABCDEF", drafts[0].Payee)
+    assert.Equal(t, domain.Income, drafts[2].Type)
+    assert.Equal(t, "Indomaret Kemang", drafts[3].Payee)
+
+    // Baris bertanggal tanpa tanda +/- dilaporkan, bukan diabaikan
+    assert.False(t, drafts[4].Valid())
+    assert.Equal(t, 8, drafts[4].Row)
+}
+
+func TestSources(t *testing.T) {
+    var names []string
+    for _, s := range importer.Sources() {
+        names = append(names, s.Name)
+    }
+    assert.Equal(t, []string{"bca", "csv", "gopay", "mandiri", "ofx", "ovo", "qif", "shopeepay"}, names)
+
+    _, ok := importer.Lookup("bri")
+    assert.False(t, ok)
+}
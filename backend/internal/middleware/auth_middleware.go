@@ -4,11 +4,12 @@ import (
     "strings"
 
     "github.com/gin-gonic/gin"
+    "github.com/myfarism/finance-tracker/internal/repository"
     jwtpkg "github.com/myfarism/finance-tracker/pkg/jwt"
     "github.com/myfarism/finance-tracker/pkg/response"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(sessionRepo repository.SessionRepository) gin.HandlerFunc {
     return func(c *gin.Context) {
         authHeader := c.GetHeader("Authorization")
         if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -25,9 +26,19 @@ func AuthMiddleware() gin.HandlerFunc {
             return
         }
 
+        // Token yang session-nya sudah di-logout / di-revoke ditolak
+        // walaupun belum expired
+        session, err := sessionRepo.FindByID(claims.SessionID)
+        if err != nil || session.IsRevoked() || session.UserID != claims.UserID {
+            response.Unauthorized(c, "session has been revoked")
+            c.Abort()
+            return
+        }
+
         // Simpan data user ke context agar bisa diakses handler lain
         c.Set("userID", claims.UserID)
         c.Set("email", claims.Email)
+        c.Set("sessionID", claims.SessionID)
         c.Next()
     }
 }
@@ -0,0 +1,138 @@
+package repository
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+)
+
+// AccountTotal adalah total pemasukan, pengeluaran & transfer sebuah akun
+type AccountTotal struct {
+    AccountID   uuid.UUID
+    Income      domain.Money
+    Expense     domain.Money
+    TransferIn  domain.Money
+    TransferOut domain.Money
+}
+
+type AccountRepository interface {
+    Create(account *domain.Account) error
+    FindAllByUser(userID uuid.UUID, includeArchived bool) ([]domain.Account, error)
+    FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Account, error)
+    FindDefault(userID uuid.UUID) (*domain.Account, error)
+    Update(account *domain.Account) error
+    SetDefault(id uuid.UUID, userID uuid.UUID) error
+    Delete(id uuid.UUID, userID uuid.UUID) error
+    CountTransactions(id uuid.UUID, userID uuid.UUID) (int64, error)
+    CountRecurringRules(id uuid.UUID, userID uuid.UUID) (int64, error)
+    // SumTransactions menghitung total per akun; until nil = semua transaksi
+    SumTransactions(userID uuid.UUID, until *time.Time) ([]AccountTotal, error)
+}
+
+type accountRepository struct {
+    db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+    return &accountRepository{db}
+}
+
+func (r *accountRepository) Create(account *domain.Account) error {
+    return r.db.Create(account).Error
+}
+
+func (r *accountRepository) FindAllByUser(userID uuid.UUID, includeArchived bool) ([]domain.Account, error) {
+    var accounts []domain.Account
+
+    query := r.db.Where("user_id = ?", userID).
+        Order("is_default DESC, name")
+
+    if !includeArchived {
+        query = query.Where("is_archived = ?", false)
+    }
+
+    err := query.Find(&accounts).Error
+    return accounts, err
+}
+
+func (r *accountRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Account, error) {
+    var account domain.Account
+    err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&account).Error
+    if err != nil {
+        return nil, err
+    }
+    return &account, nil
+}
+
+func (r *accountRepository) FindDefault(userID uuid.UUID) (*domain.Account, error) {
+    var account domain.Account
+    err := r.db.Where("user_id = ? AND is_default = ?", userID, true).First(&account).Error
+    if err != nil {
+        return nil, err
+    }
+    return &account, nil
+}
+
+func (r *accountRepository) Update(account *domain.Account) error {
+    return r.db.Save(account).Error
+}
+
+// SetDefault memindahkan status default dalam satu DB transaction, karena
+// hanya boleh ada satu akun default per user
+func (r *accountRepository) SetDefault(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        err := tx.Model(&domain.Account{}).
+            Where("user_id = ? AND is_default = ?", userID, true).
+            Update("is_default", false).Error
+        if err != nil {
+            return err
+        }
+        return tx.Model(&domain.Account{}).
+            Where("id = ? AND user_id = ?", id, userID).
+            Update("is_default", true).Error
+    })
+}
+
+func (r *accountRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Where("id = ? AND user_id = ?", id, userID).
+        Delete(&domain.Account{}).Error
+}
+
+func (r *accountRepository) CountTransactions(id uuid.UUID, userID uuid.UUID) (int64, error) {
+    var count int64
+    err := r.db.Model(&domain.Transaction{}).
+        Where("account_id = ? AND user_id = ?", id, userID).
+        Count(&count).Error
+    return count, err
+}
+
+func (r *accountRepository) CountRecurringRules(id uuid.UUID, userID uuid.UUID) (int64, error) {
+    var count int64
+    err := r.db.Model(&domain.RecurringRule{}).
+        Where("account_id = ? AND user_id = ?", id, userID).
+        Count(&count).Error
+    return count, err
+}
+
+func (r *accountRepository) SumTransactions(userID uuid.UUID, until *time.Time) ([]AccountTotal, error) {
+    var totals []AccountTotal
+
+    query := r.db.Model(&domain.Transaction{}).
+        Select(`account_id,
+            COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS income,
+            COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS expense,
+            COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS transfer_in,
+            COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS transfer_out`,
+            domain.Income, domain.Expense, domain.TransferIn, domain.TransferOut).
+        Where("user_id = ?", userID).
+        Group("account_id")
+
+    if until != nil {
+        query = query.Where("date <= ?", until)
+    }
+
+    err := query.Scan(&totals).Error
+    return totals, err
+}
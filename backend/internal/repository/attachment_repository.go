@@ -0,0 +1,59 @@
+package repository
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+    Create(attachment *domain.Attachment) error
+    FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Attachment, error)
+    FindByTransaction(transactionID uuid.UUID, userID uuid.UUID) ([]domain.Attachment, error)
+    Delete(id uuid.UUID, userID uuid.UUID) error
+    // TotalSize dipakai untuk cek kuota penyimpanan per user
+    TotalSize(userID uuid.UUID) (int64, error)
+}
+
+type attachmentRepository struct {
+    db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+    return &attachmentRepository{db}
+}
+
+func (r *attachmentRepository) Create(attachment *domain.Attachment) error {
+    return r.db.Create(attachment).Error
+}
+
+func (r *attachmentRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Attachment, error) {
+    var attachment domain.Attachment
+    err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&attachment).Error
+    if err != nil {
+        return nil, err
+    }
+    return &attachment, nil
+}
+
+func (r *attachmentRepository) FindByTransaction(transactionID uuid.UUID, userID uuid.UUID) ([]domain.Attachment, error) {
+    var attachments []domain.Attachment
+    err := r.db.Where("transaction_id = ? AND user_id = ?", transactionID, userID).
+        Order("created_at ASC").
+        Find(&attachments).Error
+    return attachments, err
+}
+
+func (r *attachmentRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Where("id = ? AND user_id = ?", id, userID).
+        Delete(&domain.Attachment{}).Error
+}
+
+func (r *attachmentRepository) TotalSize(userID uuid.UUID) (int64, error) {
+    var total int64
+    err := r.db.Model(&domain.Attachment{}).
+        Select("COALESCE(SUM(size), 0)").
+        Where("user_id = ?", userID).
+        Scan(&total).Error
+    return total, err
+}
@@ -6,10 +6,21 @@ import (
     "gorm.io/gorm"
 )
 
+type CategoryFilter struct {
+    IncludeArchived bool
+    Type            string // income | expense: kategori yang boleh dipakai tipe ini
+}
+
 type CategoryRepository interface {
-    FindAll() ([]domain.Category, error)
-    FindByID(id uuid.UUID) (*domain.Category, error)
+    // FindAllByUser return kategori sistem + kategori milik user
+    FindAllByUser(userID uuid.UUID, filter CategoryFilter) ([]domain.Category, error)
+    // FindByID hanya menemukan kategori sistem atau milik user tersebut
+    FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Category, error)
     Create(category *domain.Category) error
+    Update(category *domain.Category) error
+    Delete(id uuid.UUID, userID uuid.UUID) error
+    CountUsage(id uuid.UUID, userID uuid.UUID) (transactions int64, budgets int64, recurring int64, err error)
+    ReassignAndDelete(fromID, toID, userID uuid.UUID, sumBudgets bool) error
 }
 
 type categoryRepository struct {
@@ -20,15 +31,31 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepository {
     return &categoryRepository{db}
 }
 
-func (r *categoryRepository) FindAll() ([]domain.Category, error) {
+// visibleTo membatasi query ke kategori sistem + milik user
+func visibleTo(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+    return db.Where("(categories.user_id IS NULL OR categories.user_id = ?)", userID)
+}
+
+func (r *categoryRepository) FindAllByUser(userID uuid.UUID, filter CategoryFilter) ([]domain.Category, error) {
     var categories []domain.Category
-    err := r.db.Find(&categories).Error
+
+    query := visibleTo(r.db, userID).
+        Order("categories.user_id NULLS FIRST, categories.name")
+
+    if !filter.IncludeArchived {
+        query = query.Where("categories.is_archived = ?", false)
+    }
+    if filter.Type != "" {
+        query = query.Where("categories.kind IN ?", []string{filter.Type, string(domain.CategoryKindBoth)})
+    }
+
+    err := query.Find(&categories).Error
     return categories, err
 }
 
-func (r *categoryRepository) FindByID(id uuid.UUID) (*domain.Category, error) {
+func (r *categoryRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Category, error) {
     var category domain.Category
-    err := r.db.First(&category, "id = ?", id).Error
+    err := visibleTo(r.db, userID).First(&category, "categories.id = ?", id).Error
     if err != nil {
         return nil, err
     }
@@ -38,3 +65,126 @@ func (r *categoryRepository) FindByID(id uuid.UUID) (*domain.Category, error) {
 func (r *categoryRepository) Create(category *domain.Category) error {
     return r.db.Create(category).Error
 }
+
+func (r *categoryRepository) Update(category *domain.Category) error {
+    return r.db.Save(category).Error
+}
+
+func (r *categoryRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        if err := promoteChildren(tx, id, userID); err != nil {
+            return err
+        }
+        return tx.Where("id = ? AND user_id = ?", id, userID).
+            Delete(&domain.Category{}).Error
+    })
+}
+
+// promoteChildren memindahkan sub-kategori ke parent dari kategori yang
+// akan dihapus, supaya tidak ada sub-kategori yang kehilangan parent
+func promoteChildren(tx *gorm.DB, id uuid.UUID, userID uuid.UUID) error {
+    return tx.Exec(`
+        UPDATE categories SET parent_id = (SELECT parent_id FROM categories WHERE id = ?)
+        WHERE parent_id = ? AND user_id = ?`, id, id, userID).Error
+}
+
+func (r *categoryRepository) CountUsage(id uuid.UUID, userID uuid.UUID) (int64, int64, int64, error) {
+    var transactions, budgets, recurring int64
+
+    // Transaksi split dihitung jika salah satu split-nya memakai kategori ini
+    err := r.db.Model(&domain.Transaction{}).
+        Where("user_id = ? AND (category_id = ? OR id IN (?))", userID, id,
+            r.db.Model(&domain.TransactionSplit{}).Select("transaction_id").Where("category_id = ?", id)).
+        Count(&transactions).Error
+    if err != nil {
+        return 0, 0, 0, err
+    }
+
+    err = r.db.Model(&domain.Budget{}).
+        Where("category_id = ? AND user_id = ?", id, userID).
+        Count(&budgets).Error
+    if err != nil {
+        return 0, 0, 0, err
+    }
+
+    err = r.db.Model(&domain.RecurringRule{}).
+        Where("category_id = ? AND user_id = ?", id, userID).
+        Count(&recurring).Error
+    return transactions, budgets, recurring, err
+}
+
+// ReassignAndDelete memindahkan semua transaksi, split, rule, recurring dan
+// budget user dari kategori fromID ke toID lalu menghapus fromID, dalam satu DB
+// transaction. Jika bulan yang sama sudah punya budget di toID: sumBudgets=true
+// menjumlahkan nominalnya (merge), false mempertahankan budget toID.
+func (r *categoryRepository) ReassignAndDelete(fromID, toID, userID uuid.UUID, sumBudgets bool) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        err := tx.Model(&domain.Transaction{}).
+            Where("category_id = ? AND user_id = ?", fromID, userID).
+            Update("category_id", toID).Error
+        if err != nil {
+            return err
+        }
+        err = tx.Exec(`
+            UPDATE transaction_splits SET category_id = ?
+            WHERE category_id = ? AND transaction_id IN (SELECT id FROM transactions WHERE user_id = ?)`,
+            toID, fromID, userID).Error
+        if err != nil {
+            return err
+        }
+
+        err = tx.Model(&domain.TransactionRule{}).
+            Where("category_id = ? AND user_id = ?", fromID, userID).
+            Update("category_id", toID).Error
+        if err != nil {
+            return err
+        }
+
+        err = tx.Model(&domain.RecurringRule{}).
+            Where("category_id = ? AND user_id = ?", fromID, userID).
+            Update("category_id", toID).Error
+        if err != nil {
+            return err
+        }
+
+        if sumBudgets {
+            err = tx.Exec(`
+                UPDATE budgets target SET amount = target.amount + source.amount
+                FROM budgets source
+                WHERE source.user_id = ? AND source.category_id = ?
+                  AND target.user_id = source.user_id AND target.category_id = ?
+                  AND target.month = source.month AND target.year = source.year`,
+                userID, fromID, toID).Error
+            if err != nil {
+                return err
+            }
+        }
+
+        // Budget yang bentrok dengan budget toID di bulan yang sama dihapus,
+        // sisanya dipindah ke toID
+        err = tx.Exec(`
+            DELETE FROM budgets source
+            USING budgets target
+            WHERE source.user_id = ? AND source.category_id = ?
+              AND target.user_id = source.user_id AND target.category_id = ?
+              AND target.month = source.month AND target.year = source.year`,
+            userID, fromID, toID).Error
+        if err != nil {
+            return err
+        }
+
+        err = tx.Model(&domain.Budget{}).
+            Where("category_id = ? AND user_id = ?", fromID, userID).
+            Update("category_id", toID).Error
+        if err != nil {
+            return err
+        }
+
+        if err := promoteChildren(tx, fromID, userID); err != nil {
+            return err
+        }
+
+        return tx.Where("id = ? AND user_id = ?", fromID, userID).
+            Delete(&domain.Category{}).Error
+    })
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type DuplicateRepository interface {
+    FindDismissed(userID uuid.UUID) ([]domain.DuplicateDismissal, error)
+    // Dismiss mengabaikan pasangan yang sudah pernah di-dismiss
+    Dismiss(dismissals []domain.DuplicateDismissal) error
+    // Merge memindahkan tag & lampiran duplikat ke keep, menyimpan keep,
+    // lalu menghapus duplikatnya dalam satu DB transaction
+    Merge(keep *domain.Transaction, duplicateIDs []uuid.UUID) error
+}
+
+type duplicateRepository struct {
+    db *gorm.DB
+}
+
+func NewDuplicateRepository(db *gorm.DB) DuplicateRepository {
+    return &duplicateRepository{db}
+}
+
+func (r *duplicateRepository) FindDismissed(userID uuid.UUID) ([]domain.DuplicateDismissal, error) {
+    var dismissals []domain.DuplicateDismissal
+    err := r.db.Where("user_id = ?", userID).Find(&dismissals).Error
+    return dismissals, err
+}
+
+func (r *duplicateRepository) Dismiss(dismissals []domain.DuplicateDismissal) error {
+    return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&dismissals).Error
+}
+
+func (r *duplicateRepository) Merge(keep *domain.Transaction, duplicateIDs []uuid.UUID) error {
+    return r.db.Transaction(func(db *gorm.DB) error {
+        err := db.Exec(`
+            INSERT INTO transaction_tags (transaction_id, tag_id)
+            SELECT ?, tag_id FROM transaction_tags WHERE transaction_id IN ?
+            ON CONFLICT DO NOTHING`, keep.ID, duplicateIDs).Error
+        if err != nil {
+            return err
+        }
+        err = db.Model(&domain.Attachment{}).
+            Where("transaction_id IN ? AND user_id = ?", duplicateIDs, keep.UserID).
+            Update("transaction_id", keep.ID).Error
+        if err != nil {
+            return err
+        }
+
+        // Duplikat dihapus dulu supaya external_id / recurring occurrence
+        // yang diambil alih keep tidak bentrok dengan unique index
+        err = db.Where("transaction_id IN ?", duplicateIDs).Delete(&domain.TransactionSplit{}).Error
+        if err != nil {
+            return err
+        }
+        err = db.Exec(`DELETE FROM transaction_tags WHERE transaction_id IN ?`, duplicateIDs).Error
+        if err != nil {
+            return err
+        }
+        err = db.Where("id IN ? AND user_id = ?", duplicateIDs, keep.UserID).Delete(&domain.Transaction{}).Error
+        if err != nil {
+            return err
+        }
+        return db.Omit(clause.Associations).Save(keep).Error
+    })
+}
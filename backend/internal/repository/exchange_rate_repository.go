@@ -0,0 +1,72 @@
+package repository
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type ExchangeRateFilter struct {
+    FromCurrency string
+    ToCurrency   string
+}
+
+type ExchangeRateRepository interface {
+    // Upsert menimpa kurs untuk pasangan mata uang + tanggal yang sama
+    Upsert(rate *domain.ExchangeRate) error
+    // UpsertMany dipakai import CSV, semua baris dalam satu DB transaction
+    UpsertMany(rates []domain.ExchangeRate) error
+    FindAllByUser(userID uuid.UUID, filter ExchangeRateFilter) ([]domain.ExchangeRate, error)
+    Delete(id uuid.UUID, userID uuid.UUID) error
+}
+
+type exchangeRateRepository struct {
+    db *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) ExchangeRateRepository {
+    return &exchangeRateRepository{db}
+}
+
+func upsertRates(db *gorm.DB, value interface{}) error {
+    return db.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "user_id"}, {Name: "from_currency"}, {Name: "to_currency"}, {Name: "date"}},
+        DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+    }).Create(value).Error
+}
+
+func (r *exchangeRateRepository) Upsert(rate *domain.ExchangeRate) error {
+    return upsertRates(r.db, rate)
+}
+
+func (r *exchangeRateRepository) UpsertMany(rates []domain.ExchangeRate) error {
+    if len(rates) == 0 {
+        return nil
+    }
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        return upsertRates(tx, &rates)
+    })
+}
+
+func (r *exchangeRateRepository) FindAllByUser(userID uuid.UUID, filter ExchangeRateFilter) ([]domain.ExchangeRate, error) {
+    var rates []domain.ExchangeRate
+
+    query := r.db.Where("user_id = ?", userID).
+        Order("date DESC, from_currency, to_currency")
+
+    if filter.FromCurrency != "" {
+        query = query.Where("from_currency = ?", filter.FromCurrency)
+    }
+    if filter.ToCurrency != "" {
+        query = query.Where("to_currency = ?", filter.ToCurrency)
+    }
+
+    err := query.Find(&rates).Error
+    return rates, err
+}
+
+func (r *exchangeRateRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Where("id = ? AND user_id = ?", id, userID).
+        Delete(&domain.ExchangeRate{}).Error
+}
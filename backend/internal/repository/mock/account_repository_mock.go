@@ -0,0 +1,70 @@
+package mock
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockAccountRepository struct {
+    mock.Mock
+}
+
+func (m *MockAccountRepository) Create(account *domain.Account) error {
+    args := m.Called(account)
+    return args.Error(0)
+}
+
+func (m *MockAccountRepository) FindAllByUser(userID uuid.UUID, includeArchived bool) ([]domain.Account, error) {
+    args := m.Called(userID, includeArchived)
+    return args.Get(0).([]domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Account, error) {
+    args := m.Called(id, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindDefault(userID uuid.UUID) (*domain.Account, error) {
+    args := m.Called(userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Update(account *domain.Account) error {
+    args := m.Called(account)
+    return args.Error(0)
+}
+
+func (m *MockAccountRepository) SetDefault(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
+
+func (m *MockAccountRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
+
+func (m *MockAccountRepository) CountTransactions(id uuid.UUID, userID uuid.UUID) (int64, error) {
+    args := m.Called(id, userID)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAccountRepository) CountRecurringRules(id uuid.UUID, userID uuid.UUID) (int64, error) {
+    args := m.Called(id, userID)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAccountRepository) SumTransactions(userID uuid.UUID, until *time.Time) ([]repository.AccountTotal, error) {
+    args := m.Called(userID, until)
+    return args.Get(0).([]repository.AccountTotal), args.Error(1)
+}
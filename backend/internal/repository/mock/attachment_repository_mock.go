@@ -0,0 +1,39 @@
+package mock
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockAttachmentRepository struct {
+    mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(attachment *domain.Attachment) error {
+    args := m.Called(attachment)
+    return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Attachment, error) {
+    args := m.Called(id, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) FindByTransaction(transactionID uuid.UUID, userID uuid.UUID) ([]domain.Attachment, error) {
+    args := m.Called(transactionID, userID)
+    return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) TotalSize(userID uuid.UUID) (int64, error) {
+    args := m.Called(userID)
+    return args.Get(0).(int64), args.Error(1)
+}
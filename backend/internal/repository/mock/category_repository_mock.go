@@ -4,6 +4,7 @@ package mock
 import (
     "github.com/google/uuid"
     "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
     "github.com/stretchr/testify/mock"
 )
 
@@ -11,13 +12,13 @@ type MockCategoryRepository struct {
     mock.Mock
 }
 
-func (m *MockCategoryRepository) FindAll() ([]domain.Category, error) {
-    args := m.Called()
+func (m *MockCategoryRepository) FindAllByUser(userID uuid.UUID, filter repository.CategoryFilter) ([]domain.Category, error) {
+    args := m.Called(userID, filter)
     return args.Get(0).([]domain.Category), args.Error(1)
 }
 
-func (m *MockCategoryRepository) FindByID(id uuid.UUID) (*domain.Category, error) {
-    args := m.Called(id)
+func (m *MockCategoryRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Category, error) {
+    args := m.Called(id, userID)
     if args.Get(0) == nil {
         return nil, args.Error(1)
     }
@@ -28,3 +29,23 @@ func (m *MockCategoryRepository) Create(category *domain.Category) error {
     args := m.Called(category)
     return args.Error(0)
 }
+
+func (m *MockCategoryRepository) Update(category *domain.Category) error {
+    args := m.Called(category)
+    return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
+
+func (m *MockCategoryRepository) CountUsage(id uuid.UUID, userID uuid.UUID) (int64, int64, int64, error) {
+    args := m.Called(id, userID)
+    return args.Get(0).(int64), args.Get(1).(int64), args.Get(2).(int64), args.Error(3)
+}
+
+func (m *MockCategoryRepository) ReassignAndDelete(fromID, toID, userID uuid.UUID, sumBudgets bool) error {
+    args := m.Called(fromID, toID, userID, sumBudgets)
+    return args.Error(0)
+}
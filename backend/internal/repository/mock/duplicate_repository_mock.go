@@ -0,0 +1,26 @@
+package mock
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockDuplicateRepository struct {
+    mock.Mock
+}
+
+func (m *MockDuplicateRepository) FindDismissed(userID uuid.UUID) ([]domain.DuplicateDismissal, error) {
+    args := m.Called(userID)
+    return args.Get(0).([]domain.DuplicateDismissal), args.Error(1)
+}
+
+func (m *MockDuplicateRepository) Dismiss(dismissals []domain.DuplicateDismissal) error {
+    args := m.Called(dismissals)
+    return args.Error(0)
+}
+
+func (m *MockDuplicateRepository) Merge(keep *domain.Transaction, duplicateIDs []uuid.UUID) error {
+    args := m.Called(keep, duplicateIDs)
+    return args.Error(0)
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockExchangeRateRepository struct {
+    mock.Mock
+}
+
+func (m *MockExchangeRateRepository) Upsert(rate *domain.ExchangeRate) error {
+    args := m.Called(rate)
+    return args.Error(0)
+}
+
+func (m *MockExchangeRateRepository) UpsertMany(rates []domain.ExchangeRate) error {
+    args := m.Called(rates)
+    return args.Error(0)
+}
+
+func (m *MockExchangeRateRepository) FindAllByUser(userID uuid.UUID, filter repository.ExchangeRateFilter) ([]domain.ExchangeRate, error) {
+    args := m.Called(userID, filter)
+    return args.Get(0).([]domain.ExchangeRate), args.Error(1)
+}
+
+func (m *MockExchangeRateRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
@@ -0,0 +1,66 @@
+package mock
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockRecurringRepository struct {
+    mock.Mock
+}
+
+func (m *MockRecurringRepository) Create(rule *domain.RecurringRule) error {
+    args := m.Called(rule)
+    return args.Error(0)
+}
+
+func (m *MockRecurringRepository) FindAllByUser(userID uuid.UUID) ([]domain.RecurringRule, error) {
+    args := m.Called(userID)
+    return args.Get(0).([]domain.RecurringRule), args.Error(1)
+}
+
+func (m *MockRecurringRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.RecurringRule, error) {
+    args := m.Called(id, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.RecurringRule), args.Error(1)
+}
+
+// Modify menjalankan fn terhadap rule dari Return(rule, err), seperti
+// repository asli yang membaca rule terkunci lalu menyimpannya
+func (m *MockRecurringRepository) Modify(id uuid.UUID, userID uuid.UUID, fn func(rule *domain.RecurringRule) error) (*domain.RecurringRule, error) {
+    args := m.Called(id, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    rule := args.Get(0).(*domain.RecurringRule)
+    if err := args.Error(1); err != nil {
+        return nil, err
+    }
+    if err := fn(rule); err != nil {
+        return nil, err
+    }
+    return rule, nil
+}
+
+func (m *MockRecurringRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
+
+func (m *MockRecurringRepository) FindDue(today time.Time) ([]uuid.UUID, error) {
+    args := m.Called(today)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRecurringRepository) Materialize(id uuid.UUID, today time.Time) (int, error) {
+    args := m.Called(id, today)
+    return args.Int(0), args.Error(1)
+}
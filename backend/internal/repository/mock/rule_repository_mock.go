@@ -0,0 +1,44 @@
+package mock
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockRuleRepository struct {
+    mock.Mock
+}
+
+func (m *MockRuleRepository) Create(rule *domain.TransactionRule) error {
+    args := m.Called(rule)
+    return args.Error(0)
+}
+
+func (m *MockRuleRepository) FindAllByUser(userID uuid.UUID) ([]domain.TransactionRule, error) {
+    args := m.Called(userID)
+    return args.Get(0).([]domain.TransactionRule), args.Error(1)
+}
+
+func (m *MockRuleRepository) FindActiveByUser(userID uuid.UUID) ([]domain.TransactionRule, error) {
+    args := m.Called(userID)
+    return args.Get(0).([]domain.TransactionRule), args.Error(1)
+}
+
+func (m *MockRuleRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.TransactionRule, error) {
+    args := m.Called(id, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.TransactionRule), args.Error(1)
+}
+
+func (m *MockRuleRepository) Update(rule *domain.TransactionRule) error {
+    args := m.Called(rule)
+    return args.Error(0)
+}
+
+func (m *MockRuleRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
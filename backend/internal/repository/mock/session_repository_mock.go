@@ -0,0 +1,52 @@
+package mock
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockSessionRepository struct {
+    mock.Mock
+}
+
+func (m *MockSessionRepository) Create(session *domain.Session) error {
+    args := m.Called(session)
+    return args.Error(0)
+}
+
+func (m *MockSessionRepository) FindByID(id uuid.UUID) (*domain.Session, error) {
+    args := m.Called(id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) Revoke(id uuid.UUID) error {
+    args := m.Called(id)
+    return args.Error(0)
+}
+
+func (m *MockSessionRepository) RevokeAllByUser(userID uuid.UUID) error {
+    args := m.Called(userID)
+    return args.Error(0)
+}
+
+func (m *MockSessionRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+    args := m.Called(token)
+    return args.Error(0)
+}
+
+func (m *MockSessionRepository) FindRefreshTokenByHash(hash string) (*domain.RefreshToken, error) {
+    args := m.Called(hash)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockSessionRepository) MarkRefreshTokenUsed(id uuid.UUID) (bool, error) {
+    args := m.Called(id)
+    return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+    mock.Mock
+}
+
+func (m *MockTagRepository) FindAllByUser(userID uuid.UUID) ([]domain.Tag, error) {
+    args := m.Called(userID)
+    return args.Get(0).([]domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Tag, error) {
+    args := m.Called(id, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByName(name string, userID uuid.UUID) (*domain.Tag, error) {
+    args := m.Called(name, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindOrCreate(userID uuid.UUID, names []string) ([]domain.Tag, error) {
+    args := m.Called(userID, names)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Create(tag *domain.Tag) error {
+    args := m.Called(tag)
+    return args.Error(0)
+}
+
+func (m *MockTagRepository) Update(tag *domain.Tag) error {
+    args := m.Called(tag)
+    return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
+
+func (m *MockTagRepository) Merge(fromID, toID, userID uuid.UUID) error {
+    args := m.Called(fromID, toID, userID)
+    return args.Error(0)
+}
+
+func (m *MockTagRepository) SumByTag(userID uuid.UUID, start, end time.Time) ([]repository.TagTotal, error) {
+    args := m.Called(userID, start, end)
+    return args.Get(0).([]repository.TagTotal), args.Error(1)
+}
@@ -16,11 +16,40 @@ func (m *MockTransactionRepository) Create(tx *domain.Transaction) error {
     return args.Error(0)
 }
 
+func (m *MockTransactionRepository) CreateBatch(transactions []domain.Transaction) error {
+    args := m.Called(transactions)
+    return args.Error(0)
+}
+
+func (m *MockTransactionRepository) FindExternalIDs(accountID uuid.UUID, ids []string) ([]string, error) {
+    args := m.Called(accountID, ids)
+    return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockTransactionRepository) FindAllByUser(userID uuid.UUID, filter repository.TransactionFilter) ([]domain.Transaction, error) {
     args := m.Called(userID, filter)
     return args.Get(0).([]domain.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) FindPageByUser(userID uuid.UUID, filter repository.TransactionFilter, page repository.PageQuery) ([]domain.Transaction, *repository.Cursor, error) {
+    args := m.Called(userID, filter, page)
+    var next *repository.Cursor
+    if args.Get(1) != nil {
+        next = args.Get(1).(*repository.Cursor)
+    }
+    return args.Get(0).([]domain.Transaction), next, args.Error(2)
+}
+
+func (m *MockTransactionRepository) SumByFilter(userID uuid.UUID, filter repository.TransactionFilter) (int64, []repository.CurrencyTotal, error) {
+    args := m.Called(userID, filter)
+    return args.Get(0).(int64), args.Get(1).([]repository.CurrencyTotal), args.Error(2)
+}
+
+func (m *MockTransactionRepository) Search(userID uuid.UUID, query string, filter repository.TransactionFilter, limit int) ([]repository.SearchHit, error) {
+    args := m.Called(userID, query, filter, limit)
+    return args.Get(0).([]repository.SearchHit), args.Error(1)
+}
+
 func (m *MockTransactionRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Transaction, error) {
     args := m.Called(id, userID)
     if args.Get(0) == nil {
@@ -34,12 +63,22 @@ func (m *MockTransactionRepository) Update(tx *domain.Transaction) error {
     return args.Error(0)
 }
 
+func (m *MockTransactionRepository) UpdateWithSplits(tx *domain.Transaction) error {
+    args := m.Called(tx)
+    return args.Error(0)
+}
+
+func (m *MockTransactionRepository) UpdateBatch(transactions []domain.Transaction) error {
+    args := m.Called(transactions)
+    return args.Error(0)
+}
+
 func (m *MockTransactionRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
     args := m.Called(id, userID)
     return args.Error(0)
 }
 
-func (m *MockTransactionRepository) GetSummaryByUser(userID uuid.UUID, month, year int) (float64, float64, error) {
+func (m *MockTransactionRepository) GetSummaryByUser(userID uuid.UUID, month, year int) ([]repository.CurrencyTotal, error) {
     args := m.Called(userID, month, year)
-    return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+    return args.Get(0).([]repository.CurrencyTotal), args.Error(1)
 }
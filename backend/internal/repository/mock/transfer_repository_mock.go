@@ -0,0 +1,34 @@
+package mock
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/stretchr/testify/mock"
+)
+
+type MockTransferRepository struct {
+    mock.Mock
+}
+
+func (m *MockTransferRepository) Create(transfer *domain.Transfer) error {
+    args := m.Called(transfer)
+    return args.Error(0)
+}
+
+func (m *MockTransferRepository) FindAllByUser(userID uuid.UUID) ([]domain.Transfer, error) {
+    args := m.Called(userID)
+    return args.Get(0).([]domain.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Transfer, error) {
+    args := m.Called(id, userID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    args := m.Called(id, userID)
+    return args.Error(0)
+}
@@ -35,3 +35,13 @@ func (m *MockUserRepository) UpdateVerified(id uuid.UUID, status bool) error {
     args := m.Called(id, status)
     return args.Error(0)
 }
+
+func (m *MockUserRepository) UpdatePassword(id uuid.UUID, hashedPassword string) error {
+    args := m.Called(id, hashedPassword)
+    return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateBaseCurrency(id uuid.UUID, currency string) error {
+    args := m.Called(id, currency)
+    return args.Error(0)
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+    "errors"
+    "log"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+type RecurringRepository interface {
+    Create(rule *domain.RecurringRule) error
+    FindAllByUser(userID uuid.UUID) ([]domain.RecurringRule, error)
+    FindByID(id uuid.UUID, userID uuid.UUID) (*domain.RecurringRule, error)
+    // Modify membaca rule dengan SELECT ... FOR UPDATE, menjalankan fn lalu
+    // menyimpannya dalam satu DB transaction, supaya perubahan dari user
+    // tidak menimpa NextRunDate/OccurrenceCount hasil Materialize
+    Modify(id uuid.UUID, userID uuid.UUID, fn func(rule *domain.RecurringRule) error) (*domain.RecurringRule, error)
+    // Delete tidak menghapus transaksi yang sudah dibuat, hanya melepas relasinya
+    Delete(id uuid.UUID, userID uuid.UUID) error
+    // FindDue mengambil ID rule aktif yang punya occurrence sampai today
+    FindDue(today time.Time) ([]uuid.UUID, error)
+    // Materialize membuat semua occurrence rule yang jatuh tempo sampai today
+    // dalam satu DB transaction. Rule dikunci dengan SKIP LOCKED sehingga
+    // replica lain tidak memproses rule yang sama; unique index
+    // (recurring_rule_id, date) menjaga tidak ada transaksi ganda. Rule
+    // dengan kategori yang sudah di-archive di-pause, bukan diproses.
+    Materialize(id uuid.UUID, today time.Time) (int, error)
+}
+
+type recurringRepository struct {
+    db *gorm.DB
+}
+
+func NewRecurringRepository(db *gorm.DB) RecurringRepository {
+    return &recurringRepository{db}
+}
+
+func (r *recurringRepository) Create(rule *domain.RecurringRule) error {
+    return r.db.Omit("Account", "Category").Create(rule).Error
+}
+
+func (r *recurringRepository) FindAllByUser(userID uuid.UUID) ([]domain.RecurringRule, error) {
+    var rules []domain.RecurringRule
+    err := r.db.Where("user_id = ?", userID).
+        Preload("Account").
+        Preload("Category").
+        Order("next_run_date ASC, created_at ASC").
+        Find(&rules).Error
+    return rules, err
+}
+
+func (r *recurringRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.RecurringRule, error) {
+    var rule domain.RecurringRule
+    err := r.db.Where("id = ? AND user_id = ?", id, userID).
+        Preload("Account").
+        Preload("Category").
+        First(&rule).Error
+    if err != nil {
+        return nil, err
+    }
+    return &rule, nil
+}
+
+func (r *recurringRepository) Modify(id uuid.UUID, userID uuid.UUID, fn func(rule *domain.RecurringRule) error) (*domain.RecurringRule, error) {
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        var rule domain.RecurringRule
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+            Where("id = ? AND user_id = ?", id, userID).
+            First(&rule).Error
+        if err != nil {
+            return err
+        }
+        if err := fn(&rule); err != nil {
+            return err
+        }
+        return tx.Omit("Account", "Category").Save(&rule).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return r.FindByID(id, userID)
+}
+
+func (r *recurringRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        err := tx.Model(&domain.Transaction{}).
+            Where("recurring_rule_id = ? AND user_id = ?", id, userID).
+            Update("recurring_rule_id", nil).Error
+        if err != nil {
+            return err
+        }
+        return tx.Where("id = ? AND user_id = ?", id, userID).
+            Delete(&domain.RecurringRule{}).Error
+    })
+}
+
+func (r *recurringRepository) FindDue(today time.Time) ([]uuid.UUID, error) {
+    var ids []uuid.UUID
+    err := r.db.Model(&domain.RecurringRule{}).
+        Where("is_paused = false AND is_finished = false AND next_run_date <= ?", domain.DateOnly(today)).
+        Order("next_run_date ASC").
+        Pluck("id", &ids).Error
+    return ids, err
+}
+
+func (r *recurringRepository) Materialize(id uuid.UUID, today time.Time) (int, error) {
+    created := 0
+    err := r.db.Transaction(func(tx *gorm.DB) error {
+        var rule domain.RecurringRule
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+            Where("id = ?", id).
+            First(&rule).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil // sudah dihapus atau sedang diproses replica lain
+        }
+        if err != nil {
+            return err
+        }
+
+        var account domain.Account
+        if err := tx.Where("id = ?", rule.AccountID).First(&account).Error; err != nil {
+            return err
+        }
+
+        // Kategori yang di-archive tidak boleh dipakai transaksi baru, sama
+        // seperti input manual; rule di-pause sampai user memperbaikinya
+        var category domain.Category
+        if err := tx.Where("id = ?", rule.CategoryID).First(&category).Error; err != nil {
+            return err
+        }
+        if category.IsArchived {
+            log.Printf("recurring rule %s paused: category %q is archived", rule.ID, category.Name)
+            return tx.Model(&rule).Update("is_paused", true).Error
+        }
+
+        for rule.Due(today) {
+            transaction := rule.NextTransaction(account.Currency)
+            res := tx.Omit("Category", "Account").
+                Clauses(clause.OnConflict{DoNothing: true}).
+                Create(&transaction)
+            if res.Error != nil {
+                return res.Error
+            }
+            if res.RowsAffected == 0 {
+                // Transaksi untuk tanggal ini sudah ada, tidak dihitung lagi
+                rule.Advance()
+                continue
+            }
+            created++
+            rule.Materialized()
+        }
+        return tx.Omit("Account", "Category").Save(&rule).Error
+    })
+    if err != nil {
+        return 0, err
+    }
+    return created, nil
+}
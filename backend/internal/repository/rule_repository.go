@@ -0,0 +1,79 @@
+package repository
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+)
+
+type RuleRepository interface {
+    Create(rule *domain.TransactionRule) error
+    // FindAllByUser urut prioritas, sama dengan urutan evaluasi
+    FindAllByUser(userID uuid.UUID) ([]domain.TransactionRule, error)
+    FindActiveByUser(userID uuid.UUID) ([]domain.TransactionRule, error)
+    FindByID(id uuid.UUID, userID uuid.UUID) (*domain.TransactionRule, error)
+    Update(rule *domain.TransactionRule) error
+    Delete(id uuid.UUID, userID uuid.UUID) error
+}
+
+type ruleRepository struct {
+    db *gorm.DB
+}
+
+func NewRuleRepository(db *gorm.DB) RuleRepository {
+    return &ruleRepository{db}
+}
+
+func (r *ruleRepository) Create(rule *domain.TransactionRule) error {
+    return r.db.Omit("Category").Create(rule).Error
+}
+
+func (r *ruleRepository) ordered(userID uuid.UUID) *gorm.DB {
+    return r.db.Preload("Category").Preload("Tags").
+        Where("user_id = ?", userID).
+        Order("priority ASC, created_at ASC")
+}
+
+func (r *ruleRepository) FindAllByUser(userID uuid.UUID) ([]domain.TransactionRule, error) {
+    var rules []domain.TransactionRule
+    err := r.ordered(userID).Find(&rules).Error
+    return rules, err
+}
+
+func (r *ruleRepository) FindActiveByUser(userID uuid.UUID) ([]domain.TransactionRule, error) {
+    var rules []domain.TransactionRule
+    err := r.ordered(userID).Where("is_active").Find(&rules).Error
+    return rules, err
+}
+
+func (r *ruleRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.TransactionRule, error) {
+    var rule domain.TransactionRule
+    err := r.db.Preload("Category").Preload("Tags").
+        Where("id = ? AND user_id = ?", id, userID).
+        First(&rule).Error
+    if err != nil {
+        return nil, err
+    }
+    return &rule, nil
+}
+
+func (r *ruleRepository) Update(rule *domain.TransactionRule) error {
+    return r.db.Transaction(func(db *gorm.DB) error {
+        if err := db.Omit("Category", "Tags").Save(rule).Error; err != nil {
+            return err
+        }
+        return db.Model(rule).Association("Tags").Replace(rule.Tags)
+    })
+}
+
+func (r *ruleRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Transaction(func(db *gorm.DB) error {
+        err := db.Exec(`
+            DELETE FROM transaction_rule_tags
+            WHERE transaction_rule_id IN (SELECT id FROM transaction_rules WHERE id = ? AND user_id = ?)`, id, userID).Error
+        if err != nil {
+            return err
+        }
+        return db.Where("id = ? AND user_id = ?", id, userID).Delete(&domain.TransactionRule{}).Error
+    })
+}
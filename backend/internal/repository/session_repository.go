@@ -0,0 +1,78 @@
+package repository
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+)
+
+type SessionRepository interface {
+    Create(session *domain.Session) error
+    FindByID(id uuid.UUID) (*domain.Session, error)
+    Revoke(id uuid.UUID) error
+    RevokeAllByUser(userID uuid.UUID) error
+    CreateRefreshToken(token *domain.RefreshToken) error
+    FindRefreshTokenByHash(hash string) (*domain.RefreshToken, error)
+    MarkRefreshTokenUsed(id uuid.UUID) (bool, error)
+}
+
+type sessionRepository struct {
+    db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+    return &sessionRepository{db}
+}
+
+func (r *sessionRepository) Create(session *domain.Session) error {
+    return r.db.Create(session).Error
+}
+
+func (r *sessionRepository) FindByID(id uuid.UUID) (*domain.Session, error) {
+    var session domain.Session
+    err := r.db.Where("id = ?", id).First(&session).Error
+    if err != nil {
+        return nil, err
+    }
+    return &session, nil
+}
+
+func (r *sessionRepository) Revoke(id uuid.UUID) error {
+    return r.db.Model(&domain.Session{}).
+        Where("id = ? AND revoked_at IS NULL", id).
+        Update("revoked_at", time.Now()).Error
+}
+
+func (r *sessionRepository) RevokeAllByUser(userID uuid.UUID) error {
+    return r.db.Model(&domain.Session{}).
+        Where("user_id = ? AND revoked_at IS NULL", userID).
+        Update("revoked_at", time.Now()).Error
+}
+
+func (r *sessionRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+    return r.db.Create(token).Error
+}
+
+func (r *sessionRepository) FindRefreshTokenByHash(hash string) (*domain.RefreshToken, error) {
+    var token domain.RefreshToken
+    err := r.db.Where("token_hash = ?", hash).First(&token).Error
+    if err != nil {
+        return nil, err
+    }
+    return &token, nil
+}
+
+// MarkRefreshTokenUsed menandai token sudah dipakai secara atomik.
+// Return false jika token sudah pernah dipakai sebelumnya (termasuk
+// ketika dua request refresh datang bersamaan).
+func (r *sessionRepository) MarkRefreshTokenUsed(id uuid.UUID) (bool, error) {
+    result := r.db.Model(&domain.RefreshToken{}).
+        Where("id = ? AND used_at IS NULL", id).
+        Update("used_at", time.Now())
+    if result.Error != nil {
+        return false, result.Error
+    }
+    return result.RowsAffected > 0, nil
+}
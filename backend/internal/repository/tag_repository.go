@@ -0,0 +1,172 @@
+package repository
+
+import (
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// TagTotal dikelompokkan per tag, tipe, mata uang & tanggal supaya service
+// bisa mengonversi dengan kurs di tanggal transaksi
+type TagTotal struct {
+    TagID    uuid.UUID
+    Type     domain.TransactionType
+    Currency string
+    Date     time.Time
+    Amount   domain.Money
+    Count    int
+}
+
+type TagRepository interface {
+    FindAllByUser(userID uuid.UUID) ([]domain.Tag, error)
+    FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Tag, error)
+    FindByName(name string, userID uuid.UUID) (*domain.Tag, error)
+    // FindOrCreate mengembalikan tag dengan nama-nama tersebut, yang belum
+    // ada dibuat dulu
+    FindOrCreate(userID uuid.UUID, names []string) ([]domain.Tag, error)
+    Create(tag *domain.Tag) error
+    Update(tag *domain.Tag) error
+    Delete(id uuid.UUID, userID uuid.UUID) error
+    // Merge memindahkan semua transaksi dari fromID ke toID lalu menghapus fromID
+    Merge(fromID, toID, userID uuid.UUID) error
+    SumByTag(userID uuid.UUID, start, end time.Time) ([]TagTotal, error)
+}
+
+type tagRepository struct {
+    db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+    return &tagRepository{db}
+}
+
+func (r *tagRepository) FindAllByUser(userID uuid.UUID) ([]domain.Tag, error) {
+    var tags []domain.Tag
+    err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&tags).Error
+    return tags, err
+}
+
+func (r *tagRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Tag, error) {
+    var tag domain.Tag
+    err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&tag).Error
+    if err != nil {
+        return nil, err
+    }
+    return &tag, nil
+}
+
+func (r *tagRepository) FindByName(name string, userID uuid.UUID) (*domain.Tag, error) {
+    var tag domain.Tag
+    err := r.db.Where("name = ? AND user_id = ?", name, userID).First(&tag).Error
+    if err != nil {
+        return nil, err
+    }
+    return &tag, nil
+}
+
+func (r *tagRepository) FindOrCreate(userID uuid.UUID, names []string) ([]domain.Tag, error) {
+    if len(names) == 0 {
+        return nil, nil
+    }
+
+    tags := make([]domain.Tag, len(names))
+    for i, name := range names {
+        tags[i] = domain.Tag{ID: uuid.New(), UserID: userID, Name: name}
+    }
+    err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+    if err != nil {
+        return nil, err
+    }
+
+    var existing []domain.Tag
+    err = r.db.Where("user_id = ? AND name IN ?", userID, names).Order("name ASC").Find(&existing).Error
+    return existing, err
+}
+
+func (r *tagRepository) Create(tag *domain.Tag) error {
+    return r.db.Create(tag).Error
+}
+
+func (r *tagRepository) Update(tag *domain.Tag) error {
+    return r.db.Save(tag).Error
+}
+
+// Delete juga melepas tag dari rule; rule yang tidak punya aksi lain
+// dinonaktifkan karena tidak lagi lolos Validate
+func (r *tagRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        err := tx.Exec(`
+            DELETE FROM transaction_tags
+            WHERE tag_id IN (SELECT id FROM tags WHERE id = ? AND user_id = ?)`, id, userID).Error
+        if err != nil {
+            return err
+        }
+        err = tx.Exec(`
+            DELETE FROM transaction_rule_tags
+            WHERE tag_id IN (SELECT id FROM tags WHERE id = ? AND user_id = ?)`, id, userID).Error
+        if err != nil {
+            return err
+        }
+        err = tx.Exec(`
+            UPDATE transaction_rules r SET is_active = false
+            WHERE r.user_id = ? AND r.category_id IS NULL AND COALESCE(r.set_payee, '') = ''
+              AND NOT EXISTS (SELECT 1 FROM transaction_rule_tags rt WHERE rt.transaction_rule_id = r.id)`,
+            userID).Error
+        if err != nil {
+            return err
+        }
+        return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&domain.Tag{}).Error
+    })
+}
+
+func (r *tagRepository) Merge(fromID, toID, userID uuid.UUID) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        // Transaksi yang sudah punya kedua tag cukup kehilangan tag lama
+        err := tx.Exec(`
+            INSERT INTO transaction_tags (transaction_id, tag_id)
+            SELECT tt.transaction_id, ? FROM transaction_tags tt
+            JOIN tags t ON t.id = tt.tag_id
+            WHERE tt.tag_id = ? AND t.user_id = ?
+            ON CONFLICT DO NOTHING`, toID, fromID, userID).Error
+        if err != nil {
+            return err
+        }
+        err = tx.Exec(`DELETE FROM transaction_tags WHERE tag_id = ?`, fromID).Error
+        if err != nil {
+            return err
+        }
+
+        // Rule yang memakai tag lama ikut pindah ke tag tujuan
+        err = tx.Exec(`
+            INSERT INTO transaction_rule_tags (transaction_rule_id, tag_id)
+            SELECT rt.transaction_rule_id, ? FROM transaction_rule_tags rt
+            JOIN tags t ON t.id = rt.tag_id
+            WHERE rt.tag_id = ? AND t.user_id = ?
+            ON CONFLICT DO NOTHING`, toID, fromID, userID).Error
+        if err != nil {
+            return err
+        }
+        err = tx.Exec(`DELETE FROM transaction_rule_tags WHERE tag_id = ?`, fromID).Error
+        if err != nil {
+            return err
+        }
+        return tx.Where("id = ? AND user_id = ?", fromID, userID).Delete(&domain.Tag{}).Error
+    })
+}
+
+func (r *tagRepository) SumByTag(userID uuid.UUID, start, end time.Time) ([]TagTotal, error) {
+    var totals []TagTotal
+
+    err := r.db.Table("transactions t").
+        Select("tt.tag_id, t.type, t.currency, t.date, COALESCE(SUM(t.amount), 0) AS amount, COUNT(*) AS count").
+        Joins("JOIN transaction_tags tt ON tt.transaction_id = t.id").
+        Where("t.user_id = ? AND t.type IN ? AND t.date >= ? AND t.date <= ?",
+            userID, []domain.TransactionType{domain.Income, domain.Expense}, start, end).
+        Group("tt.tag_id, t.type, t.currency, t.date").
+        Scan(&totals).Error
+
+    return totals, err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+const (
+    SortByDate      = "date"
+    SortByAmount    = "amount"
+    SortByCreatedAt = "created_at"
+)
+
+// sortColumns juga berfungsi sebagai whitelist kolom untuk ORDER BY
+var sortColumns = map[string]string{
+    SortByDate:      "date",
+    SortByAmount:    "amount",
+    SortByCreatedAt: "created_at",
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type TransactionSort struct {
+    Field string
+    Desc  bool
+}
+
+// ValidSortField dipakai handler untuk validasi query ?sort=
+func ValidSortField(field string) bool {
+    _, ok := sortColumns[field]
+    return ok
+}
+
+type PageQuery struct {
+    Limit int // 0 = tanpa batas
+    Sort  TransactionSort
+    After *Cursor
+}
+
+// Cursor menunjuk baris terakhir suatu halaman: nilai kolom sort + id
+// sebagai penentu urutan jika nilainya sama. Cursor hanya berlaku untuk
+// urutan yang sama dengan saat dibuat.
+type Cursor struct {
+    Field string    `json:"f"`
+    Desc  bool      `json:"d"`
+    Value string    `json:"v"`
+    ID    uuid.UUID `json:"id"`
+}
+
+func cursorAfter(tx *domain.Transaction, sort TransactionSort) Cursor {
+    c := Cursor{Field: sort.Field, Desc: sort.Desc, ID: tx.ID}
+    switch sort.Field {
+    case SortByAmount:
+        c.Value = tx.Amount.String()
+    case SortByCreatedAt:
+        c.Value = tx.CreatedAt.Format(time.RFC3339Nano)
+    default:
+        c.Value = tx.Date.Format(time.RFC3339Nano)
+    }
+    return c
+}
+
+func (c *Cursor) value() (interface{}, error) {
+    switch c.Field {
+    case SortByAmount:
+        return domain.ParseMoney(c.Value)
+    case SortByDate, SortByCreatedAt:
+        return time.Parse(time.RFC3339Nano, c.Value)
+    }
+    return nil, ErrInvalidCursor
+}
+
+func (c Cursor) Encode() string {
+    data, _ := json.Marshal(c)
+    return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor menolak cursor rusak atau yang dibuat untuk urutan lain
+func DecodeCursor(s string, sort TransactionSort) (*Cursor, error) {
+    data, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil {
+        return nil, ErrInvalidCursor
+    }
+    var c Cursor
+    if err := json.Unmarshal(data, &c); err != nil {
+        return nil, ErrInvalidCursor
+    }
+    if c.Field != sort.Field || c.Desc != sort.Desc {
+        return nil, errors.New("cursor does not match the requested sort")
+    }
+    if _, err := c.value(); err != nil {
+        return nil, ErrInvalidCursor
+    }
+    return &c, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+    "fmt"
     "time"
 
     "github.com/google/uuid"
@@ -8,21 +9,73 @@ import (
     "gorm.io/gorm"
 )
 
+// TagMatch menentukan cara filter Tags: "any" (default) cukup salah satu
+// tag, "all" harus punya semua tag
+const (
+    TagMatchAny = "any"
+    TagMatchAll = "all"
+)
+
+// TransactionFilter: field kosong/nil = tidak difilter. Filter kategori
+// juga mencocokkan split, jadi transaksi split ikut muncul (atau ikut
+// dikecualikan) jika salah satu split-nya di kategori tersebut. Min/Max
+// amount dibandingkan dalam mata uang transaksinya sendiri.
 type TransactionFilter struct {
-    Type       string
-    CategoryID string
-    StartDate  *time.Time
-    EndDate    *time.Time
-    Search     string
+    Type               string
+    CategoryIDs        []uuid.UUID
+    ExcludeCategoryIDs []uuid.UUID
+    AccountIDs         []uuid.UUID
+    MinAmount          *domain.Money
+    MaxAmount          *domain.Money
+    StartDate          *time.Time
+    EndDate            *time.Time
+    CreatedFrom        *time.Time
+    CreatedTo          *time.Time
+    UpdatedFrom        *time.Time
+    UpdatedTo          *time.Time
+    Search             string // full-text: deskripsi, payee, catatan split, nama kategori
+    Tags               []uuid.UUID
+    TagMatch           string
+    HasAttachment      *bool
+}
+
+type CurrencyTotal struct {
+    Type     domain.TransactionType
+    Currency string
+    Date     time.Time
+    Amount   domain.Money
 }
 
 type TransactionRepository interface {
     Create(tx *domain.Transaction) error
+    // CreateBatch menyimpan semua transaksi atau tidak sama sekali (import)
+    CreateBatch(transactions []domain.Transaction) error
+    // FindExternalIDs mengembalikan external ID (FITID) yang sudah ada di akun
+    FindExternalIDs(accountID uuid.UUID, ids []string) ([]string, error)
     FindAllByUser(userID uuid.UUID, filter TransactionFilter) ([]domain.Transaction, error)
     FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Transaction, error)
+    // Update menyimpan field dan tag transaksi; split tidak disentuh
     Update(tx *domain.Transaction) error
+    // UpdateWithSplits seperti Update tapi juga mengganti seluruh split
+    // lama dengan tx.Splits
+    UpdateWithSplits(tx *domain.Transaction) error
+    // UpdateBatch menyimpan field dan tag semua transaksi atau tidak sama
+    // sekali (apply rule); split tidak disentuh
+    UpdateBatch(transactions []domain.Transaction) error
     Delete(id uuid.UUID, userID uuid.UUID) error
-    GetSummaryByUser(userID uuid.UUID, month, year int) (income, expense float64, err error)
+    // FindPageByUser mengambil satu halaman (keyset pagination) beserta
+    // cursor halaman berikutnya; Limit 0 = semua baris
+    FindPageByUser(userID uuid.UUID, filter TransactionFilter, page PageQuery) ([]domain.Transaction, *Cursor, error)
+    // SumByFilter menghitung jumlah baris dan total income/expense per
+    // mata uang & tanggal untuk filter yang sama dengan list; dengan filter
+    // kategori, transaksi split dijumlah per split yang cocok
+    SumByFilter(userID uuid.UUID, filter TransactionFilter) (int64, []CurrencyTotal, error)
+    // Search: full-text search dengan prefix matching, diurutkan menurut
+    // relevansi; filter lain tetap berlaku
+    Search(userID uuid.UUID, query string, filter TransactionFilter, limit int) ([]SearchHit, error)
+    // GetSummaryByUser mengelompokkan total per tipe, mata uang & tanggal
+    // supaya service bisa mengonversi dengan kurs di tanggal transaksi
+    GetSummaryByUser(userID uuid.UUID, month, year int) ([]CurrencyTotal, error)
 }
 
 type transactionRepository struct {
@@ -33,22 +86,67 @@ func NewTransactionRepository(db *gorm.DB) TransactionRepository {
     return &transactionRepository{db}
 }
 
+// Create ikut menyimpan Splits lewat association GORM
 func (r *transactionRepository) Create(tx *domain.Transaction) error {
-    return r.db.Create(tx).Error
+    return r.db.Omit("Splits.Category").Create(tx).Error
+}
+
+func (r *transactionRepository) CreateBatch(transactions []domain.Transaction) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        return tx.Omit("Splits.Category").CreateInBatches(&transactions, 200).Error
+    })
+}
+
+func (r *transactionRepository) FindExternalIDs(accountID uuid.UUID, ids []string) ([]string, error) {
+    var found []string
+    err := r.db.Model(&domain.Transaction{}).
+        Where("account_id = ? AND external_id IN ?", accountID, ids).
+        Pluck("external_id", &found).Error
+    return found, err
 }
 
 func (r *transactionRepository) FindAllByUser(userID uuid.UUID, filter TransactionFilter) ([]domain.Transaction, error) {
     var transactions []domain.Transaction
 
-    query := r.db.Where("user_id = ?", userID).
+    query := r.withRelations(r.filtered(userID, filter)).Order("date DESC")
+
+    err := query.Find(&transactions).Error
+    return transactions, err
+}
+
+func (r *transactionRepository) withRelations(query *gorm.DB) *gorm.DB {
+    return query.
         Preload("Category").
-        Order("date DESC")
+        Preload("Account").
+        Preload("Splits.Category").
+        Preload("Tags").
+        Preload("Attachments")
+}
+
+// filtered membangun query transaksi user sesuai filter, dipakai bersama
+// oleh list, halaman dan total
+func (r *transactionRepository) filtered(userID uuid.UUID, filter TransactionFilter) *gorm.DB {
+    query := r.db.Model(&domain.Transaction{}).Where("user_id = ?", userID)
 
     if filter.Type != "" {
         query = query.Where("type = ?", filter.Type)
     }
-    if filter.CategoryID != "" {
-        query = query.Where("category_id = ?", filter.CategoryID)
+    if len(filter.CategoryIDs) > 0 {
+        query = query.Where("(category_id IN ? OR id IN (?))", filter.CategoryIDs, r.splitsIn(filter.CategoryIDs))
+    }
+    if len(filter.ExcludeCategoryIDs) > 0 {
+        // Leg transfer tidak punya kategori, jadi tetap lolos
+        query = query.Where("(category_id IS NULL OR category_id NOT IN ?) AND id NOT IN (?)",
+            filter.ExcludeCategoryIDs, r.splitsIn(filter.ExcludeCategoryIDs))
+    }
+    if len(filter.AccountIDs) > 0 {
+        query = query.Where("account_id IN ?", filter.AccountIDs)
+    }
+    if filter.MinAmount != nil {
+        query = query.Where("amount >= ?", *filter.MinAmount)
+    }
+    if filter.MaxAmount != nil {
+        query = query.Where("amount <= ?", *filter.MaxAmount)
     }
     if filter.StartDate != nil {
         query = query.Where("date >= ?", filter.StartDate)
@@ -56,18 +154,117 @@ func (r *transactionRepository) FindAllByUser(userID uuid.UUID, filter Transacti
     if filter.EndDate != nil {
         query = query.Where("date <= ?", filter.EndDate)
     }
+    if filter.CreatedFrom != nil {
+        query = query.Where("created_at >= ?", filter.CreatedFrom)
+    }
+    if filter.CreatedTo != nil {
+        query = query.Where("created_at < ?", filter.CreatedTo)
+    }
+    if filter.UpdatedFrom != nil {
+        query = query.Where("updated_at >= ?", filter.UpdatedFrom)
+    }
+    if filter.UpdatedTo != nil {
+        query = query.Where("updated_at < ?", filter.UpdatedTo)
+    }
     if filter.Search != "" {
-        query = query.Where("description ILIKE ?", "%"+filter.Search+"%")
+        // Kata tanpa huruf/angka sama sekali tidak cocok dengan apa pun
+        query = query.Where("search_vector @@ to_tsquery(?, ?)", searchConfig, PrefixQuery(filter.Search))
+    }
+    if filter.HasAttachment != nil {
+        attached := r.db.Model(&domain.Attachment{}).Select("transaction_id")
+        if *filter.HasAttachment {
+            query = query.Where("id IN (?)", attached)
+        } else {
+            query = query.Where("id NOT IN (?)", attached)
+        }
+    }
+    if len(filter.Tags) > 0 {
+        tagged := r.db.Table("transaction_tags").Select("transaction_id").Where("tag_id IN ?", filter.Tags)
+        if filter.TagMatch == TagMatchAll {
+            tagged = tagged.Group("transaction_id").Having("COUNT(DISTINCT tag_id) = ?", len(filter.Tags))
+        }
+        query = query.Where("id IN (?)", tagged)
     }
+    return query
+}
 
-    err := query.Find(&transactions).Error
-    return transactions, err
+func (r *transactionRepository) splitsIn(categoryIDs []uuid.UUID) *gorm.DB {
+    return r.db.Model(&domain.TransactionSplit{}).Select("transaction_id").Where("category_id IN ?", categoryIDs)
+}
+
+func (r *transactionRepository) FindPageByUser(userID uuid.UUID, filter TransactionFilter, page PageQuery) ([]domain.Transaction, *Cursor, error) {
+    column, ok := sortColumns[page.Sort.Field]
+    if !ok {
+        return nil, nil, fmt.Errorf("invalid sort field %q", page.Sort.Field)
+    }
+    direction, compare := "ASC", ">"
+    if page.Sort.Desc {
+        direction, compare = "DESC", "<"
+    }
+
+    query := r.withRelations(r.filtered(userID, filter)).
+        Order(column + " " + direction).
+        Order("id " + direction)
+
+    if page.After != nil {
+        value, err := page.After.value()
+        if err != nil {
+            return nil, nil, err
+        }
+        // Keyset: lanjut tepat setelah baris terakhir halaman sebelumnya
+        query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, compare), value, page.After.ID)
+    }
+    if page.Limit > 0 {
+        query = query.Limit(page.Limit + 1)
+    }
+
+    var transactions []domain.Transaction
+    if err := query.Find(&transactions).Error; err != nil {
+        return nil, nil, err
+    }
+
+    if page.Limit <= 0 || len(transactions) <= page.Limit {
+        return transactions, nil, nil
+    }
+    transactions = transactions[:page.Limit]
+    next := cursorAfter(&transactions[len(transactions)-1], page.Sort)
+    return transactions, &next, nil
+}
+
+func (r *transactionRepository) SumByFilter(userID uuid.UUID, filter TransactionFilter) (int64, []CurrencyTotal, error) {
+    var count int64
+    if err := r.filtered(userID, filter).Count(&count).Error; err != nil {
+        return 0, nil, err
+    }
+
+    query := r.filtered(userID, filter)
+    if len(filter.CategoryIDs) > 0 {
+        // Transaksi split hanya dihitung sebesar split yang kategorinya cocok
+        query = query.Select(`type, currency, date, COALESCE(SUM(
+            CASE WHEN EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = transactions.id)
+            THEN (SELECT COALESCE(SUM(s.amount), 0) FROM transaction_splits s
+                  WHERE s.transaction_id = transactions.id AND s.category_id IN ?)
+            ELSE amount END), 0) AS amount`, filter.CategoryIDs)
+    } else {
+        query = query.Select("type, currency, date, COALESCE(SUM(amount), 0) AS amount")
+    }
+
+    var totals []CurrencyTotal
+    err := query.
+        Where("type IN ?", []domain.TransactionType{domain.Income, domain.Expense}).
+        Group("type, currency, date").
+        Scan(&totals).Error
+    return count, totals, err
 }
 
 func (r *transactionRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Transaction, error) {
     var tx domain.Transaction
     err := r.db.Where("id = ? AND user_id = ?", id, userID).
         Preload("Category").
+        Preload("Account").
+        Preload("Splits.Category").
+        Preload("Tags").
+        Preload("Attachments").
         First(&tx).Error
     if err != nil {
         return nil, err
@@ -75,29 +272,85 @@ func (r *transactionRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domai
     return &tx, nil
 }
 
+// Update mengganti tag lama dengan tx.Tags
 func (r *transactionRepository) Update(tx *domain.Transaction) error {
-    return r.db.Save(tx).Error
+    return r.db.Transaction(func(db *gorm.DB) error {
+        return saveTransaction(db, tx)
+    })
 }
 
-func (r *transactionRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
-    return r.db.Where("id = ? AND user_id = ?", id, userID).
-        Delete(&domain.Transaction{}).Error
+// UpdateWithSplits juga mengganti seluruh split lama dengan tx.Splits
+func (r *transactionRepository) UpdateWithSplits(tx *domain.Transaction) error {
+    return r.db.Transaction(func(db *gorm.DB) error {
+        if err := saveTransaction(db, tx); err != nil {
+            return err
+        }
+        if err := db.Where("transaction_id = ?", tx.ID).Delete(&domain.TransactionSplit{}).Error; err != nil {
+            return err
+        }
+        if len(tx.Splits) == 0 {
+            return nil
+        }
+        for i := range tx.Splits {
+            tx.Splits[i].ID = uuid.New()
+            tx.Splits[i].TransactionID = tx.ID
+        }
+        return db.Omit("Category").Create(&tx.Splits).Error
+    })
 }
 
-func (r *transactionRepository) GetSummaryByUser(userID uuid.UUID, month, year int) (float64, float64, error) {
-    var income, expense float64
+func (r *transactionRepository) UpdateBatch(transactions []domain.Transaction) error {
+    return r.db.Transaction(func(db *gorm.DB) error {
+        for i := range transactions {
+            if err := saveTransaction(db, &transactions[i]); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
+
+func saveTransaction(db *gorm.DB, tx *domain.Transaction) error {
+    if err := db.Omit("Splits", "Tags", "Attachments").Save(tx).Error; err != nil {
+        return err
+    }
+    return db.Model(tx).Association("Tags").Replace(tx.Tags)
+}
+
+func (r *transactionRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Transaction(func(db *gorm.DB) error {
+        err := db.Where("transaction_id IN (?)",
+            db.Model(&domain.Transaction{}).Select("id").Where("id = ? AND user_id = ?", id, userID)).
+            Delete(&domain.TransactionSplit{}).Error
+        if err != nil {
+            return err
+        }
+        // File lampiran dihapus dari storage oleh service
+        err = db.Where("transaction_id = ? AND user_id = ?", id, userID).
+            Delete(&domain.Attachment{}).Error
+        if err != nil {
+            return err
+        }
+        err = db.Exec(`
+            DELETE FROM transaction_tags
+            WHERE transaction_id IN (SELECT id FROM transactions WHERE id = ? AND user_id = ?)`, id, userID).Error
+        if err != nil {
+            return err
+        }
+        return db.Where("id = ? AND user_id = ?", id, userID).
+            Delete(&domain.Transaction{}).Error
+    })
+}
 
-    r.db.Model(&domain.Transaction{}).
-        Where("user_id = ? AND type = ? AND EXTRACT(MONTH FROM date) = ? AND EXTRACT(YEAR FROM date) = ?",
-            userID, domain.Income, month, year).
-        Select("COALESCE(SUM(amount), 0)").
-        Scan(&income)
+func (r *transactionRepository) GetSummaryByUser(userID uuid.UUID, month, year int) ([]CurrencyTotal, error) {
+    var totals []CurrencyTotal
 
-    r.db.Model(&domain.Transaction{}).
-        Where("user_id = ? AND type = ? AND EXTRACT(MONTH FROM date) = ? AND EXTRACT(YEAR FROM date) = ?",
-            userID, domain.Expense, month, year).
-        Select("COALESCE(SUM(amount), 0)").
-        Scan(&expense)
+    err := r.db.Model(&domain.Transaction{}).
+        Select("type, currency, date, COALESCE(SUM(amount), 0) AS amount").
+        Where("user_id = ? AND type IN ? AND EXTRACT(MONTH FROM date) = ? AND EXTRACT(YEAR FROM date) = ?",
+            userID, []domain.TransactionType{domain.Income, domain.Expense}, month, year).
+        Group("type, currency, date").
+        Scan(&totals).Error
 
-    return income, expense, nil
+    return totals, err
 }
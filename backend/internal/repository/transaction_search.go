@@ -0,0 +1,98 @@
+package repository
+
+import (
+    "regexp"
+    "strings"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+// Konfigurasi 'simple' tanpa stemming karena Postgres tidak punya kamus
+// bahasa Indonesia; variasi kata ("makan" → "makanan") ditangani prefix
+// matching. search_vector diisi trigger di database (lihat
+// database.migrateSearchIndex) dari deskripsi, payee, catatan split dan
+// nama kategori.
+const searchConfig = "simple"
+
+var searchTermPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// PrefixQuery mengubah input bebas menjadi tsquery dengan prefix matching
+// per kata, mis. "kopi  sus" → "kopi:* & sus:*". Tanda baca dan operator
+// tsquery dibuang sehingga input user tidak bisa membuat query invalid.
+// Hasil kosong berarti tidak ada kata yang bisa dicari.
+func PrefixQuery(input string) string {
+    terms := searchTermPattern.FindAllString(strings.ToLower(input), -1)
+    for i, term := range terms {
+        terms[i] = term + ":*"
+    }
+    return strings.Join(terms, " & ")
+}
+
+// SearchHit: Snippet berisi potongan teks yang cocok dengan kata yang
+// dicari dibungkus <mark>...</mark>; teks aslinya sudah di-escape HTML
+type SearchHit struct {
+    Transaction domain.Transaction `json:"transaction"`
+    Rank        float64            `json:"rank"`
+    Snippet     string             `json:"snippet"`
+}
+
+type searchRow struct {
+    ID      uuid.UUID
+    Rank    float64
+    Snippet string
+}
+
+// searchDocument: teks yang ditampilkan di snippet, di-escape sebelum
+// ts_headline menambahkan tag <mark>
+const searchDocument = `replace(replace(replace(concat_ws(' · ',
+        NULLIF(description, ''),
+        NULLIF(payee, ''),
+        (SELECT string_agg(note, ' · ') FROM transaction_splits s WHERE s.transaction_id = transactions.id AND note <> '')
+    ), '&', '&amp;'), '<', '&lt;'), '>', '&gt;')`
+
+func (r *transactionRepository) Search(userID uuid.UUID, query string, filter TransactionFilter, limit int) ([]SearchHit, error) {
+    tsquery := PrefixQuery(query)
+    if tsquery == "" {
+        return []SearchHit{}, nil
+    }
+
+    var rows []searchRow
+    err := r.filtered(userID, filter).
+        Select(`id,
+            ts_rank_cd(search_vector, to_tsquery(?, ?)) AS rank,
+            ts_headline(?, `+searchDocument+`, to_tsquery(?, ?),
+                'StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5, MaxFragments=2') AS snippet`,
+            searchConfig, tsquery, searchConfig, searchConfig, tsquery).
+        Where("search_vector @@ to_tsquery(?, ?)", searchConfig, tsquery).
+        Order("rank DESC").
+        Order("date DESC").
+        Order("id DESC").
+        Limit(limit).
+        Scan(&rows).Error
+    if err != nil || len(rows) == 0 {
+        return []SearchHit{}, err
+    }
+
+    ids := make([]uuid.UUID, len(rows))
+    for i, row := range rows {
+        ids[i] = row.ID
+    }
+    var transactions []domain.Transaction
+    if err := r.withRelations(r.db).Where("id IN ?", ids).Find(&transactions).Error; err != nil {
+        return nil, err
+    }
+    byID := make(map[uuid.UUID]domain.Transaction, len(transactions))
+    for _, tx := range transactions {
+        byID[tx.ID] = tx
+    }
+
+    // Pertahankan urutan ranking dari query pertama
+    hits := make([]SearchHit, 0, len(rows))
+    for _, row := range rows {
+        if tx, ok := byID[row.ID]; ok {
+            hits = append(hits, SearchHit{Transaction: tx, Rank: row.Rank, Snippet: row.Snippet})
+        }
+    }
+    return hits, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "gorm.io/gorm"
+)
+
+type TransferRepository interface {
+    // Create menyimpan transfer beserta semua leg-nya dalam satu DB transaction
+    Create(transfer *domain.Transfer) error
+    FindAllByUser(userID uuid.UUID) ([]domain.Transfer, error)
+    FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Transfer, error)
+    // Delete menghapus transfer beserta semua leg-nya
+    Delete(id uuid.UUID, userID uuid.UUID) error
+}
+
+type transferRepository struct {
+    db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) TransferRepository {
+    return &transferRepository{db}
+}
+
+func (r *transferRepository) Create(transfer *domain.Transfer) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        legs := transfer.Legs
+        transfer.Legs = nil
+        defer func() { transfer.Legs = legs }()
+
+        if err := tx.Omit("FromAccount", "ToAccount").Create(transfer).Error; err != nil {
+            return err
+        }
+        for i := range legs {
+            legs[i].TransferID = &transfer.ID
+            if err := tx.Create(&legs[i]).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+}
+
+func (r *transferRepository) FindAllByUser(userID uuid.UUID) ([]domain.Transfer, error) {
+    var transfers []domain.Transfer
+    err := r.db.Where("user_id = ?", userID).
+        Preload("FromAccount").
+        Preload("ToAccount").
+        Preload("Legs").
+        Order("date DESC, created_at DESC").
+        Find(&transfers).Error
+    return transfers, err
+}
+
+func (r *transferRepository) FindByID(id uuid.UUID, userID uuid.UUID) (*domain.Transfer, error) {
+    var transfer domain.Transfer
+    err := r.db.Where("id = ? AND user_id = ?", id, userID).
+        Preload("FromAccount").
+        Preload("ToAccount").
+        Preload("Legs").
+        First(&transfer).Error
+    if err != nil {
+        return nil, err
+    }
+    return &transfer, nil
+}
+
+func (r *transferRepository) Delete(id uuid.UUID, userID uuid.UUID) error {
+    return r.db.Transaction(func(tx *gorm.DB) error {
+        err := tx.Where("transfer_id = ? AND user_id = ?", id, userID).
+            Delete(&domain.Transaction{}).Error
+        if err != nil {
+            return err
+        }
+        return tx.Where("id = ? AND user_id = ?", id, userID).
+            Delete(&domain.Transfer{}).Error
+    })
+}
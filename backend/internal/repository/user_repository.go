@@ -11,6 +11,8 @@ type UserRepository interface {
     FindByEmail(email string) (*domain.User, error)
     FindByID(id uuid.UUID) (*domain.User, error)
 	UpdateVerified(id uuid.UUID, status bool) error
+    UpdatePassword(id uuid.UUID, hashedPassword string) error
+    UpdateBaseCurrency(id uuid.UUID, currency string) error
 }
 
 type userRepository struct {
@@ -48,3 +50,16 @@ func (r *userRepository) UpdateVerified(id uuid.UUID, status bool) error {
         Where("id = ?", id).
         Update("is_verified", status).Error
 }
+
+
+func (r *userRepository) UpdatePassword(id uuid.UUID, hashedPassword string) error {
+    return r.db.Model(&domain.User{}).
+        Where("id = ?", id).
+        Update("password", hashedPassword).Error
+}
+
+func (r *userRepository) UpdateBaseCurrency(id uuid.UUID, currency string) error {
+    return r.db.Model(&domain.User{}).
+        Where("id = ?", id).
+        Update("base_currency", currency).Error
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+    "context"
+    "log"
+    "time"
+
+    "github.com/myfarism/finance-tracker/internal/service"
+)
+
+// RecurringScheduler menjalankan RecurringService.RunDue secara berkala di
+// dalam proses API. Aman dijalankan di beberapa replica sekaligus karena
+// repository mengunci tiap rule dan transaksi dijaga unique index.
+type RecurringScheduler struct {
+    recurringService service.RecurringService
+    interval         time.Duration
+}
+
+func NewRecurringScheduler(recurringService service.RecurringService, interval time.Duration) *RecurringScheduler {
+    return &RecurringScheduler{recurringService, interval}
+}
+
+// Start langsung memproses occurrence yang tertunda (mis. selama server
+// mati), lalu mengulang setiap interval sampai ctx dibatalkan
+func (s *RecurringScheduler) Start(ctx context.Context) {
+    go func() {
+        s.run()
+
+        ticker := time.NewTicker(s.interval)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case <-ticker.C:
+                s.run()
+            }
+        }
+    }()
+}
+
+func (s *RecurringScheduler) run() {
+    created, err := s.recurringService.RunDue(time.Now())
+    if err != nil {
+        log.Printf("Recurring scheduler error: %v", err)
+    }
+    if created > 0 {
+        log.Printf("Recurring scheduler created %d transaction(s)", created)
+    }
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+type CreateAccountInput struct {
+    Name           string       `json:"name" binding:"required"`
+    Type           string       `json:"type" binding:"required,oneof=bank cash ewallet credit_card"`
+    OpeningBalance domain.Money `json:"opening_balance"`
+    Currency       string       `json:"currency"` // kosong = base currency user
+    IsDefault      bool         `json:"is_default"`
+}
+
+type UpdateAccountInput struct {
+    Name           string        `json:"name"`
+    Type           string        `json:"type" binding:"omitempty,oneof=bank cash ewallet credit_card"`
+    OpeningBalance *domain.Money `json:"opening_balance"`
+    IsDefault      *bool         `json:"is_default"`
+    IsArchived     *bool         `json:"is_archived"`
+}
+
+// AccountBalance: Balance = OpeningBalance + Income - Expense
+// + TransferIn - TransferOut sampai AsOf
+type AccountBalance struct {
+    Account        domain.Account `json:"account"`
+    OpeningBalance domain.Money   `json:"opening_balance"`
+    Income         domain.Money   `json:"income"`
+    Expense        domain.Money   `json:"expense"`
+    TransferIn     domain.Money   `json:"transfer_in"`
+    TransferOut    domain.Money   `json:"transfer_out"`
+    Balance        domain.Money   `json:"balance"`
+    AsOf           *time.Time     `json:"as_of"`
+}
+
+type AccountService interface {
+    GetAll(userID uuid.UUID, includeArchived bool) ([]domain.Account, error)
+    GetByID(id string, userID uuid.UUID) (*domain.Account, error)
+    Create(userID uuid.UUID, input CreateAccountInput) (*domain.Account, error)
+    Update(id string, userID uuid.UUID, input UpdateAccountInput) (*domain.Account, error)
+    // Delete hanya untuk akun tanpa transaksi, selebihnya arsipkan
+    Delete(id string, userID uuid.UUID) error
+    GetBalance(id string, userID uuid.UUID, asOf *time.Time) (*AccountBalance, error)
+}
+
+type accountService struct {
+    accountRepo repository.AccountRepository
+    userRepo    repository.UserRepository
+}
+
+func NewAccountService(accountRepo repository.AccountRepository, userRepo repository.UserRepository) AccountService {
+    return &accountService{accountRepo, userRepo}
+}
+
+func (s *accountService) GetAll(userID uuid.UUID, includeArchived bool) ([]domain.Account, error) {
+    accounts, err := s.accountRepo.FindAllByUser(userID, includeArchived)
+    if err != nil {
+        return nil, err
+    }
+
+    totals, err := s.totals(userID, nil)
+    if err != nil {
+        return nil, err
+    }
+    for i := range accounts {
+        accounts[i].Balance = balanceOf(&accounts[i], totals[accounts[i].ID])
+    }
+    return accounts, nil
+}
+
+func (s *accountService) GetByID(id string, userID uuid.UUID) (*domain.Account, error) {
+    balance, err := s.GetBalance(id, userID, nil)
+    if err != nil {
+        return nil, err
+    }
+    return &balance.Account, nil
+}
+
+func (s *accountService) Create(userID uuid.UUID, input CreateAccountInput) (*domain.Account, error) {
+    var currency string
+    var err error
+    if input.Currency == "" {
+        currency, err = baseCurrency(s.userRepo, userID)
+    } else {
+        currency, err = domain.NormalizeCurrency(input.Currency)
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    account := &domain.Account{
+        ID:             uuid.New(),
+        UserID:         userID,
+        Name:           input.Name,
+        Type:           domain.AccountType(input.Type),
+        Currency:       currency,
+        OpeningBalance: input.OpeningBalance,
+    }
+
+    // Akun pertama otomatis menjadi default
+    if _, err := s.accountRepo.FindDefault(userID); err != nil {
+        account.IsDefault = true
+    }
+
+    if err := s.accountRepo.Create(account); err != nil {
+        return nil, err
+    }
+
+    if input.IsDefault && !account.IsDefault {
+        if err := s.accountRepo.SetDefault(account.ID, userID); err != nil {
+            return nil, err
+        }
+        account.IsDefault = true
+    }
+
+    account.Balance = account.OpeningBalance
+    return account, nil
+}
+
+func (s *accountService) findAccount(id string, userID uuid.UUID) (*domain.Account, error) {
+    accountID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid account id")
+    }
+    account, err := s.accountRepo.FindByID(accountID, userID)
+    if err != nil {
+        return nil, errors.New("account not found")
+    }
+    return account, nil
+}
+
+func (s *accountService) Update(id string, userID uuid.UUID, input UpdateAccountInput) (*domain.Account, error) {
+    account, err := s.findAccount(id, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    if input.Name != "" {
+        account.Name = input.Name
+    }
+    if input.Type != "" {
+        account.Type = domain.AccountType(input.Type)
+    }
+    if input.OpeningBalance != nil {
+        account.OpeningBalance = *input.OpeningBalance
+    }
+    if input.IsArchived != nil {
+        if *input.IsArchived && account.IsDefault {
+            return nil, errors.New("default account cannot be archived")
+        }
+        account.IsArchived = *input.IsArchived
+    }
+
+    makeDefault := input.IsDefault != nil && *input.IsDefault && !account.IsDefault
+    if input.IsDefault != nil && !*input.IsDefault && account.IsDefault {
+        return nil, errors.New("set another account as default instead")
+    }
+    if makeDefault && account.IsArchived {
+        return nil, errors.New("archived account cannot be default")
+    }
+
+    if err := s.accountRepo.Update(account); err != nil {
+        return nil, err
+    }
+    if makeDefault {
+        if err := s.accountRepo.SetDefault(account.ID, userID); err != nil {
+            return nil, err
+        }
+    }
+
+    return s.GetByID(id, userID)
+}
+
+func (s *accountService) Delete(id string, userID uuid.UUID) error {
+    account, err := s.findAccount(id, userID)
+    if err != nil {
+        return err
+    }
+    if account.IsDefault {
+        return errors.New("default account cannot be deleted")
+    }
+
+    count, err := s.accountRepo.CountTransactions(account.ID, userID)
+    if err != nil {
+        return err
+    }
+    if count > 0 {
+        return errors.New("account has transactions, archive it instead")
+    }
+
+    rules, err := s.accountRepo.CountRecurringRules(account.ID, userID)
+    if err != nil {
+        return err
+    }
+    if rules > 0 {
+        return fmt.Errorf("account is used by %d recurring rules, move or delete them first", rules)
+    }
+
+    return s.accountRepo.Delete(account.ID, userID)
+}
+
+func (s *accountService) GetBalance(id string, userID uuid.UUID, asOf *time.Time) (*AccountBalance, error) {
+    account, err := s.findAccount(id, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    totals, err := s.totals(userID, asOf)
+    if err != nil {
+        return nil, err
+    }
+    t := totals[account.ID]
+    account.Balance = balanceOf(account, t)
+
+    return &AccountBalance{
+        Account:        *account,
+        OpeningBalance: account.OpeningBalance,
+        Income:         t.Income,
+        Expense:        t.Expense,
+        TransferIn:     t.TransferIn,
+        TransferOut:    t.TransferOut,
+        Balance:        account.Balance,
+        AsOf:           asOf,
+    }, nil
+}
+
+func (s *accountService) totals(userID uuid.UUID, until *time.Time) (map[uuid.UUID]repository.AccountTotal, error) {
+    rows, err := s.accountRepo.SumTransactions(userID, until)
+    if err != nil {
+        return nil, err
+    }
+    totals := make(map[uuid.UUID]repository.AccountTotal, len(rows))
+    for _, row := range rows {
+        totals[row.AccountID] = row
+    }
+    return totals, nil
+}
+
+func balanceOf(account *domain.Account, t repository.AccountTotal) domain.Money {
+    return account.OpeningBalance + t.Income - t.Expense + t.TransferIn - t.TransferOut
+}
+
+// defaultAccount mengambil akun default user, membuatnya jika belum ada
+// (user baru, atau user lama yang belum pernah bertransaksi)
+func defaultAccount(accountRepo repository.AccountRepository, userRepo repository.UserRepository, userID uuid.UUID) (*domain.Account, error) {
+    if account, err := accountRepo.FindDefault(userID); err == nil {
+        return account, nil
+    }
+
+    currency, err := baseCurrency(userRepo, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    account := &domain.Account{
+        ID:        uuid.New(),
+        UserID:    userID,
+        Name:      domain.DefaultAccountName,
+        Type:      domain.AccountCash,
+        Currency:  currency,
+        IsDefault: true,
+    }
+    if err := accountRepo.Create(account); err != nil {
+        return nil, err
+    }
+    return account, nil
+}
@@ -0,0 +1,176 @@
+package service_test
+
+import (
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+// ──────────────────────────────────────────
+// CREATE ACCOUNT TESTS
+// ──────────────────────────────────────────
+
+func TestCreateAccount_FirstAccountBecomesDefault(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    mockAccountRepo.On("FindDefault", userID).Return(nil, assert.AnError)
+    mockAccountRepo.On("Create", mock.AnythingOfType("*domain.Account")).Return(nil)
+
+    result, err := svc.Create(userID, service.CreateAccountInput{
+        Name:           "BCA",
+        Type:           "bank",
+        OpeningBalance: domain.NewMoney(1000000),
+    })
+
+    assert.NoError(t, err)
+    assert.True(t, result.IsDefault)
+    assert.Equal(t, domain.NewMoney(1000000), result.Balance)
+    mockAccountRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything)
+}
+
+func TestCreateAccount_MoveDefault(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    existing := &domain.Account{ID: uuid.New(), UserID: userID, IsDefault: true}
+    mockAccountRepo.On("FindDefault", userID).Return(existing, nil)
+    mockAccountRepo.On("Create", mock.AnythingOfType("*domain.Account")).Return(nil)
+    mockAccountRepo.On("SetDefault", mock.AnythingOfType("uuid.UUID"), userID).Return(nil)
+
+    result, err := svc.Create(userID, service.CreateAccountInput{
+        Name:      "GoPay",
+        Type:      "ewallet",
+        IsDefault: true,
+    })
+
+    assert.NoError(t, err)
+    assert.True(t, result.IsDefault)
+    mockAccountRepo.AssertExpectations(t)
+}
+
+// ──────────────────────────────────────────
+// BALANCE TESTS
+// ──────────────────────────────────────────
+
+func TestGetAllAccounts_ComputesBalances(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    bank := domain.Account{ID: uuid.New(), UserID: userID, Name: "BCA", OpeningBalance: domain.NewMoney(500000)}
+    card := domain.Account{ID: uuid.New(), UserID: userID, Name: "Kartu Kredit", OpeningBalance: domain.NewMoney(-200000)}
+    unused := domain.Account{ID: uuid.New(), UserID: userID, Name: "OVO", OpeningBalance: domain.NewMoney(50000)}
+
+    mockAccountRepo.On("FindAllByUser", userID, false).
+        Return([]domain.Account{bank, card, unused}, nil)
+    mockAccountRepo.On("SumTransactions", userID, (*time.Time)(nil)).
+        Return([]repository.AccountTotal{
+            {AccountID: bank.ID, Income: domain.NewMoney(3000000), Expense: domain.NewMoney(1250000)},
+            {AccountID: card.ID, Expense: domain.NewMoney(300000)},
+        }, nil)
+
+    result, err := svc.GetAll(userID, false)
+
+    assert.NoError(t, err)
+    assert.Equal(t, domain.NewMoney(2250000), result[0].Balance)
+    assert.Equal(t, domain.NewMoney(-500000), result[1].Balance)
+    assert.Equal(t, domain.NewMoney(50000), result[2].Balance)
+}
+
+func TestGetBalance_AsOfDate(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    account := &domain.Account{ID: uuid.New(), UserID: userID, OpeningBalance: domain.NewMoney(100000)}
+    asOf := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+    mockAccountRepo.On("SumTransactions", userID, &asOf).
+        Return([]repository.AccountTotal{{AccountID: account.ID, Income: domain.NewMoney(50000), Expense: domain.NewMoney(20000)}}, nil)
+
+    result, err := svc.GetBalance(account.ID.String(), userID, &asOf)
+
+    assert.NoError(t, err)
+    assert.Equal(t, domain.NewMoney(100000), result.OpeningBalance)
+    assert.Equal(t, domain.NewMoney(50000), result.Income)
+    assert.Equal(t, domain.NewMoney(20000), result.Expense)
+    assert.Equal(t, domain.NewMoney(130000), result.Balance)
+}
+
+// ──────────────────────────────────────────
+// UPDATE / DELETE ACCOUNT TESTS
+// ──────────────────────────────────────────
+
+func TestUpdateAccount_CannotArchiveDefault(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    account := &domain.Account{ID: uuid.New(), UserID: userID, IsDefault: true}
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+
+    archived := true
+    _, err := svc.Update(account.ID.String(), userID, service.UpdateAccountInput{IsArchived: &archived})
+
+    assert.EqualError(t, err, "default account cannot be archived")
+    mockAccountRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestDeleteAccount_WithTransactions(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    account := &domain.Account{ID: uuid.New(), UserID: userID}
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+    mockAccountRepo.On("CountTransactions", account.ID, userID).Return(int64(3), nil)
+
+    err := svc.Delete(account.ID.String(), userID)
+
+    assert.EqualError(t, err, "account has transactions, archive it instead")
+    mockAccountRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteAccount_UsedByRecurringRule(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    account := &domain.Account{ID: uuid.New(), UserID: userID}
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+    mockAccountRepo.On("CountTransactions", account.ID, userID).Return(int64(0), nil)
+    mockAccountRepo.On("CountRecurringRules", account.ID, userID).Return(int64(2), nil)
+
+    err := svc.Delete(account.ID.String(), userID)
+
+    assert.EqualError(t, err, "account is used by 2 recurring rules, move or delete them first")
+    mockAccountRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteAccount_Success(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    account := &domain.Account{ID: uuid.New(), UserID: userID}
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+    mockAccountRepo.On("CountTransactions", account.ID, userID).Return(int64(0), nil)
+    mockAccountRepo.On("CountRecurringRules", account.ID, userID).Return(int64(0), nil)
+    mockAccountRepo.On("Delete", account.ID, userID).Return(nil)
+
+    err := svc.Delete(account.ID.String(), userID)
+
+    assert.NoError(t, err)
+    mockAccountRepo.AssertExpectations(t)
+}
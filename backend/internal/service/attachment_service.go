@@ -0,0 +1,208 @@
+package service
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "net/http"
+    "path/filepath"
+    "strings"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    "github.com/myfarism/finance-tracker/pkg/storage"
+)
+
+var (
+    ErrAttachmentTooLarge = errors.New("attachment is too large")
+    ErrAttachmentQuota    = errors.New("attachment storage quota exceeded")
+)
+
+// allowedAttachmentTypes: tipe dideteksi dari isi file, bukan dari header
+// Content-Type yang dikirim client
+var allowedAttachmentTypes = map[string]bool{
+    "image/jpeg":      true,
+    "image/png":       true,
+    "image/webp":      true,
+    "image/gif":       true,
+    "application/pdf": true,
+}
+
+type AttachmentLimits struct {
+    MaxFileSize int64 // per file, byte
+    UserQuota   int64 // total semua lampiran per user, byte
+}
+
+var DefaultAttachmentLimits = AttachmentLimits{
+    MaxFileSize: 10 << 20,
+    UserQuota:   100 << 20,
+}
+
+type UploadAttachmentInput struct {
+    FileName string
+    Size     int64
+    File     io.Reader
+}
+
+type AttachmentService interface {
+    GetAll(transactionID string, userID uuid.UUID) ([]domain.Attachment, error)
+    Upload(transactionID string, userID uuid.UUID, input UploadAttachmentInput) (*domain.Attachment, error)
+    // Open mengembalikan metadata dan isi file; reader wajib di-Close
+    Open(transactionID, id string, userID uuid.UUID) (*domain.Attachment, io.ReadCloser, error)
+    Delete(transactionID, id string, userID uuid.UUID) error
+}
+
+type attachmentService struct {
+    attachmentRepo repository.AttachmentRepository
+    txRepo         repository.TransactionRepository
+    blobs          storage.Storage
+    limits         AttachmentLimits
+}
+
+func NewAttachmentService(
+    attachmentRepo repository.AttachmentRepository,
+    txRepo repository.TransactionRepository,
+    blobs storage.Storage,
+    limits AttachmentLimits,
+) AttachmentService {
+    return &attachmentService{attachmentRepo, txRepo, blobs, limits}
+}
+
+func (s *attachmentService) findTransaction(id string, userID uuid.UUID) (*domain.Transaction, error) {
+    txID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid transaction id")
+    }
+    tx, err := s.txRepo.FindByID(txID, userID)
+    if err != nil {
+        return nil, errors.New("transaction not found")
+    }
+    return tx, nil
+}
+
+func (s *attachmentService) findAttachment(transactionID, id string, userID uuid.UUID) (*domain.Attachment, error) {
+    tx, err := s.findTransaction(transactionID, userID)
+    if err != nil {
+        return nil, err
+    }
+    attachmentID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid attachment id")
+    }
+    attachment, err := s.attachmentRepo.FindByID(attachmentID, userID)
+    if err != nil || attachment.TransactionID != tx.ID {
+        return nil, errors.New("attachment not found")
+    }
+    return attachment, nil
+}
+
+func (s *attachmentService) GetAll(transactionID string, userID uuid.UUID) ([]domain.Attachment, error) {
+    tx, err := s.findTransaction(transactionID, userID)
+    if err != nil {
+        return nil, err
+    }
+    return s.attachmentRepo.FindByTransaction(tx.ID, userID)
+}
+
+func (s *attachmentService) Upload(transactionID string, userID uuid.UUID, input UploadAttachmentInput) (*domain.Attachment, error) {
+    tx, err := s.findTransaction(transactionID, userID)
+    if err != nil {
+        return nil, err
+    }
+    if tx.IsTransferLeg() {
+        return nil, errors.New("attachments cannot be added to transfer legs")
+    }
+
+    if input.Size <= 0 {
+        return nil, errors.New("file is empty")
+    }
+    if input.Size > s.limits.MaxFileSize {
+        return nil, fmt.Errorf("%w (max %d MB)", ErrAttachmentTooLarge, s.limits.MaxFileSize>>20)
+    }
+    used, err := s.attachmentRepo.TotalSize(userID)
+    if err != nil {
+        return nil, err
+    }
+    if used+input.Size > s.limits.UserQuota {
+        return nil, fmt.Errorf("%w (%d MB)", ErrAttachmentQuota, s.limits.UserQuota>>20)
+    }
+
+    // 512 byte pertama cukup untuk http.DetectContentType
+    head := make([]byte, 512)
+    n, err := io.ReadFull(input.File, head)
+    if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+        return nil, err
+    }
+    head = head[:n]
+    contentType := http.DetectContentType(head)
+    if !allowedAttachmentTypes[contentType] {
+        return nil, fmt.Errorf("unsupported file type %s, use JPEG, PNG, WebP, GIF or PDF", contentType)
+    }
+
+    attachment := &domain.Attachment{
+        ID:            uuid.New(),
+        UserID:        userID,
+        TransactionID: tx.ID,
+        FileName:      cleanFileName(input.FileName),
+        ContentType:   contentType,
+        Size:          input.Size,
+    }
+    attachment.StorageKey = fmt.Sprintf("attachments/%s/%s", userID, attachment.ID)
+
+    // Batasi bacaan supaya ukuran sebenarnya tidak melebihi yang dilaporkan
+    body := io.LimitReader(io.MultiReader(bytes.NewReader(head), input.File), input.Size)
+    if err := s.blobs.Put(attachment.StorageKey, body, input.Size, contentType); err != nil {
+        return nil, err
+    }
+    if err := s.attachmentRepo.Create(attachment); err != nil {
+        removeBlobs(s.blobs, []domain.Attachment{*attachment})
+        return nil, err
+    }
+    return attachment, nil
+}
+
+// cleanFileName hanya menyimpan nama file tanpa path dari client
+func cleanFileName(name string) string {
+    name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+    if name == "." || name == "/" || name == "" {
+        return "attachment"
+    }
+    return name
+}
+
+func (s *attachmentService) Open(transactionID, id string, userID uuid.UUID) (*domain.Attachment, io.ReadCloser, error) {
+    attachment, err := s.findAttachment(transactionID, id, userID)
+    if err != nil {
+        return nil, nil, err
+    }
+    file, err := s.blobs.Get(attachment.StorageKey)
+    if err != nil {
+        return nil, nil, err
+    }
+    return attachment, file, nil
+}
+
+func (s *attachmentService) Delete(transactionID, id string, userID uuid.UUID) error {
+    attachment, err := s.findAttachment(transactionID, id, userID)
+    if err != nil {
+        return err
+    }
+    if err := s.attachmentRepo.Delete(attachment.ID, userID); err != nil {
+        return err
+    }
+    removeBlobs(s.blobs, []domain.Attachment{*attachment})
+    return nil
+}
+
+// removeBlobs dipanggil setelah metadata terhapus. Kegagalan hanya dicatat:
+// file yatim lebih aman daripada metadata yang menunjuk ke file hilang.
+func removeBlobs(blobs storage.Storage, attachments []domain.Attachment) {
+    for _, a := range attachments {
+        if err := blobs.Delete(a.StorageKey); err != nil {
+            log.Printf("Failed to delete attachment file %s: %v", a.StorageKey, err)
+        }
+    }
+}
@@ -0,0 +1,174 @@
+package service_test
+
+import (
+    "bytes"
+    "errors"
+    "io"
+    "testing"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/storage"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+// pngFile: header PNG yang cukup untuk dideteksi sebagai image/png
+var pngFile = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+func newAttachmentService(limits service.AttachmentLimits) (service.AttachmentService, *repomock.MockAttachmentRepository, *repomock.MockTransactionRepository, *storage.MemoryStorage) {
+    mockAttachmentRepo := new(repomock.MockAttachmentRepository)
+    mockTxRepo         := new(repomock.MockTransactionRepository)
+    blobs              := storage.NewMemoryStorage()
+    svc := service.NewAttachmentService(mockAttachmentRepo, mockTxRepo, blobs, limits)
+    return svc, mockAttachmentRepo, mockTxRepo, blobs
+}
+
+// ──────────────────────────────────────────
+// UPLOAD ATTACHMENT TESTS
+// ──────────────────────────────────────────
+
+func TestUploadAttachment_Success(t *testing.T) {
+    svc, mockAttachmentRepo, mockTxRepo, blobs := newAttachmentService(service.DefaultAttachmentLimits)
+
+    userID := uuid.New()
+    tx     := &domain.Transaction{ID: uuid.New(), UserID: userID}
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+    mockAttachmentRepo.On("TotalSize", userID).Return(int64(0), nil)
+    mockAttachmentRepo.On("Create", mock.AnythingOfType("*domain.Attachment")).Return(nil)
+
+    attachment, err := svc.Upload(tx.ID.String(), userID, service.UploadAttachmentInput{
+        FileName: `C:\Users\me\struk.png`,
+        Size:     int64(len(pngFile)),
+        File:     bytes.NewReader(pngFile),
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, "image/png", attachment.ContentType)
+    assert.Equal(t, "struk.png", attachment.FileName)
+    assert.True(t, blobs.Has(attachment.StorageKey))
+
+    stored, _ := blobs.Get(attachment.StorageKey)
+    data, _ := io.ReadAll(stored)
+    assert.Equal(t, pngFile, data)
+}
+
+func TestUploadAttachment_RejectsUnsupportedType(t *testing.T) {
+    svc, mockAttachmentRepo, mockTxRepo, _ := newAttachmentService(service.DefaultAttachmentLimits)
+
+    userID := uuid.New()
+    tx     := &domain.Transaction{ID: uuid.New(), UserID: userID}
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+    mockAttachmentRepo.On("TotalSize", userID).Return(int64(0), nil)
+
+    html := []byte("<html><script>alert(1)</script></html>")
+    _, err := svc.Upload(tx.ID.String(), userID, service.UploadAttachmentInput{
+        FileName: "struk.png",
+        Size:     int64(len(html)),
+        File:     bytes.NewReader(html),
+    })
+
+    assert.ErrorContains(t, err, "unsupported file type")
+    mockAttachmentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUploadAttachment_FileTooLarge(t *testing.T) {
+    svc, _, mockTxRepo, _ := newAttachmentService(service.AttachmentLimits{MaxFileSize: 50, UserQuota: 1 << 20})
+
+    userID := uuid.New()
+    tx     := &domain.Transaction{ID: uuid.New(), UserID: userID}
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+
+    _, err := svc.Upload(tx.ID.String(), userID, service.UploadAttachmentInput{
+        FileName: "struk.png",
+        Size:     int64(len(pngFile)),
+        File:     bytes.NewReader(pngFile),
+    })
+
+    assert.ErrorIs(t, err, service.ErrAttachmentTooLarge)
+}
+
+func TestUploadAttachment_QuotaExceeded(t *testing.T) {
+    svc, mockAttachmentRepo, mockTxRepo, _ := newAttachmentService(service.AttachmentLimits{MaxFileSize: 1 << 20, UserQuota: 1000})
+
+    userID := uuid.New()
+    tx     := &domain.Transaction{ID: uuid.New(), UserID: userID}
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+    mockAttachmentRepo.On("TotalSize", userID).Return(int64(950), nil)
+
+    _, err := svc.Upload(tx.ID.String(), userID, service.UploadAttachmentInput{
+        FileName: "struk.png",
+        Size:     int64(len(pngFile)),
+        File:     bytes.NewReader(pngFile),
+    })
+
+    assert.ErrorIs(t, err, service.ErrAttachmentQuota)
+}
+
+func TestUploadAttachment_RemovesFileWhenSaveFails(t *testing.T) {
+    svc, mockAttachmentRepo, mockTxRepo, blobs := newAttachmentService(service.DefaultAttachmentLimits)
+
+    userID := uuid.New()
+    tx     := &domain.Transaction{ID: uuid.New(), UserID: userID}
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+    mockAttachmentRepo.On("TotalSize", userID).Return(int64(0), nil)
+
+    var key string
+    mockAttachmentRepo.On("Create", mock.AnythingOfType("*domain.Attachment")).
+        Run(func(args mock.Arguments) { key = args.Get(0).(*domain.Attachment).StorageKey }).
+        Return(errors.New("db down"))
+
+    _, err := svc.Upload(tx.ID.String(), userID, service.UploadAttachmentInput{
+        FileName: "struk.png",
+        Size:     int64(len(pngFile)),
+        File:     bytes.NewReader(pngFile),
+    })
+
+    assert.Error(t, err)
+    assert.False(t, blobs.Has(key))
+}
+
+// ──────────────────────────────────────────
+// DELETE ATTACHMENT TESTS
+// ──────────────────────────────────────────
+
+func TestDeleteAttachment_OfAnotherTransaction(t *testing.T) {
+    svc, mockAttachmentRepo, mockTxRepo, _ := newAttachmentService(service.DefaultAttachmentLimits)
+
+    userID     := uuid.New()
+    tx         := &domain.Transaction{ID: uuid.New(), UserID: userID}
+    attachment := &domain.Attachment{ID: uuid.New(), UserID: userID, TransactionID: uuid.New()}
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+    mockAttachmentRepo.On("FindByID", attachment.ID, userID).Return(attachment, nil)
+
+    err := svc.Delete(tx.ID.String(), attachment.ID.String(), userID)
+
+    assert.EqualError(t, err, "attachment not found")
+    mockAttachmentRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteTransaction_RemovesAttachmentFiles(t *testing.T) {
+    mockTxRepo := new(repomock.MockTransactionRepository)
+    blobs      := storage.NewMemoryStorage()
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository), new(repomock.MockAccountRepository),
+        idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), blobs)
+
+    userID := uuid.New()
+    key    := "attachments/" + userID.String() + "/" + uuid.New().String()
+    blobs.Put(key, bytes.NewReader(pngFile), int64(len(pngFile)), "image/png")
+
+    tx := &domain.Transaction{
+        ID:          uuid.New(),
+        UserID:      userID,
+        Attachments: []domain.Attachment{{ID: uuid.New(), StorageKey: key}},
+    }
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+    mockTxRepo.On("Delete", tx.ID, userID).Return(nil)
+
+    err := svc.Delete(tx.ID.String(), userID)
+
+    assert.NoError(t, err)
+    assert.False(t, blobs.Has(key))
+}
@@ -2,6 +2,7 @@ package service
 
 import (
     "errors"
+    "log"
     "os"
     "time"
 
@@ -35,9 +36,25 @@ type LoginInput struct {
     Password string `json:"password" binding:"required"`
 }
 
+type ForgotPasswordInput struct {
+    Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+    Email       string `json:"email" binding:"required,email"`
+    Code        string `json:"code" binding:"required,len=6"`
+    NewPassword string `json:"new_password" binding:"required,min=8"`
+}
+
+type RefreshTokenInput struct {
+    RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
 type AuthResponse struct {
-    Token string      `json:"token"`
-    User  domain.User `json:"user"`
+    Token        string      `json:"token"`
+    RefreshToken string      `json:"refresh_token"`
+    ExpiresIn    int64       `json:"expires_in"` // detik, masa berlaku access token
+    User         domain.User `json:"user"`
 }
 
 type AuthService interface {
@@ -45,14 +62,26 @@ type AuthService interface {
     VerifyOTP(input VerifyOTPInput) (*AuthResponse, error) // verifikasi → return token
     ResendOTP(input ResendOTPInput) error
     Login(input LoginInput) (*AuthResponse, error)
+    Refresh(input RefreshTokenInput) (*AuthResponse, error) // rotasi refresh token
+    Logout(input RefreshTokenInput) error
+    ForgotPassword(input ForgotPasswordInput) error
+    ResetPassword(input ResetPasswordInput) error
 }
 
 type authService struct {
-    userRepo repository.UserRepository
+    userRepo    repository.UserRepository
+    sessionRepo repository.SessionRepository
+    otpStore    otp.Store
+    mailer      mailer.Mailer
 }
 
-func NewAuthService(userRepo repository.UserRepository) AuthService {
-    return &authService{userRepo}
+func NewAuthService(
+    userRepo repository.UserRepository,
+    sessionRepo repository.SessionRepository,
+    otpStore otp.Store,
+    mailer mailer.Mailer,
+) AuthService {
+    return &authService{userRepo, sessionRepo, otpStore, mailer}
 }
 
 func (s *authService) Register(input RegisterInput) error {
@@ -63,7 +92,7 @@ func (s *authService) Register(input RegisterInput) error {
             return errors.New("email sudah terdaftar")
         }
         // Email ada tapi belum verified → kirim ulang OTP
-        return s.sendOTP(existing.Name, existing.Email)
+        return s.sendOTP(otp.PurposeRegister, existing.Name, existing.Email)
     }
 
     // Hash password
@@ -84,25 +113,40 @@ func (s *authService) Register(input RegisterInput) error {
         return err
     }
 
-    return s.sendOTP(user.Name, user.Email)
+    return s.sendOTP(otp.PurposeRegister, user.Name, user.Email)
+}
+
+// sendOTP menerapkan cooldown + limit harian sebelum mengirim kode
+func (s *authService) sendOTP(purpose otp.Purpose, name, email string) error {
+    if err := s.otpStore.Allow(purpose, email); err != nil {
+        return err
+    }
+    return s.deliverOTP(purpose, name, email)
 }
 
-func (s *authService) sendOTP(name, email string) error {
+func (s *authService) deliverOTP(purpose otp.Purpose, name, email string) error {
     expiryMinutes, _ := strconv.Atoi(os.Getenv("OTP_EXPIRY_MINUTES"))
     if expiryMinutes == 0 {
         expiryMinutes = 5
     }
 
+    expiry := time.Duration(expiryMinutes) * time.Minute
+
     code := otp.Generate()
-    otp.Save(email, code, time.Duration(expiryMinutes)*time.Minute)
+    if err := s.otpStore.Save(purpose, email, code, expiry); err != nil {
+        return err
+    }
 
-    return mailer.SendOTP(email, name, code)
+    if purpose == otp.PurposeResetPassword {
+        return s.mailer.Send(mailer.PasswordResetMessage(email, name, code, expiry))
+    }
+    return s.mailer.Send(mailer.OTPMessage(email, name, code, expiry))
 }
 
 func (s *authService) VerifyOTP(input VerifyOTPInput) (*AuthResponse, error) {
     // Cek OTP valid
-    if !otp.Verify(input.Email, input.Code) {
-        return nil, errors.New("kode OTP tidak valid atau sudah kadaluarsa")
+    if err := s.otpStore.Verify(otp.PurposeRegister, input.Email, input.Code); err != nil {
+        return nil, err
     }
 
     // Ambil user
@@ -117,13 +161,12 @@ func (s *authService) VerifyOTP(input VerifyOTPInput) (*AuthResponse, error) {
     }
     user.IsVerified = true
 
-    // Generate token
-    token, err := jwtpkg.GenerateToken(user.ID, user.Email)
-    if err != nil {
-        return nil, err
+    // Email sambutan bersifat best-effort, gagal kirim tidak menggagalkan verifikasi
+    if err := s.mailer.Send(mailer.WelcomeMessage(user.Email, user.Name)); err != nil {
+        log.Printf("failed to send welcome email to %s: %v", user.Email, err)
     }
 
-    return &AuthResponse{Token: token, User: *user}, nil
+    return s.startSession(user)
 }
 
 func (s *authService) ResendOTP(input ResendOTPInput) error {
@@ -134,7 +177,7 @@ func (s *authService) ResendOTP(input ResendOTPInput) error {
     if user.IsVerified {
         return errors.New("akun sudah terverifikasi")
     }
-    return s.sendOTP(user.Name, user.Email)
+    return s.sendOTP(otp.PurposeRegister, user.Name, user.Email)
 }
 
 func (s *authService) Login(input LoginInput) (*AuthResponse, error) {
@@ -152,10 +195,142 @@ func (s *authService) Login(input LoginInput) (*AuthResponse, error) {
         return nil, errors.New("email atau password salah")
     }
 
-    token, err := jwtpkg.GenerateToken(user.ID, user.Email)
+    return s.startSession(user)
+}
+
+func (s *authService) Refresh(input RefreshTokenInput) (*AuthResponse, error) {
+    stored, err := s.sessionRepo.FindRefreshTokenByHash(jwtpkg.HashRefreshToken(input.RefreshToken))
     if err != nil {
+        return nil, errors.New("refresh token tidak valid")
+    }
+
+    session, err := s.sessionRepo.FindByID(stored.SessionID)
+    if err != nil || session.IsRevoked() {
+        return nil, errors.New("sesi sudah berakhir, silakan login ulang")
+    }
+
+    // Token yang sudah dirotasi dipakai lagi → kemungkinan dicuri,
+    // matikan seluruh session family
+    if stored.UsedAt != nil {
+        if err := s.sessionRepo.Revoke(session.ID); err != nil {
+            return nil, err
+        }
+        return nil, errors.New("refresh token sudah dipakai, silakan login ulang")
+    }
+
+    if time.Now().After(stored.ExpiresAt) {
+        return nil, errors.New("sesi sudah berakhir, silakan login ulang")
+    }
+
+    marked, err := s.sessionRepo.MarkRefreshTokenUsed(stored.ID)
+    if err != nil {
+        return nil, err
+    }
+    if !marked {
+        // Kalah balapan dengan request refresh lain yang memakai token yang sama
+        if err := s.sessionRepo.Revoke(session.ID); err != nil {
+            return nil, err
+        }
+        return nil, errors.New("refresh token sudah dipakai, silakan login ulang")
+    }
+
+    user, err := s.userRepo.FindByID(session.UserID)
+    if err != nil {
+        return nil, errors.New("user tidak ditemukan")
+    }
+
+    return s.issueTokens(user, session.ID)
+}
+
+func (s *authService) Logout(input RefreshTokenInput) error {
+    stored, err := s.sessionRepo.FindRefreshTokenByHash(jwtpkg.HashRefreshToken(input.RefreshToken))
+    if err != nil {
+        return errors.New("refresh token tidak valid")
+    }
+    return s.sessionRepo.Revoke(stored.SessionID)
+}
+
+func (s *authService) ForgotPassword(input ForgotPasswordInput) error {
+    // Throttle dicek sebelum lookup user, supaya respons 429 juga tidak
+    // membedakan email terdaftar dan tidak terdaftar
+    if err := s.otpStore.Allow(otp.PurposeResetPassword, input.Email); err != nil {
+        return err
+    }
+
+    // Jangan bocorkan apakah email terdaftar atau tidak
+    user, err := s.userRepo.FindByEmail(input.Email)
+    if err != nil || !user.IsVerified {
+        return nil
+    }
+    return s.deliverOTP(otp.PurposeResetPassword, user.Name, user.Email)
+}
+
+func (s *authService) ResetPassword(input ResetPasswordInput) error {
+    if err := s.otpStore.Verify(otp.PurposeResetPassword, input.Email, input.Code); err != nil {
+        return err
+    }
+
+    user, err := s.userRepo.FindByEmail(input.Email)
+    if err != nil {
+        return errors.New("user tidak ditemukan")
+    }
+
+    hashed, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), bcrypt.DefaultCost)
+    if err != nil {
+        return err
+    }
+
+    if err := s.userRepo.UpdatePassword(user.ID, string(hashed)); err != nil {
+        return err
+    }
+
+    // Password berubah → semua sesi lama wajib login ulang
+    if err := s.sessionRepo.RevokeAllByUser(user.ID); err != nil {
+        return err
+    }
+
+    if err := s.mailer.Send(mailer.PasswordChangedMessage(user.Email, user.Name)); err != nil {
+        log.Printf("failed to send password changed email to %s: %v", user.Email, err)
+    }
+    return nil
+}
+
+// startSession membuat session family baru untuk setiap login/verifikasi
+func (s *authService) startSession(user *domain.User) (*AuthResponse, error) {
+    session := &domain.Session{
+        ID:     uuid.New(),
+        UserID: user.ID,
+    }
+    if err := s.sessionRepo.Create(session); err != nil {
+        return nil, err
+    }
+    return s.issueTokens(user, session.ID)
+}
+
+func (s *authService) issueTokens(user *domain.User, sessionID uuid.UUID) (*AuthResponse, error) {
+    token, err := jwtpkg.GenerateToken(user.ID, user.Email, sessionID)
+    if err != nil {
+        return nil, err
+    }
+
+    refreshToken, hash, err := jwtpkg.GenerateRefreshToken()
+    if err != nil {
+        return nil, err
+    }
+
+    if err := s.sessionRepo.CreateRefreshToken(&domain.RefreshToken{
+        ID:        uuid.New(),
+        SessionID: sessionID,
+        TokenHash: hash,
+        ExpiresAt: time.Now().Add(jwtpkg.RefreshTokenTTL()),
+    }); err != nil {
         return nil, err
     }
 
-    return &AuthResponse{Token: token, User: *user}, nil
+    return &AuthResponse{
+        Token:        token,
+        RefreshToken: refreshToken,
+        ExpiresIn:    int64(jwtpkg.AccessTokenTTL().Seconds()),
+        User:         *user,
+    }, nil
 }
@@ -10,10 +10,24 @@ import (
     "github.com/myfarism/finance-tracker/internal/service"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/mock"
+    jwtpkg "github.com/myfarism/finance-tracker/pkg/jwt"
+    "github.com/myfarism/finance-tracker/pkg/mailer"
+    "github.com/myfarism/finance-tracker/pkg/otp"
     "golang.org/x/crypto/bcrypt"
     "os"
+    "time"
 )
 
+// fakeMailer menyimpan email yang "terkirim" supaya bisa diperiksa di test
+type fakeMailer struct {
+    sent []mailer.Message
+}
+
+func (m *fakeMailer) Send(msg mailer.Message) error {
+    m.sent = append(m.sent, msg)
+    return nil
+}
+
 func init() {
     // Set JWT secret untuk testing
     os.Setenv("JWT_SECRET", "test_secret_key_minimum_32_characters!!")
@@ -26,7 +40,8 @@ func init() {
 
 func TestLogin_Success(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otp.NewMemoryStore(), new(fakeMailer))
 
     hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
     mockUser := &domain.User{
@@ -37,6 +52,8 @@ func TestLogin_Success(t *testing.T) {
     }
 
     mockRepo.On("FindByEmail", "john@example.com").Return(mockUser, nil)
+    mockSessionRepo.On("Create", mock.AnythingOfType("*domain.Session")).Return(nil)
+    mockSessionRepo.On("CreateRefreshToken", mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
 
     result, err := svc.Login(service.LoginInput{
         Email:    "john@example.com",
@@ -46,12 +63,14 @@ func TestLogin_Success(t *testing.T) {
     assert.NoError(t, err)
     assert.NotNil(t, result)
     assert.NotEmpty(t, result.Token)
+    assert.NotEmpty(t, result.RefreshToken)
     mockRepo.AssertExpectations(t)
+    mockSessionRepo.AssertExpectations(t)
 }
 
 func TestLogin_EmailNotFound(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     mockRepo.On("FindByEmail", "notfound@example.com").
         Return(nil, errors.New("record not found"))
@@ -69,7 +88,7 @@ func TestLogin_EmailNotFound(t *testing.T) {
 
 func TestLogin_WrongPassword(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     hashed, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
     mockUser := &domain.User{
@@ -93,7 +112,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 
 func TestLogin_NotVerified(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
     mockUser := &domain.User{
@@ -121,7 +140,8 @@ func TestLogin_NotVerified(t *testing.T) {
 
 func TestRegister_Success(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    mail := new(fakeMailer)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), mail)
 
     // Email belum ada
     mockRepo.On("FindByEmail", "new@example.com").
@@ -135,15 +155,16 @@ func TestRegister_Success(t *testing.T) {
         Password: "password123",
     })
 
-    // Error di sini hanya dari SMTP (tidak ada di test env) — kita skip
-    // yang penting Create dipanggil
+    assert.NoError(t, err)
     mockRepo.AssertCalled(t, "Create", mock.AnythingOfType("*domain.User"))
-    _ = err // SMTP akan error di test env, tidak apa-apa
+    assert.Len(t, mail.sent, 1)
+    assert.Equal(t, "new@example.com", mail.sent[0].To)
+    assert.Equal(t, "Kode Verifikasi - Finance Tracker", mail.sent[0].Subject)
 }
 
 func TestRegister_EmailAlreadyExists(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     existingUser := &domain.User{
         ID:         uuid.New(),
@@ -171,7 +192,7 @@ func TestRegister_EmailAlreadyExists(t *testing.T) {
 
 func TestVerifyOTP_InvalidCode(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     result, err := svc.VerifyOTP(service.VerifyOTPInput{
         Email: "john@example.com",
@@ -189,7 +210,7 @@ func TestVerifyOTP_InvalidCode(t *testing.T) {
 
 func TestRegister_UnverifiedEmailResendOTP(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     // User ada tapi belum verified
     unverifiedUser := &domain.User{
@@ -201,7 +222,6 @@ func TestRegister_UnverifiedEmailResendOTP(t *testing.T) {
 
     mockRepo.On("FindByEmail", "john@example.com").Return(unverifiedUser, nil)
 
-    // Tidak assert error karena SMTP akan fail di test env
     _ = svc.Register(service.RegisterInput{
         Name:     "John",
         Email:    "john@example.com",
@@ -214,7 +234,7 @@ func TestRegister_UnverifiedEmailResendOTP(t *testing.T) {
 
 func TestRegister_DatabaseError(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     mockRepo.On("FindByEmail", "new@example.com").
         Return(nil, errors.New("not found"))
@@ -237,7 +257,7 @@ func TestRegister_DatabaseError(t *testing.T) {
 
 func TestResendOTP_UserNotFound(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     mockRepo.On("FindByEmail", "ghost@example.com").
         Return(nil, errors.New("not found"))
@@ -250,7 +270,7 @@ func TestResendOTP_UserNotFound(t *testing.T) {
 
 func TestResendOTP_AlreadyVerified(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     verifiedUser := &domain.User{
         ID:         uuid.New(),
@@ -272,7 +292,7 @@ func TestResendOTP_AlreadyVerified(t *testing.T) {
 
 func TestVerifyOTP_UserNotFoundAfterValidOTP(t *testing.T) {
     mockRepo := new(repomock.MockUserRepository)
-    svc := service.NewAuthService(mockRepo)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
 
     // Inject OTP valid ke cache dulu via ResendOTP workaround
     // Kita test skenario: OTP valid tapi user hilang dari DB
@@ -290,4 +310,253 @@ func TestVerifyOTP_UserNotFoundAfterValidOTP(t *testing.T) {
     assert.Equal(t, "kode OTP tidak valid atau sudah kadaluarsa", err.Error())
     // Pastikan FindByEmail tidak dipanggil karena OTP sudah gagal duluan
     mockRepo.AssertNotCalled(t, "FindByEmail")
-}
\ No newline at end of file
+}
+
+// ──────────────────────────────────────────
+// REFRESH & LOGOUT TESTS
+// ──────────────────────────────────────────
+
+func TestRefresh_RotatesToken(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otp.NewMemoryStore(), new(fakeMailer))
+
+    user := &domain.User{ID: uuid.New(), Email: "john@example.com", IsVerified: true}
+    session := &domain.Session{ID: uuid.New(), UserID: user.ID}
+    stored := &domain.RefreshToken{
+        ID:        uuid.New(),
+        SessionID: session.ID,
+        ExpiresAt: time.Now().Add(time.Hour),
+    }
+
+    mockSessionRepo.On("FindRefreshTokenByHash", jwtpkg.HashRefreshToken("old-token")).Return(stored, nil)
+    mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+    mockSessionRepo.On("MarkRefreshTokenUsed", stored.ID).Return(true, nil)
+    mockSessionRepo.On("CreateRefreshToken", mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+        return rt.SessionID == session.ID
+    })).Return(nil)
+    mockRepo.On("FindByID", user.ID).Return(user, nil)
+
+    result, err := svc.Refresh(service.RefreshTokenInput{RefreshToken: "old-token"})
+
+    assert.NoError(t, err)
+    assert.NotEmpty(t, result.Token)
+    assert.NotEqual(t, "old-token", result.RefreshToken)
+    // Rotasi tetap di session yang sama, tidak membuat session baru
+    mockSessionRepo.AssertNotCalled(t, "Create", mock.Anything)
+    mockSessionRepo.AssertExpectations(t)
+}
+
+func TestRefresh_ReuseRevokesSession(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otp.NewMemoryStore(), new(fakeMailer))
+
+    usedAt := time.Now().Add(-time.Minute)
+    session := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+    stored := &domain.RefreshToken{
+        ID:        uuid.New(),
+        SessionID: session.ID,
+        ExpiresAt: time.Now().Add(time.Hour),
+        UsedAt:    &usedAt,
+    }
+
+    mockSessionRepo.On("FindRefreshTokenByHash", jwtpkg.HashRefreshToken("stolen")).Return(stored, nil)
+    mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+    mockSessionRepo.On("Revoke", session.ID).Return(nil)
+
+    result, err := svc.Refresh(service.RefreshTokenInput{RefreshToken: "stolen"})
+
+    assert.Error(t, err)
+    assert.Nil(t, result)
+    assert.Equal(t, "refresh token sudah dipakai, silakan login ulang", err.Error())
+    mockSessionRepo.AssertCalled(t, "Revoke", session.ID)
+    mockSessionRepo.AssertNotCalled(t, "MarkRefreshTokenUsed", mock.Anything)
+}
+
+func TestRefresh_ReuseRevokeFails(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otp.NewMemoryStore(), new(fakeMailer))
+
+    usedAt := time.Now().Add(-time.Minute)
+    session := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+    stored := &domain.RefreshToken{
+        ID:        uuid.New(),
+        SessionID: session.ID,
+        ExpiresAt: time.Now().Add(time.Hour),
+        UsedAt:    &usedAt,
+    }
+
+    mockSessionRepo.On("FindRefreshTokenByHash", jwtpkg.HashRefreshToken("stolen")).Return(stored, nil)
+    mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+    mockSessionRepo.On("Revoke", session.ID).Return(errors.New("db down"))
+
+    result, err := svc.Refresh(service.RefreshTokenInput{RefreshToken: "stolen"})
+
+    assert.Nil(t, result)
+    assert.EqualError(t, err, "db down")
+}
+
+func TestRefresh_RevokedSession(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otp.NewMemoryStore(), new(fakeMailer))
+
+    revokedAt := time.Now()
+    session := &domain.Session{ID: uuid.New(), UserID: uuid.New(), RevokedAt: &revokedAt}
+    stored := &domain.RefreshToken{ID: uuid.New(), SessionID: session.ID, ExpiresAt: time.Now().Add(time.Hour)}
+
+    mockSessionRepo.On("FindRefreshTokenByHash", jwtpkg.HashRefreshToken("token")).Return(stored, nil)
+    mockSessionRepo.On("FindByID", session.ID).Return(session, nil)
+
+    _, err := svc.Refresh(service.RefreshTokenInput{RefreshToken: "token"})
+
+    assert.Error(t, err)
+    assert.Equal(t, "sesi sudah berakhir, silakan login ulang", err.Error())
+}
+
+func TestLogout_RevokesSession(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otp.NewMemoryStore(), new(fakeMailer))
+
+    stored := &domain.RefreshToken{ID: uuid.New(), SessionID: uuid.New()}
+    mockSessionRepo.On("FindRefreshTokenByHash", jwtpkg.HashRefreshToken("token")).Return(stored, nil)
+    mockSessionRepo.On("Revoke", stored.SessionID).Return(nil)
+
+    err := svc.Logout(service.RefreshTokenInput{RefreshToken: "token"})
+
+    assert.NoError(t, err)
+    mockSessionRepo.AssertExpectations(t)
+}
+
+// ──────────────────────────────────────────
+// FORGOT / RESET PASSWORD TESTS
+// ──────────────────────────────────────────
+
+func TestForgotPassword_UnknownEmailDoesNotLeak(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
+
+    mockRepo.On("FindByEmail", "ghost@example.com").
+        Return(nil, errors.New("not found"))
+
+    err := svc.ForgotPassword(service.ForgotPasswordInput{Email: "ghost@example.com"})
+
+    assert.NoError(t, err)
+}
+
+func TestResetPassword_RegisterOTPRejected(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    otpStore := otp.NewMemoryStore()
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otpStore, new(fakeMailer))
+
+    // OTP registrasi tidak boleh bisa dipakai untuk reset password
+    otpStore.Save(otp.PurposeRegister, "reset1@example.com", "123456", time.Minute)
+
+    err := svc.ResetPassword(service.ResetPasswordInput{
+        Email:       "reset1@example.com",
+        Code:        "123456",
+        NewPassword: "newpassword123",
+    })
+
+    assert.Error(t, err)
+    assert.Equal(t, "kode OTP tidak valid atau sudah kadaluarsa", err.Error())
+    mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+}
+
+func TestResetPassword_Success(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    otpStore := otp.NewMemoryStore()
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otpStore, new(fakeMailer))
+
+    user := &domain.User{ID: uuid.New(), Email: "reset2@example.com", IsVerified: true}
+    otpStore.Save(otp.PurposeResetPassword, user.Email, "654321", time.Minute)
+
+    mockRepo.On("FindByEmail", user.Email).Return(user, nil)
+    mockRepo.On("UpdatePassword", user.ID, mock.MatchedBy(func(hash string) bool {
+        return bcrypt.CompareHashAndPassword([]byte(hash), []byte("newpassword123")) == nil
+    })).Return(nil)
+    mockSessionRepo.On("RevokeAllByUser", user.ID).Return(nil)
+
+    err := svc.ResetPassword(service.ResetPasswordInput{
+        Email:       user.Email,
+        Code:        "654321",
+        NewPassword: "newpassword123",
+    })
+
+    assert.NoError(t, err)
+    mockRepo.AssertExpectations(t)
+    mockSessionRepo.AssertExpectations(t)
+}
+
+// ──────────────────────────────────────────
+// OTP THROTTLING TESTS
+// ──────────────────────────────────────────
+
+func TestVerifyOTP_LocksAfterTooManyAttempts(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    otpStore := otp.NewMemoryStore()
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otpStore, new(fakeMailer))
+
+    email := "bruteforce@example.com"
+    otpStore.Save(otp.PurposeRegister, email, "111111", time.Minute)
+
+    var err error
+    for i := 0; i < 5; i++ {
+        _, err = svc.VerifyOTP(service.VerifyOTPInput{Email: email, Code: "000000"})
+    }
+
+    var rateLimited *otp.RateLimitError
+    assert.ErrorAs(t, err, &rateLimited)
+    assert.Contains(t, err.Error(), "terlalu banyak percobaan")
+
+    // Kode yang benar pun ditolak selama masih terkunci
+    _, err = svc.VerifyOTP(service.VerifyOTPInput{Email: email, Code: "111111"})
+    assert.ErrorAs(t, err, &rateLimited)
+    mockRepo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+}
+
+func TestResendOTP_Cooldown(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    svc := service.NewAuthService(mockRepo, new(repomock.MockSessionRepository), otp.NewMemoryStore(), new(fakeMailer))
+
+    user := &domain.User{ID: uuid.New(), Name: "John", Email: "cooldown@example.com"}
+    mockRepo.On("FindByEmail", user.Email).Return(user, nil)
+
+    err := svc.ResendOTP(service.ResendOTPInput{Email: user.Email})
+    assert.NoError(t, err)
+
+    var rateLimited *otp.RateLimitError
+
+    err = svc.ResendOTP(service.ResendOTPInput{Email: user.Email})
+    assert.ErrorAs(t, err, &rateLimited)
+    assert.Greater(t, rateLimited.RetryAfter, time.Duration(0))
+}
+
+func TestVerifyOTP_SendsWelcomeEmail(t *testing.T) {
+    mockRepo := new(repomock.MockUserRepository)
+    mockSessionRepo := new(repomock.MockSessionRepository)
+    otpStore := otp.NewMemoryStore()
+    mail := new(fakeMailer)
+    svc := service.NewAuthService(mockRepo, mockSessionRepo, otpStore, mail)
+
+    user := &domain.User{ID: uuid.New(), Name: "<b>Jane</b>", Email: "welcome@example.com"}
+    otpStore.Save(otp.PurposeRegister, user.Email, "222222", time.Minute)
+
+    mockRepo.On("FindByEmail", user.Email).Return(user, nil)
+    mockRepo.On("UpdateVerified", user.ID, true).Return(nil)
+    mockSessionRepo.On("Create", mock.AnythingOfType("*domain.Session")).Return(nil)
+    mockSessionRepo.On("CreateRefreshToken", mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+    result, err := svc.VerifyOTP(service.VerifyOTPInput{Email: user.Email, Code: "222222"})
+
+    assert.NoError(t, err)
+    assert.True(t, result.User.IsVerified)
+    assert.Len(t, mail.sent, 1)
+    assert.Equal(t, "Selamat Datang di Finance Tracker", mail.sent[0].Subject)
+    // Nama user di-escape oleh html/template
+    assert.NotContains(t, mail.sent[0].HTML, "<b>Jane</b>")
+}
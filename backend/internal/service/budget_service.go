@@ -10,10 +10,10 @@ import (
 )
 
 type UpsertBudgetInput struct {
-    CategoryID string  `json:"category_id" binding:"required"`
-    Amount     float64 `json:"amount" binding:"required,gt=0"`
-    Month      int     `json:"month" binding:"required,min=1,max=12"`
-    Year       int     `json:"year" binding:"required,min=2000"`
+    CategoryID string       `json:"category_id" binding:"required"`
+    Amount     domain.Money `json:"amount" binding:"required,gt=0"`
+    Month      int          `json:"month" binding:"required,min=1,max=12"`
+    Year       int          `json:"year" binding:"required,min=2000"`
 }
 
 type BudgetService interface {
@@ -25,13 +25,19 @@ type BudgetService interface {
 type budgetService struct {
     budgetRepo repository.BudgetRepository
     txRepo     repository.TransactionRepository
+    catRepo    repository.CategoryRepository
+    userRepo   repository.UserRepository
+    rateRepo   repository.ExchangeRateRepository
 }
 
 func NewBudgetService(
     budgetRepo repository.BudgetRepository,
     txRepo repository.TransactionRepository,
+    catRepo repository.CategoryRepository,
+    userRepo repository.UserRepository,
+    rateRepo repository.ExchangeRateRepository,
 ) BudgetService {
-    return &budgetService{budgetRepo, txRepo}
+    return &budgetService{budgetRepo, txRepo, catRepo, userRepo, rateRepo}
 }
 
 // Di budget_service.go — ganti fungsi Upsert
@@ -41,6 +47,14 @@ func (s *budgetService) Upsert(userID uuid.UUID, input UpsertBudgetInput) (*doma
         return nil, errors.New("invalid category_id")
     }
 
+    cat, err := s.catRepo.FindByID(catID, userID)
+    if err != nil {
+        return nil, errors.New("category not found")
+    }
+    if cat.IsArchived {
+        return nil, errors.New("category is archived")
+    }
+
     budget := &domain.Budget{
         ID:         uuid.New(),
         UserID:     userID,
@@ -78,9 +92,7 @@ func (s *budgetService) GetByMonth(userID uuid.UUID, month, year int) ([]domain.
     }
 
     // Ambil semua transaksi bulan ini untuk hitung spent
-    now := time.Now()
-    startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, now.Location())
-    endDate := startDate.AddDate(0, 1, -1)
+    startDate, endDate := monthRange(month, year)
 
     filter := repository.TransactionFilter{
         StartDate: &startDate,
@@ -92,25 +104,54 @@ func (s *budgetService) GetByMonth(userID uuid.UUID, month, year int) ([]domain.
         return nil, err
     }
 
-    // Hitung spent per kategori dari transaksi
-    spentMap := map[uuid.UUID]float64{}
+    // Budget selalu dalam base currency, transaksi valas dikonversi
+    // dengan kurs di tanggal transaksinya
+    base, err := baseCurrency(s.userRepo, userID)
+    if err != nil {
+        return nil, err
+    }
+    converter := newCurrencyConverter(s.rateRepo, userID)
+
+    // Hitung spent per kategori dari transaksi, transaksi split per split-nya
+    spentMap := map[uuid.UUID]domain.Money{}
     for _, tx := range transactions {
-        if tx.Type == domain.Expense {
-            spentMap[tx.CategoryID] += tx.Amount
+        if tx.Type != domain.Expense {
+            continue
+        }
+        for categoryID, amount := range tx.CategoryAmounts() {
+            converted, err := converter.convert(amount, tx.Currency, base, tx.Date)
+            if err != nil {
+                return nil, err
+            }
+            spentMap[categoryID] += converted
         }
     }
 
+    // Spent sub-kategori ikut dihitung di budget parent-nya
+    categories, err := s.catRepo.FindAllByUser(userID, repository.CategoryFilter{IncludeArchived: true})
+    if err != nil {
+        return nil, err
+    }
+    spentMap = newCategoryTree(categories).rollUp(spentMap)
+
     // Enrich budget dengan data spent
     for i := range budgets {
         spent := spentMap[budgets[i].CategoryID]
         budgets[i].Spent = spent
         budgets[i].Remaining = budgets[i].Amount - spent
         budgets[i].IsOver = spent > budgets[i].Amount
+        budgets[i].Currency = base
     }
 
     return budgets, nil
 }
 
+// monthRange return tanggal pertama dan terakhir dari bulan tersebut
+func monthRange(month, year int) (time.Time, time.Time) {
+    startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Now().Location())
+    return startDate, startDate.AddDate(0, 1, -1)
+}
+
 func (s *budgetService) Delete(id string, userID uuid.UUID) error {
     budgetID, err := uuid.Parse(id)
     if err != nil {
@@ -1,6 +1,7 @@
 package service_test
 
 import (
+    "errors"
     "testing"
     "time"
 
@@ -16,11 +17,13 @@ import (
 func TestUpsertBudget_Success(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
     userID := uuid.New()
     catID  := uuid.New()
 
+    mockCatRepo.On("FindByID", catID, userID).Return(&domain.Category{ID: catID, UserID: &userID}, nil)
     mockBudgetRepo.On("Upsert", mock.AnythingOfType("*domain.Budget")).Return(nil)
     mockBudgetRepo.On("FindByUserAndMonth", userID, 2, 2026).
         Return([]domain.Budget{
@@ -29,37 +32,40 @@ func TestUpsertBudget_Success(t *testing.T) {
                 UserID:     userID,
                 CategoryID: catID,
                 Category:   domain.Category{ID: catID, Name: "Makan", Icon: "🍜"},
-                Amount:     500000,
+                Amount:     domain.NewMoney(500000),
                 Month:      2,
                 Year:       2026,
             },
         }, nil)
 
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{}, nil)
     mockTxRepo.On("FindAllByUser", userID,
         mock.AnythingOfType("repository.TransactionFilter")). // ← fix
         Return([]domain.Transaction{}, nil)
 
     result, err := svc.Upsert(userID, service.UpsertBudgetInput{
         CategoryID: catID.String(),
-        Amount:     500000,
+        Amount:     domain.NewMoney(500000),
         Month:      2,
         Year:       2026,
     })
 
     assert.NoError(t, err)
     assert.NotNil(t, result)
-    assert.Equal(t, 500000.0, result.Amount)
+    assert.Equal(t, domain.NewMoney(500000), result.Amount)
     mockBudgetRepo.AssertExpectations(t)
 }
 
 func TestUpsertBudget_InvalidCategoryID(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
     _, err := svc.Upsert(uuid.New(), service.UpsertBudgetInput{
         CategoryID: "bukan-uuid",
-        Amount:     500000,
+        Amount:     domain.NewMoney(500000),
         Month:      2,
         Year:       2026,
     })
@@ -72,16 +78,19 @@ func TestUpsertBudget_InvalidCategoryID(t *testing.T) {
 func TestUpsertBudget_DatabaseError(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
-    catID := uuid.New()
+    catID  := uuid.New()
+    userID := uuid.New()
 
+    mockCatRepo.On("FindByID", catID, userID).Return(&domain.Category{ID: catID}, nil)
     mockBudgetRepo.On("Upsert", mock.AnythingOfType("*domain.Budget")).
         Return(assert.AnError)
 
-    _, err := svc.Upsert(uuid.New(), service.UpsertBudgetInput{
+    _, err := svc.Upsert(userID, service.UpsertBudgetInput{
         CategoryID: catID.String(),
-        Amount:     500000,
+        Amount:     domain.NewMoney(500000),
         Month:      2,
         Year:       2026,
     })
@@ -90,10 +99,35 @@ func TestUpsertBudget_DatabaseError(t *testing.T) {
     mockBudgetRepo.AssertNotCalled(t, "FindByUserAndMonth")
 }
 
+func TestUpsertBudget_CategoryOfAnotherUser(t *testing.T) {
+    mockBudgetRepo := new(repomock.MockBudgetRepository)
+    mockTxRepo     := new(repomock.MockTransactionRepository)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    catID  := uuid.New()
+
+    // Repository tidak menemukan kategori milik user lain
+    mockCatRepo.On("FindByID", catID, userID).Return(nil, errors.New("record not found"))
+
+    _, err := svc.Upsert(userID, service.UpsertBudgetInput{
+        CategoryID: catID.String(),
+        Amount:     domain.NewMoney(500000),
+        Month:      2,
+        Year:       2026,
+    })
+
+    assert.Error(t, err)
+    assert.Equal(t, "category not found", err.Error())
+    mockBudgetRepo.AssertNotCalled(t, "Upsert", mock.Anything)
+}
+
 func TestGetBudgetByMonth_WithSpentCalculation(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
     userID := uuid.New()
     catID  := uuid.New()
@@ -105,21 +139,23 @@ func TestGetBudgetByMonth_WithSpentCalculation(t *testing.T) {
                 UserID:     userID,
                 CategoryID: catID,
                 Category:   domain.Category{ID: catID, Name: "Makan"},
-                Amount:     500000,
+                Amount:     domain.NewMoney(500000),
                 Month:      2,
                 Year:       2026,
             },
         }, nil)
 
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{}, nil)
     mockTxRepo.On("FindAllByUser", userID,
         mock.AnythingOfType("repository.TransactionFilter")). // ← fix
         Return([]domain.Transaction{
             {
                 ID:         uuid.New(),
                 UserID:     userID,
-                CategoryID: catID,
+                CategoryID: &catID,
                 Type:       domain.Expense,
-                Amount:     150000,
+                Amount:     domain.NewMoney(150000),
                 Date:       time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC),
             },
         }, nil)
@@ -128,15 +164,16 @@ func TestGetBudgetByMonth_WithSpentCalculation(t *testing.T) {
 
     assert.NoError(t, err)
     assert.Len(t, budgets, 1)
-    assert.Equal(t, 150000.0, budgets[0].Spent)
-    assert.Equal(t, 350000.0, budgets[0].Remaining)
+    assert.Equal(t, domain.NewMoney(150000), budgets[0].Spent)
+    assert.Equal(t, domain.NewMoney(350000), budgets[0].Remaining)
     assert.False(t, budgets[0].IsOver)
 }
 
 func TestGetBudgetByMonth_OverBudget(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
     userID := uuid.New()
     catID  := uuid.New()
@@ -147,19 +184,21 @@ func TestGetBudgetByMonth_OverBudget(t *testing.T) {
                 ID:         uuid.New(),
                 UserID:     userID,
                 CategoryID: catID,
-                Amount:     200000,
+                Amount:     domain.NewMoney(200000),
                 Month:      2,
                 Year:       2026,
             },
         }, nil)
 
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{}, nil)
     mockTxRepo.On("FindAllByUser", userID,
         mock.AnythingOfType("repository.TransactionFilter")). // ← fix
         Return([]domain.Transaction{
             {
-                CategoryID: catID,
+                CategoryID: &catID,
                 Type:       domain.Expense,
-                Amount:     350000,
+                Amount:     domain.NewMoney(350000),
                 Date:       time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC),
             },
         }, nil)
@@ -168,20 +207,23 @@ func TestGetBudgetByMonth_OverBudget(t *testing.T) {
 
     assert.NoError(t, err)
     assert.True(t, budgets[0].IsOver)
-    assert.Equal(t, 350000.0, budgets[0].Spent)
-    assert.Equal(t, -150000.0, budgets[0].Remaining)
+    assert.Equal(t, domain.NewMoney(350000), budgets[0].Spent)
+    assert.Equal(t, domain.NewMoney(-150000), budgets[0].Remaining)
 }
 
 func TestGetBudgetByMonth_EmptyBudgets(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
     userID := uuid.New()
 
     mockBudgetRepo.On("FindByUserAndMonth", userID, 2, 2026).
         Return([]domain.Budget{}, nil)
 
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{}, nil)
     mockTxRepo.On("FindAllByUser", userID,
         mock.AnythingOfType("repository.TransactionFilter")). // ← fix
         Return([]domain.Transaction{}, nil)
@@ -192,10 +234,91 @@ func TestGetBudgetByMonth_EmptyBudgets(t *testing.T) {
     assert.Empty(t, budgets)
 }
 
+func TestGetBudgetByMonth_RollsUpSubCategories(t *testing.T) {
+    mockBudgetRepo := new(repomock.MockBudgetRepository)
+    mockTxRepo     := new(repomock.MockTransactionRepository)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
+
+    userID      := uuid.New()
+    makananID   := uuid.New()
+    restoranID  := uuid.New()
+    groceriesID := uuid.New()
+
+    mockBudgetRepo.On("FindByUserAndMonth", userID, 2, 2026).
+        Return([]domain.Budget{
+            {ID: uuid.New(), UserID: userID, CategoryID: makananID, Amount: domain.NewMoney(1000000), Month: 2, Year: 2026},
+            {ID: uuid.New(), UserID: userID, CategoryID: restoranID, Amount: domain.NewMoney(300000), Month: 2, Year: 2026},
+        }, nil)
+
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{
+            {ID: makananID, Name: "Makanan"},
+            {ID: restoranID, Name: "Restoran", ParentID: &makananID},
+            {ID: groceriesID, Name: "Groceries", ParentID: &makananID},
+        }, nil)
+
+    mockTxRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return([]domain.Transaction{
+            {CategoryID: &makananID, Type: domain.Expense, Amount: domain.NewMoney(50000)},
+            {CategoryID: &restoranID, Type: domain.Expense, Amount: domain.NewMoney(200000)},
+            {CategoryID: &groceriesID, Type: domain.Expense, Amount: domain.NewMoney(400000)},
+        }, nil)
+
+    budgets, err := svc.GetByMonth(userID, 2, 2026)
+
+    assert.NoError(t, err)
+    // Budget "Makanan" mencakup semua sub-kategorinya
+    assert.Equal(t, domain.NewMoney(650000), budgets[0].Spent)
+    assert.Equal(t, domain.NewMoney(350000), budgets[0].Remaining)
+    // Budget sub-kategori hanya menghitung dirinya sendiri
+    assert.Equal(t, domain.NewMoney(200000), budgets[1].Spent)
+}
+
+func TestGetBudgetByMonth_CountsSplitsPerCategory(t *testing.T) {
+    mockBudgetRepo := new(repomock.MockBudgetRepository)
+    mockTxRepo     := new(repomock.MockTransactionRepository)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
+
+    userID      := uuid.New()
+    groceriesID := uuid.New()
+    healthID    := uuid.New()
+
+    mockBudgetRepo.On("FindByUserAndMonth", userID, 2, 2026).
+        Return([]domain.Budget{
+            {ID: uuid.New(), UserID: userID, CategoryID: groceriesID, Amount: domain.NewMoney(1000000), Month: 2, Year: 2026},
+            {ID: uuid.New(), UserID: userID, CategoryID: healthID, Amount: domain.NewMoney(100000), Month: 2, Year: 2026},
+        }, nil)
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{{ID: groceriesID, Name: "Groceries"}, {ID: healthID, Name: "Kesehatan"}}, nil)
+
+    // Satu struk supermarket: groceries 250rb + obat 50rb
+    mockTxRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return([]domain.Transaction{
+            {
+                CategoryID: &groceriesID,
+                Type:       domain.Expense,
+                Amount:     domain.NewMoney(300000),
+                Splits: []domain.TransactionSplit{
+                    {CategoryID: groceriesID, Amount: domain.NewMoney(250000)},
+                    {CategoryID: healthID, Amount: domain.NewMoney(50000)},
+                },
+            },
+        }, nil)
+
+    budgets, err := svc.GetByMonth(userID, 2, 2026)
+
+    assert.NoError(t, err)
+    assert.Equal(t, domain.NewMoney(250000), budgets[0].Spent)
+    assert.Equal(t, domain.NewMoney(50000), budgets[1].Spent)
+}
+
 func TestDeleteBudget_Success(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
     budgetID := uuid.New()
     userID   := uuid.New()
@@ -211,7 +334,8 @@ func TestDeleteBudget_Success(t *testing.T) {
 func TestDeleteBudget_InvalidID(t *testing.T) {
     mockBudgetRepo := new(repomock.MockBudgetRepository)
     mockTxRepo     := new(repomock.MockTransactionRepository)
-    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo)
+    mockCatRepo    := new(repomock.MockCategoryRepository)
+    svc := service.NewBudgetService(mockBudgetRepo, mockTxRepo, mockCatRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
 
     err := svc.Delete("bukan-uuid", uuid.New())
 
@@ -1,13 +1,41 @@
 package service
 
 import (
+    "errors"
+    "fmt"
+
+    "github.com/google/uuid"
     "github.com/myfarism/finance-tracker/internal/domain"
     "github.com/myfarism/finance-tracker/internal/repository"
 )
 
+type CreateCategoryInput struct {
+    Name     string `json:"name" binding:"required"`
+    Icon     string `json:"icon"`
+    Kind     string `json:"kind" binding:"omitempty,oneof=income expense both"`
+    ParentID string `json:"parent_id"`
+}
+
+type UpdateCategoryInput struct {
+    Name       string  `json:"name"`
+    Icon       string  `json:"icon"`
+    Kind       string  `json:"kind" binding:"omitempty,oneof=income expense both"`
+    IsArchived *bool   `json:"is_archived"`
+    ParentID   *string `json:"parent_id"` // "" = jadikan kategori utama
+}
+
+type MergeCategoryInput struct {
+    TargetID string `json:"target_id" binding:"required"`
+}
+
 type CategoryService interface {
-    GetAll() ([]domain.Category, error)
-    Create(cat *domain.Category) error
+    GetAll(userID uuid.UUID, filter repository.CategoryFilter) ([]domain.Category, error)
+    Create(userID uuid.UUID, input CreateCategoryInput) (*domain.Category, error)
+    Update(id string, userID uuid.UUID, input UpdateCategoryInput) (*domain.Category, error)
+    // Delete menolak kategori yang masih dipakai kecuali reassignTo diisi
+    Delete(id string, userID uuid.UUID, reassignTo string) error
+    // Merge melebur kategori id ke target: transaksi dipindah, budget dijumlahkan
+    Merge(id string, userID uuid.UUID, input MergeCategoryInput) (*domain.Category, error)
 }
 
 type categoryService struct {
@@ -18,10 +46,201 @@ func NewCategoryService(catRepo repository.CategoryRepository) CategoryService {
     return &categoryService{catRepo}
 }
 
-func (s *categoryService) GetAll() ([]domain.Category, error) {
-    return s.catRepo.FindAll()
+func (s *categoryService) GetAll(userID uuid.UUID, filter repository.CategoryFilter) ([]domain.Category, error) {
+    return s.catRepo.FindAllByUser(userID, filter)
+}
+
+func (s *categoryService) Create(userID uuid.UUID, input CreateCategoryInput) (*domain.Category, error) {
+    kind := domain.CategoryKind(input.Kind)
+    if kind == "" {
+        kind = domain.CategoryKindBoth
+    }
+
+    cat := &domain.Category{
+        ID:     uuid.New(),
+        UserID: &userID,
+        Name:   input.Name,
+        Icon:   input.Icon,
+        Kind:   kind,
+    }
+
+    parentID, err := s.resolveParent(cat, input.ParentID, userID)
+    if err != nil {
+        return nil, err
+    }
+    cat.ParentID = parentID
+
+    if err := s.catRepo.Create(cat); err != nil {
+        return nil, err
+    }
+    return cat, nil
+}
+
+// findOwned mengambil kategori yang boleh diubah user: bukan kategori sistem
+func (s *categoryService) findOwned(id string, userID uuid.UUID) (*domain.Category, error) {
+    catID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid category id")
+    }
+
+    cat, err := s.catRepo.FindByID(catID, userID)
+    if err != nil {
+        return nil, errors.New("category not found")
+    }
+    if cat.IsSystem() {
+        return nil, errors.New("system categories cannot be modified")
+    }
+    return cat, nil
+}
+
+// resolveParent memvalidasi parent_id: harus terlihat oleh user, tidak
+// di-archive, kind-nya cocok, tidak membentuk siklus, dan tidak melebihi
+// MaxCategoryDepth
+func (s *categoryService) resolveParent(cat *domain.Category, parentID string, userID uuid.UUID) (*uuid.UUID, error) {
+    if parentID == "" {
+        return nil, nil
+    }
+    catID := cat.ID
+
+    pid, err := uuid.Parse(parentID)
+    if err != nil {
+        return nil, errors.New("invalid parent_id")
+    }
+    if pid == catID {
+        return nil, errors.New("category cannot be its own parent")
+    }
+
+    categories, err := s.catRepo.FindAllByUser(userID, repository.CategoryFilter{IncludeArchived: true})
+    if err != nil {
+        return nil, err
+    }
+    tree := newCategoryTree(categories)
+
+    parent, ok := tree.byID[pid]
+    if !ok {
+        return nil, errors.New("parent category not found")
+    }
+    if parent.IsArchived {
+        return nil, errors.New("parent category is archived")
+    }
+    if !parent.Allows(domain.Income) || !parent.Allows(domain.Expense) {
+        // Parent hanya untuk satu tipe → sub-kategori harus sama
+        if parent.Kind != cat.Kind {
+            return nil, fmt.Errorf("sub-category of an %s category must also be %s", parent.Kind, parent.Kind)
+        }
+    }
+    if tree.isAncestor(catID, pid) {
+        return nil, errors.New("parent category cannot be one of its sub-categories")
+    }
+    if tree.depth(pid)+tree.height(catID) > domain.MaxCategoryDepth {
+        return nil, fmt.Errorf("categories can be nested at most %d levels deep", domain.MaxCategoryDepth)
+    }
+
+    return &pid, nil
+}
+
+// findTarget mengambil kategori tujuan reassign/merge
+func (s *categoryService) findTarget(id string, source *domain.Category, userID uuid.UUID) (*domain.Category, error) {
+    targetID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid target category id")
+    }
+    if targetID == source.ID {
+        return nil, errors.New("target category must be different")
+    }
+
+    target, err := s.catRepo.FindByID(targetID, userID)
+    if err != nil {
+        return nil, errors.New("target category not found")
+    }
+    if target.IsArchived {
+        return nil, errors.New("target category is archived")
+    }
+    return target, nil
+}
+
+func (s *categoryService) Update(id string, userID uuid.UUID, input UpdateCategoryInput) (*domain.Category, error) {
+    cat, err := s.findOwned(id, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    if input.Name != "" {
+        cat.Name = input.Name
+    }
+    if input.Icon != "" {
+        cat.Icon = input.Icon
+    }
+    if input.Kind != "" {
+        cat.Kind = domain.CategoryKind(input.Kind)
+    }
+    if input.IsArchived != nil {
+        cat.IsArchived = *input.IsArchived
+    }
+
+    parentID := ""
+    if cat.ParentID != nil {
+        parentID = cat.ParentID.String()
+    }
+    if input.ParentID != nil {
+        parentID = *input.ParentID
+    }
+    // Validasi ulang parent juga saat kind berubah
+    if input.ParentID != nil || input.Kind != "" {
+        resolved, err := s.resolveParent(cat, parentID, userID)
+        if err != nil {
+            return nil, err
+        }
+        cat.ParentID = resolved
+    }
+
+    if err := s.catRepo.Update(cat); err != nil {
+        return nil, err
+    }
+    return cat, nil
+}
+
+func (s *categoryService) Delete(id string, userID uuid.UUID, reassignTo string) error {
+    cat, err := s.findOwned(id, userID)
+    if err != nil {
+        return err
+    }
+
+    if reassignTo != "" {
+        target, err := s.findTarget(reassignTo, cat, userID)
+        if err != nil {
+            return err
+        }
+        return s.catRepo.ReassignAndDelete(cat.ID, target.ID, userID, false)
+    }
+
+    transactions, budgets, recurring, err := s.catRepo.CountUsage(cat.ID, userID)
+    if err != nil {
+        return err
+    }
+    if transactions > 0 || budgets > 0 || recurring > 0 {
+        return fmt.Errorf(
+            "category is used by %d transactions, %d budgets and %d recurring rules, provide reassign_to to move them",
+            transactions, budgets, recurring,
+        )
+    }
+
+    return s.catRepo.Delete(cat.ID, userID)
 }
 
-func (s *categoryService) Create(cat *domain.Category) error {
-    return s.catRepo.Create(cat)
+func (s *categoryService) Merge(id string, userID uuid.UUID, input MergeCategoryInput) (*domain.Category, error) {
+    cat, err := s.findOwned(id, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    target, err := s.findTarget(input.TargetID, cat, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := s.catRepo.ReassignAndDelete(cat.ID, target.ID, userID, true); err != nil {
+        return nil, err
+    }
+    return target, nil
 }
@@ -0,0 +1,264 @@
+package service_test
+
+import (
+    "testing"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+// ──────────────────────────────────────────
+// UPDATE / ARCHIVE CATEGORY TESTS
+// ──────────────────────────────────────────
+
+func TestUpdateCategory_Archive(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID, Name: "Kopi"}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockCatRepo.On("Update", mock.AnythingOfType("*domain.Category")).Return(nil)
+
+    archived := true
+    result, err := svc.Update(cat.ID.String(), userID, service.UpdateCategoryInput{IsArchived: &archived})
+
+    assert.NoError(t, err)
+    assert.True(t, result.IsArchived)
+    assert.Equal(t, "Kopi", result.Name)
+}
+
+func TestUpdateCategory_SystemCategoryRejected(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    system := &domain.Category{ID: uuid.New(), Name: "Makanan"}
+
+    mockCatRepo.On("FindByID", system.ID, userID).Return(system, nil)
+
+    _, err := svc.Update(system.ID.String(), userID, service.UpdateCategoryInput{Name: "Makan"})
+
+    assert.Error(t, err)
+    assert.Equal(t, "system categories cannot be modified", err.Error())
+    mockCatRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+// ──────────────────────────────────────────
+// SUB-CATEGORY TESTS
+// ──────────────────────────────────────────
+
+func TestCreateCategory_WithParent(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    makanan := domain.Category{ID: uuid.New(), Name: "Makanan"}
+
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{makanan}, nil)
+    mockCatRepo.On("Create", mock.AnythingOfType("*domain.Category")).Return(nil)
+
+    result, err := svc.Create(userID, service.CreateCategoryInput{
+        Name:     "Restoran",
+        ParentID: makanan.ID.String(),
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, makanan.ID, *result.ParentID)
+}
+
+func TestCreateCategory_TooDeep(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    makanan := domain.Category{ID: uuid.New(), Name: "Makanan"}
+    restoran := domain.Category{ID: uuid.New(), Name: "Restoran", UserID: &userID, ParentID: &makanan.ID}
+
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{makanan, restoran}, nil)
+
+    _, err := svc.Create(userID, service.CreateCategoryInput{
+        Name:     "Sushi",
+        ParentID: restoran.ID.String(),
+    })
+
+    assert.Error(t, err)
+    assert.Equal(t, "categories can be nested at most 2 levels deep", err.Error())
+    mockCatRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateCategory_KindMustMatchParent(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    makanan := domain.Category{ID: uuid.New(), Name: "Makanan", Kind: domain.CategoryKindExpense}
+
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{makanan}, nil)
+
+    _, err := svc.Create(userID, service.CreateCategoryInput{
+        Name:     "Cashback",
+        Kind:     "income",
+        ParentID: makanan.ID.String(),
+    })
+
+    assert.Error(t, err)
+    assert.Equal(t, "sub-category of an expense category must also be expense", err.Error())
+}
+
+func TestUpdateCategory_ParentCycleRejected(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    parent := domain.Category{ID: uuid.New(), Name: "Hobi", UserID: &userID}
+    child := domain.Category{ID: uuid.New(), Name: "Game", UserID: &userID, ParentID: &parent.ID}
+
+    mockCatRepo.On("FindByID", parent.ID, userID).Return(&parent, nil)
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{parent, child}, nil)
+
+    childID := child.ID.String()
+    _, err := svc.Update(parent.ID.String(), userID, service.UpdateCategoryInput{ParentID: &childID})
+
+    assert.Error(t, err)
+    assert.Equal(t, "parent category cannot be one of its sub-categories", err.Error())
+    mockCatRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+// ──────────────────────────────────────────
+// DELETE CATEGORY TESTS
+// ──────────────────────────────────────────
+
+func TestDeleteCategory_InUseRequiresReassign(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockCatRepo.On("CountUsage", cat.ID, userID).Return(int64(3), int64(1), int64(0), nil)
+
+    err := svc.Delete(cat.ID.String(), userID, "")
+
+    assert.Error(t, err)
+    assert.Contains(t, err.Error(), "provide reassign_to")
+    mockCatRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteCategory_Unused(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockCatRepo.On("CountUsage", cat.ID, userID).Return(int64(0), int64(0), int64(0), nil)
+    mockCatRepo.On("Delete", cat.ID, userID).Return(nil)
+
+    err := svc.Delete(cat.ID.String(), userID, "")
+
+    assert.NoError(t, err)
+    mockCatRepo.AssertExpectations(t)
+}
+
+func TestDeleteCategory_UsedByRecurringRule(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockCatRepo.On("CountUsage", cat.ID, userID).Return(int64(0), int64(0), int64(1), nil)
+
+    err := svc.Delete(cat.ID.String(), userID, "")
+
+    assert.EqualError(t, err, "category is used by 0 transactions, 0 budgets and 1 recurring rules, provide reassign_to to move them")
+    mockCatRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteCategory_WithReassign(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID}
+    target := &domain.Category{ID: uuid.New()} // kategori sistem boleh jadi tujuan
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockCatRepo.On("FindByID", target.ID, userID).Return(target, nil)
+    mockCatRepo.On("ReassignAndDelete", cat.ID, target.ID, userID, false).Return(nil)
+
+    err := svc.Delete(cat.ID.String(), userID, target.ID.String())
+
+    assert.NoError(t, err)
+    mockCatRepo.AssertExpectations(t)
+    mockCatRepo.AssertNotCalled(t, "CountUsage", mock.Anything, mock.Anything)
+}
+
+// ──────────────────────────────────────────
+// MERGE CATEGORY TESTS
+// ──────────────────────────────────────────
+
+func TestMergeCategory_SumsBudgets(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID, Name: "Jajan"}
+    target := &domain.Category{ID: uuid.New(), UserID: &userID, Name: "Makanan"}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockCatRepo.On("FindByID", target.ID, userID).Return(target, nil)
+    mockCatRepo.On("ReassignAndDelete", cat.ID, target.ID, userID, true).Return(nil)
+
+    result, err := svc.Merge(cat.ID.String(), userID, service.MergeCategoryInput{TargetID: target.ID.String()})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "Makanan", result.Name)
+    mockCatRepo.AssertExpectations(t)
+}
+
+func TestMergeCategory_IntoItself(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+
+    _, err := svc.Merge(cat.ID.String(), userID, service.MergeCategoryInput{TargetID: cat.ID.String()})
+
+    assert.Error(t, err)
+    assert.Equal(t, "target category must be different", err.Error())
+}
+
+func TestMergeCategory_ArchivedTarget(t *testing.T) {
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewCategoryService(mockCatRepo)
+
+    userID := uuid.New()
+    cat := &domain.Category{ID: uuid.New(), UserID: &userID}
+    target := &domain.Category{ID: uuid.New(), UserID: &userID, IsArchived: true}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockCatRepo.On("FindByID", target.ID, userID).Return(target, nil)
+
+    _, err := svc.Merge(cat.ID.String(), userID, service.MergeCategoryInput{TargetID: target.ID.String()})
+
+    assert.Error(t, err)
+    assert.Equal(t, "target category is archived", err.Error())
+    mockCatRepo.AssertNotCalled(t, "ReassignAndDelete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+)
+
+// categoryTree dibangun dari semua kategori yang terlihat oleh user
+// (termasuk yang di-archive) untuk validasi hierarki dan roll-up nominal
+type categoryTree struct {
+    byID     map[uuid.UUID]domain.Category
+    children map[uuid.UUID][]uuid.UUID
+}
+
+func newCategoryTree(categories []domain.Category) *categoryTree {
+    t := &categoryTree{
+        byID:     make(map[uuid.UUID]domain.Category, len(categories)),
+        children: map[uuid.UUID][]uuid.UUID{},
+    }
+    for _, c := range categories {
+        t.byID[c.ID] = c
+        if c.ParentID != nil {
+            t.children[*c.ParentID] = append(t.children[*c.ParentID], c.ID)
+        }
+    }
+    return t
+}
+
+// ancestors return parent, kakek, dst. (tanpa id itu sendiri)
+func (t *categoryTree) ancestors(id uuid.UUID) []uuid.UUID {
+    var result []uuid.UUID
+    visited := map[uuid.UUID]bool{id: true}
+
+    current, ok := t.byID[id]
+    for ok && current.ParentID != nil && !visited[*current.ParentID] {
+        parentID := *current.ParentID
+        visited[parentID] = true
+        result = append(result, parentID)
+        current, ok = t.byID[parentID]
+    }
+    return result
+}
+
+// depth: kategori root = 1
+func (t *categoryTree) depth(id uuid.UUID) int {
+    return len(t.ancestors(id)) + 1
+}
+
+// height: jumlah level subtree termasuk dirinya sendiri (leaf = 1)
+func (t *categoryTree) height(id uuid.UUID) int {
+    max := 0
+    for _, child := range t.children[id] {
+        if h := t.height(child); h > max {
+            max = h
+        }
+    }
+    return max + 1
+}
+
+func (t *categoryTree) isAncestor(ancestorID, id uuid.UUID) bool {
+    for _, a := range t.ancestors(id) {
+        if a == ancestorID {
+            return true
+        }
+    }
+    return false
+}
+
+// rollUp menjumlahkan nominal tiap kategori ke semua parent-nya, sehingga
+// hasil untuk "Makanan" sudah termasuk "Makanan > Restoran"
+func (t *categoryTree) rollUp(amounts map[uuid.UUID]domain.Money) map[uuid.UUID]domain.Money {
+    rolled := make(map[uuid.UUID]domain.Money, len(amounts))
+    for id, amount := range amounts {
+        rolled[id] += amount
+        for _, ancestor := range t.ancestors(id) {
+            rolled[ancestor] += amount
+        }
+    }
+    return rolled
+}
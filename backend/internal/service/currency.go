@@ -0,0 +1,103 @@
+package service
+
+import (
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+// baseCurrency mengambil mata uang dasar user untuk summary dan budget
+func baseCurrency(userRepo repository.UserRepository, userID uuid.UUID) (string, error) {
+    user, err := userRepo.FindByID(userID)
+    if err != nil {
+        return "", err
+    }
+    if user.BaseCurrency == "" {
+        return domain.DefaultCurrency, nil
+    }
+    return user.BaseCurrency, nil
+}
+
+// currencyConverter mengonversi nominal memakai tabel kurs milik user.
+// Kurs per pasangan mata uang dimuat sekali lalu di-cache selama converter
+// dipakai (satu request).
+type currencyConverter struct {
+    rateRepo repository.ExchangeRateRepository
+    userID   uuid.UUID
+    rates    map[string][]domain.ExchangeRate // urut tanggal naik
+}
+
+func newCurrencyConverter(rateRepo repository.ExchangeRateRepository, userID uuid.UUID) *currencyConverter {
+    return &currencyConverter{
+        rateRepo: rateRepo,
+        userID:   userID,
+        rates:    map[string][]domain.ExchangeRate{},
+    }
+}
+
+func (c *currencyConverter) pair(from, to string) ([]domain.ExchangeRate, error) {
+    key := from + "/" + to
+    if rates, ok := c.rates[key]; ok {
+        return rates, nil
+    }
+
+    rates, err := c.rateRepo.FindAllByUser(c.userID, repository.ExchangeRateFilter{
+        FromCurrency: from,
+        ToCurrency:   to,
+    })
+    if err != nil {
+        return nil, err
+    }
+    sort.Slice(rates, func(i, j int) bool {
+        return rates[i].Date.Before(rates[j].Date)
+    })
+    c.rates[key] = rates
+    return rates, nil
+}
+
+// rateOn mengambil kurs terakhir pada atau sebelum date
+func rateOn(rates []domain.ExchangeRate, date time.Time) *domain.ExchangeRate {
+    day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+    i := sort.Search(len(rates), func(i int) bool {
+        d := rates[i].Date
+        return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC).After(day)
+    })
+    if i == 0 {
+        return nil
+    }
+    return &rates[i-1]
+}
+
+// convert memakai kurs langsung (from→to) atau kebalikannya (to→from),
+// mana yang tanggalnya paling dekat dengan tanggal transaksi
+func (c *currencyConverter) convert(amount domain.Money, from, to string, date time.Time) (domain.Money, error) {
+    if from == "" {
+        from = domain.DefaultCurrency
+    }
+    if from == to || amount == 0 {
+        return amount, nil
+    }
+
+    direct, err := c.pair(from, to)
+    if err != nil {
+        return 0, err
+    }
+    inverse, err := c.pair(to, from)
+    if err != nil {
+        return 0, err
+    }
+
+    d := rateOn(direct, date)
+    inv := rateOn(inverse, date)
+    switch {
+    case d != nil && (inv == nil || !inv.Date.After(d.Date)):
+        return amount.Convert(d.Rate), nil
+    case inv != nil:
+        return amount.Convert(1 / inv.Rate), nil
+    }
+    return 0, fmt.Errorf("no exchange rate %s to %s on or before %s", from, to, date.Format("2006-01-02"))
+}
@@ -0,0 +1,226 @@
+package service_test
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/storage"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+// idrUserRepo: user dengan base currency IDR, untuk test yang tidak
+// menyentuh multi-currency
+func idrUserRepo() *repomock.MockUserRepository {
+    repo := new(repomock.MockUserRepository)
+    repo.On("FindByID", mock.Anything).
+        Return(&domain.User{BaseCurrency: domain.DefaultCurrency}, nil).Maybe()
+    return repo
+}
+
+// noRules: user tanpa rule kategorisasi otomatis
+func noRules() *repomock.MockRuleRepository {
+    repo := new(repomock.MockRuleRepository)
+    repo.On("FindActiveByUser", mock.Anything).Return([]domain.TransactionRule{}, nil).Maybe()
+    return repo
+}
+
+func rateOn(from, to string, rate float64, date string) domain.ExchangeRate {
+    d, _ := time.Parse("2006-01-02", date)
+    return domain.ExchangeRate{ID: uuid.New(), FromCurrency: from, ToCurrency: to, Rate: rate, Date: d}
+}
+
+// ──────────────────────────────────────────
+// CONVERSION TESTS
+// ──────────────────────────────────────────
+
+func TestGetSummary_ConvertsWithRateOnTransactionDate(t *testing.T) {
+    mockTxRepo   := new(repomock.MockTransactionRepository)
+    mockRateRepo := new(repomock.MockExchangeRateRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), idrUserRepo(), mockRateRepo, new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    mockTxRepo.On("GetSummaryByUser", userID, 2, 2026).
+        Return([]repository.CurrencyTotal{
+            {Type: domain.Income, Currency: "IDR", Amount: domain.NewMoney(10000000)},
+            {Type: domain.Expense, Currency: "USD", Amount: domain.NewMoney(10),
+                Date: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+            {Type: domain.Expense, Currency: "USD", Amount: domain.NewMoney(20),
+                Date: time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)},
+        }, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "USD", ToCurrency: "IDR"}).
+        Return([]domain.ExchangeRate{
+            rateOn("USD", "IDR", 16500, "2026-02-15"),
+            rateOn("USD", "IDR", 16000, "2026-01-31"),
+        }, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "IDR", ToCurrency: "USD"}).
+        Return([]domain.ExchangeRate{}, nil)
+
+    summary, err := svc.GetSummary(userID, 2, 2026)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "IDR", summary.Currency)
+    // 10 × 16.000 + 20 × 16.500
+    assert.Equal(t, domain.NewMoney(490000), summary.Expense)
+    assert.Equal(t, domain.NewMoney(9510000), summary.Balance)
+    // Kurs per pasangan hanya dimuat sekali
+    mockRateRepo.AssertNumberOfCalls(t, "FindAllByUser", 2)
+}
+
+func TestGetSummary_UsesInverseRate(t *testing.T) {
+    mockTxRepo   := new(repomock.MockTransactionRepository)
+    mockRateRepo := new(repomock.MockExchangeRateRepository)
+    mockUserRepo := new(repomock.MockUserRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), mockUserRepo, mockRateRepo, new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    mockUserRepo.On("FindByID", userID).Return(&domain.User{ID: userID, BaseCurrency: "USD"}, nil)
+    mockTxRepo.On("GetSummaryByUser", userID, 2, 2026).
+        Return([]repository.CurrencyTotal{
+            {Type: domain.Expense, Currency: "IDR", Amount: domain.NewMoney(160000),
+                Date: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+        }, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "IDR", ToCurrency: "USD"}).
+        Return([]domain.ExchangeRate{}, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "USD", ToCurrency: "IDR"}).
+        Return([]domain.ExchangeRate{rateOn("USD", "IDR", 16000, "2026-01-01")}, nil)
+
+    summary, err := svc.GetSummary(userID, 2, 2026)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "USD", summary.Currency)
+    assert.Equal(t, domain.NewMoney(10), summary.Expense)
+}
+
+func TestGetSummary_MissingRate(t *testing.T) {
+    mockTxRepo   := new(repomock.MockTransactionRepository)
+    mockRateRepo := new(repomock.MockExchangeRateRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), idrUserRepo(), mockRateRepo, new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    mockTxRepo.On("GetSummaryByUser", userID, 2, 2026).
+        Return([]repository.CurrencyTotal{
+            {Type: domain.Expense, Currency: "USD", Amount: domain.NewMoney(10),
+                Date: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)},
+        }, nil)
+    // Kurs hanya ada setelah tanggal transaksi
+    mockRateRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.ExchangeRateFilter")).
+        Return([]domain.ExchangeRate{rateOn("USD", "IDR", 16000, "2026-02-10")}, nil)
+
+    _, err := svc.GetSummary(userID, 2, 2026)
+
+    assert.EqualError(t, err, "no exchange rate USD to IDR on or before 2026-02-03")
+}
+
+func TestCreateTransaction_CurrencyMustMatchAccount(t *testing.T) {
+    mockTxRepo      := new(repomock.MockTransactionRepository)
+    mockCatRepo     := new(repomock.MockCategoryRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo,
+        idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID  := uuid.New()
+    catID   := uuid.New()
+    account := &domain.Account{ID: uuid.New(), UserID: userID, Name: "Wise", Currency: "USD"}
+
+    mockCatRepo.On("FindByID", catID, userID).Return(&domain.Category{ID: catID}, nil)
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        AccountID:  account.ID.String(),
+        CategoryID: catID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(12),
+        Currency:   "idr",
+        Date:       "2026-02-20",
+    })
+
+    assert.EqualError(t, err, "account Wise uses USD, not IDR")
+    mockTxRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateTransfer_ConvertsBetweenCurrencies(t *testing.T) {
+    mockTransferRepo := new(repomock.MockTransferRepository)
+    mockAccountRepo  := new(repomock.MockAccountRepository)
+    mockRateRepo     := new(repomock.MockExchangeRateRepository)
+    svc := service.NewTransferService(mockTransferRepo, mockAccountRepo,
+        new(repomock.MockCategoryRepository), mockRateRepo)
+
+    userID := uuid.New()
+    bca    := &domain.Account{ID: uuid.New(), UserID: userID, Name: "BCA", Currency: "IDR"}
+    wise   := &domain.Account{ID: uuid.New(), UserID: userID, Name: "Wise", Currency: "USD"}
+
+    mockAccountRepo.On("FindByID", bca.ID, userID).Return(bca, nil)
+    mockAccountRepo.On("FindByID", wise.ID, userID).Return(wise, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "IDR", ToCurrency: "USD"}).
+        Return([]domain.ExchangeRate{}, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "USD", ToCurrency: "IDR"}).
+        Return([]domain.ExchangeRate{rateOn("USD", "IDR", 16000, "2026-02-01")}, nil)
+
+    var saved *domain.Transfer
+    mockTransferRepo.On("Create", mock.AnythingOfType("*domain.Transfer")).
+        Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.Transfer) }).
+        Return(nil)
+    mockTransferRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
+        Return(&domain.Transfer{}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransferInput{
+        FromAccountID: bca.ID.String(),
+        ToAccountID:   wise.ID.String(),
+        Amount:        domain.NewMoney(1600000),
+        Date:          "2026-02-20",
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, domain.NewMoney(100), saved.ToAmount)
+    assert.Equal(t, "IDR", saved.Legs[0].Currency)
+    assert.Equal(t, "USD", saved.Legs[1].Currency)
+    assert.Equal(t, domain.NewMoney(100), saved.Legs[1].Amount)
+}
+
+// ──────────────────────────────────────────
+// EXCHANGE RATE IMPORT TESTS
+// ──────────────────────────────────────────
+
+func TestImportRatesCSV_Success(t *testing.T) {
+    mockRateRepo := new(repomock.MockExchangeRateRepository)
+    svc := service.NewExchangeRateService(mockRateRepo, new(repomock.MockUserRepository))
+
+    userID := uuid.New()
+    csv := "Date,From,To,Rate\n2026-02-01,usd,idr,16250.5\n2026-02-02,USD,IDR,16300\n\n"
+
+    mockRateRepo.On("UpsertMany", mock.MatchedBy(func(rates []domain.ExchangeRate) bool {
+        return len(rates) == 2 && rates[0].FromCurrency == "USD" && rates[0].Rate == 16250.5
+    })).Return(nil)
+
+    result, err := svc.ImportCSV(userID, strings.NewReader(csv))
+
+    assert.NoError(t, err)
+    assert.Equal(t, 2, result.Imported)
+    mockRateRepo.AssertExpectations(t)
+}
+
+func TestImportRatesCSV_InvalidRowsRejectWholeFile(t *testing.T) {
+    mockRateRepo := new(repomock.MockExchangeRateRepository)
+    svc := service.NewExchangeRateService(mockRateRepo, new(repomock.MockUserRepository))
+
+    csv := "2026-02-01,USD,IDR,16250\n2026-02-31,USD,IDR,16300\n2026-02-03,USD,IDR,-1\n2026-02-01,USD,IDR,16000\n"
+
+    result, err := svc.ImportCSV(uuid.New(), strings.NewReader(csv))
+
+    assert.Error(t, err)
+    assert.Equal(t, 0, result.Imported)
+    assert.Len(t, result.Errors, 3)
+    assert.Equal(t, 2, result.Errors[0].Line)
+    assert.Equal(t, "duplicate of line 1", result.Errors[2].Message)
+    mockRateRepo.AssertNotCalled(t, "UpsertMany", mock.Anything)
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+const (
+    // DefaultDuplicateLookbackDays: rentang default pencarian duplikat
+    DefaultDuplicateLookbackDays = 90
+    MaxDuplicateWindowDays       = 14
+)
+
+// DuplicateQuery: Window 0 = domain.DefaultDuplicateWindow
+type DuplicateQuery struct {
+    StartDate time.Time
+    EndDate   time.Time
+    Window    time.Duration
+}
+
+// DuplicateGroup: transaksi yang kemungkinan sama, urut tanggal. Satu
+// grup bisa berisi lebih dari dua transaksi (mis. input manual, import
+// CSV dan import OFX untuk pembelian yang sama).
+type DuplicateGroup struct {
+    Type         domain.TransactionType `json:"type"`
+    Amount       domain.Money           `json:"amount"`
+    Currency     string                 `json:"currency"`
+    Transactions []domain.Transaction   `json:"transactions"`
+}
+
+// MergeDuplicatesInput: KeepID dipertahankan, DuplicateIDs dihapus
+// setelah tag, lampiran dan field kosongnya dipindah ke KeepID
+type MergeDuplicatesInput struct {
+    KeepID       string   `json:"keep_id" binding:"required"`
+    DuplicateIDs []string `json:"duplicate_ids" binding:"required,min=1"`
+}
+
+// DismissDuplicatesInput: semua pasangan di antara transaksi ini tidak
+// akan ditampilkan lagi sebagai duplikat
+type DismissDuplicatesInput struct {
+    TransactionIDs []string `json:"transaction_ids" binding:"required,min=2"`
+}
+
+type DuplicateService interface {
+    FindGroups(userID uuid.UUID, query DuplicateQuery) ([]DuplicateGroup, error)
+    Merge(userID uuid.UUID, input MergeDuplicatesInput) (*domain.Transaction, error)
+    Dismiss(userID uuid.UUID, input DismissDuplicatesInput) error
+}
+
+type duplicateService struct {
+    txRepo        repository.TransactionRepository
+    duplicateRepo repository.DuplicateRepository
+}
+
+func NewDuplicateService(txRepo repository.TransactionRepository, duplicateRepo repository.DuplicateRepository) DuplicateService {
+    return &duplicateService{txRepo, duplicateRepo}
+}
+
+func (s *duplicateService) FindGroups(userID uuid.UUID, query DuplicateQuery) ([]DuplicateGroup, error) {
+    if query.Window == 0 {
+        query.Window = domain.DefaultDuplicateWindow
+    }
+    if query.Window < 0 || query.Window > MaxDuplicateWindowDays*24*time.Hour {
+        return nil, fmt.Errorf("window_days must be between 1 and %d", MaxDuplicateWindowDays)
+    }
+
+    transactions, err := s.txRepo.FindAllByUser(userID, repository.TransactionFilter{
+        StartDate: &query.StartDate,
+        EndDate:   &query.EndDate,
+    })
+    if err != nil {
+        return nil, err
+    }
+    dismissals, err := s.duplicateRepo.FindDismissed(userID)
+    if err != nil {
+        return nil, err
+    }
+    dismissed := make(map[[2]uuid.UUID]bool, len(dismissals))
+    for _, d := range dismissals {
+        dismissed[[2]uuid.UUID{d.TransactionID, d.OtherID}] = true
+    }
+
+    return duplicateGroups(transactions, query.Window, dismissed), nil
+}
+
+// duplicateGroups menggabungkan pasangan duplikat yang saling terhubung
+// (A~B, B~C) menjadi satu grup. Transaksi diurutkan per tipe, mata uang
+// & nominal supaya hanya tetangga dalam window yang perlu dibandingkan.
+func duplicateGroups(transactions []domain.Transaction, window time.Duration, dismissed map[[2]uuid.UUID]bool) []DuplicateGroup {
+    sort.SliceStable(transactions, func(i, j int) bool {
+        a, b := &transactions[i], &transactions[j]
+        if a.Type != b.Type {
+            return a.Type < b.Type
+        }
+        if a.Currency != b.Currency {
+            return a.Currency < b.Currency
+        }
+        if a.Amount != b.Amount {
+            return a.Amount < b.Amount
+        }
+        return a.Date.Before(b.Date)
+    })
+
+    parent := make([]int, len(transactions))
+    for i := range parent {
+        parent[i] = i
+    }
+    var root func(int) int
+    root = func(i int) int {
+        if parent[i] != i {
+            parent[i] = root(parent[i])
+        }
+        return parent[i]
+    }
+
+    for i := range transactions {
+        a := &transactions[i]
+        for j := i + 1; j < len(transactions); j++ {
+            b := &transactions[j]
+            if b.Type != a.Type || b.Currency != a.Currency || b.Amount != a.Amount || b.Date.Sub(a.Date) > window {
+                break
+            }
+            if !domain.IsLikelyDuplicate(a, b, window) {
+                continue
+            }
+            pair := domain.NewDuplicateDismissal(a.UserID, a.ID, b.ID)
+            if dismissed[[2]uuid.UUID{pair.TransactionID, pair.OtherID}] {
+                continue
+            }
+            parent[root(j)] = root(i)
+        }
+    }
+
+    members := map[int][]domain.Transaction{}
+    for i := range transactions {
+        r := root(i)
+        members[r] = append(members[r], transactions[i])
+    }
+    groups := []DuplicateGroup{}
+    for _, txs := range members {
+        if len(txs) < 2 {
+            continue
+        }
+        groups = append(groups, DuplicateGroup{
+            Type:         txs[0].Type,
+            Amount:       txs[0].Amount,
+            Currency:     txs[0].Currency,
+            Transactions: txs,
+        })
+    }
+    // Grup terbaru dulu
+    sort.Slice(groups, func(i, j int) bool {
+        a, b := groups[i].Transactions, groups[j].Transactions
+        return a[len(a)-1].Date.After(b[len(b)-1].Date)
+    })
+    return groups
+}
+
+func (s *duplicateService) Merge(userID uuid.UUID, input MergeDuplicatesInput) (*domain.Transaction, error) {
+    ids, err := parseDuplicateIDs(append([]string{input.KeepID}, input.DuplicateIDs...))
+    if err != nil {
+        return nil, err
+    }
+
+    keep, err := s.mergeable(ids[0], userID)
+    if err != nil {
+        return nil, err
+    }
+    for _, id := range ids[1:] {
+        dup, err := s.mergeable(id, userID)
+        if err != nil {
+            return nil, err
+        }
+        if dup.Type != keep.Type || dup.Amount != keep.Amount || dup.Currency != keep.Currency {
+            return nil, fmt.Errorf("transaction %s has a different type or amount", id)
+        }
+
+        // Field yang kosong di transaksi yang dipertahankan diambil dari
+        // duplikatnya, termasuk FITID supaya import ulang tetap terdeteksi
+        if keep.Description == "" {
+            keep.Description = dup.Description
+        }
+        if keep.Payee == "" {
+            keep.Payee = dup.Payee
+        }
+        if keep.ExternalID == nil && dup.AccountID == keep.AccountID {
+            keep.ExternalID = dup.ExternalID
+        }
+        if keep.RecurringRuleID == nil {
+            keep.RecurringRuleID = dup.RecurringRuleID
+        }
+    }
+
+    if err := s.duplicateRepo.Merge(keep, ids[1:]); err != nil {
+        return nil, err
+    }
+    return s.txRepo.FindByID(keep.ID, userID)
+}
+
+func (s *duplicateService) mergeable(id, userID uuid.UUID) (*domain.Transaction, error) {
+    tx, err := s.txRepo.FindByID(id, userID)
+    if err != nil {
+        return nil, fmt.Errorf("transaction %s not found", id)
+    }
+    if tx.IsTransferLeg() {
+        return nil, errors.New("transfer transactions cannot be merged, delete the transfer instead")
+    }
+    return tx, nil
+}
+
+func (s *duplicateService) Dismiss(userID uuid.UUID, input DismissDuplicatesInput) error {
+    ids, err := parseDuplicateIDs(input.TransactionIDs)
+    if err != nil {
+        return err
+    }
+    for _, id := range ids {
+        if _, err := s.txRepo.FindByID(id, userID); err != nil {
+            return fmt.Errorf("transaction %s not found", id)
+        }
+    }
+
+    var dismissals []domain.DuplicateDismissal
+    for i := range ids {
+        for j := i + 1; j < len(ids); j++ {
+            dismissals = append(dismissals, domain.NewDuplicateDismissal(userID, ids[i], ids[j]))
+        }
+    }
+    return s.duplicateRepo.Dismiss(dismissals)
+}
+
+// parseDuplicateIDs: minimal dua ID berbeda
+func parseDuplicateIDs(raw []string) ([]uuid.UUID, error) {
+    seen := map[uuid.UUID]bool{}
+    ids := make([]uuid.UUID, 0, len(raw))
+    for _, s := range raw {
+        id, err := uuid.Parse(s)
+        if err != nil {
+            return nil, fmt.Errorf("invalid transaction id %q", s)
+        }
+        if seen[id] {
+            return nil, fmt.Errorf("transaction %s is listed twice", id)
+        }
+        seen[id] = true
+        ids = append(ids, id)
+    }
+    if len(ids) < 2 {
+        return nil, errors.New("at least two transactions are required")
+    }
+    return ids, nil
+}
@@ -0,0 +1,161 @@
+package service_test
+
+import (
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+func expenseOn(userID uuid.UUID, amount int64, description, date string) domain.Transaction {
+    d, _ := time.Parse("2006-01-02", date)
+    return domain.Transaction{ID: uuid.New(), UserID: userID, AccountID: uuid.New(), Type: domain.Expense,
+        Amount: domain.NewMoney(amount), Currency: "IDR", Description: description, Date: d}
+}
+
+func duplicateQuery() service.DuplicateQuery {
+    return service.DuplicateQuery{
+        StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+        EndDate:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+    }
+}
+
+// ──────────────────────────────────────────
+// DUPLICATE DETECTION TESTS
+// ──────────────────────────────────────────
+
+func TestFindDuplicates_GroupsChainedMatches(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockDupRepo := new(repomock.MockDuplicateRepository)
+    svc := service.NewDuplicateService(mockTxRepo, mockDupRepo)
+
+    userID := uuid.New()
+    manual := expenseOn(userID, 25000, "Kopi", "2026-02-01")
+    csv    := expenseOn(userID, 25000, "QRIS Kopi Kenangan", "2026-02-03")
+    ofx    := expenseOn(userID, 25000, "Kopi Kenangan Kemang", "2026-02-05")
+    other  := expenseOn(userID, 25000, "Parkir", "2026-02-01")
+    later  := expenseOn(userID, 80000, "Bensin", "2026-03-01")
+    later2 := expenseOn(userID, 80000, "bensin", "2026-03-01")
+
+    mockTxRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return([]domain.Transaction{manual, csv, ofx, other, later, later2}, nil)
+    mockDupRepo.On("FindDismissed", userID).Return([]domain.DuplicateDismissal{}, nil)
+
+    groups, err := svc.FindGroups(userID, duplicateQuery())
+
+    assert.NoError(t, err)
+    assert.Len(t, groups, 2)
+    // Grup terbaru dulu
+    assert.Equal(t, domain.NewMoney(80000), groups[0].Amount)
+    // manual~csv dan csv~ofx jadi satu grup meski manual dan ofx berselisih 4 hari
+    assert.Len(t, groups[1].Transactions, 3)
+    assert.Equal(t, manual.ID, groups[1].Transactions[0].ID)
+}
+
+func TestFindDuplicates_SkipsDismissedPairs(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockDupRepo := new(repomock.MockDuplicateRepository)
+    svc := service.NewDuplicateService(mockTxRepo, mockDupRepo)
+
+    userID := uuid.New()
+    a := expenseOn(userID, 25000, "Kopi", "2026-02-01")
+    b := expenseOn(userID, 25000, "Kopi", "2026-02-01")
+
+    mockTxRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return([]domain.Transaction{a, b}, nil)
+    mockDupRepo.On("FindDismissed", userID).
+        Return([]domain.DuplicateDismissal{domain.NewDuplicateDismissal(userID, b.ID, a.ID)}, nil)
+
+    groups, err := svc.FindGroups(userID, duplicateQuery())
+
+    assert.NoError(t, err)
+    assert.Empty(t, groups)
+}
+
+func TestFindDuplicates_WindowTooLarge(t *testing.T) {
+    svc := service.NewDuplicateService(new(repomock.MockTransactionRepository), new(repomock.MockDuplicateRepository))
+
+    query := duplicateQuery()
+    query.Window = 30 * 24 * time.Hour
+    _, err := svc.FindGroups(uuid.New(), query)
+
+    assert.EqualError(t, err, "window_days must be between 1 and 14")
+}
+
+// ──────────────────────────────────────────
+// MERGE / DISMISS TESTS
+// ──────────────────────────────────────────
+
+func TestMergeDuplicates_KeepsTransactionAndFillsBlanks(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockDupRepo := new(repomock.MockDuplicateRepository)
+    svc := service.NewDuplicateService(mockTxRepo, mockDupRepo)
+
+    userID := uuid.New()
+    keep   := expenseOn(userID, 25000, "Kopi", "2026-02-01")
+    dup    := expenseOn(userID, 25000, "QRIS Kopi Kenangan", "2026-02-02")
+    fitID  := "A1"
+    dup.Payee = "Kopi Kenangan"
+    dup.AccountID = keep.AccountID
+    dup.ExternalID = &fitID
+
+    mockTxRepo.On("FindByID", keep.ID, userID).Return(&keep, nil)
+    mockTxRepo.On("FindByID", dup.ID, userID).Return(&dup, nil)
+    mockDupRepo.On("Merge", mock.MatchedBy(func(tx *domain.Transaction) bool {
+        return tx.ID == keep.ID && tx.Description == "Kopi" && tx.Payee == "Kopi Kenangan" && *tx.ExternalID == "A1"
+    }), []uuid.UUID{dup.ID}).Return(nil)
+
+    _, err := svc.Merge(userID, service.MergeDuplicatesInput{KeepID: keep.ID.String(), DuplicateIDs: []string{dup.ID.String()}})
+
+    assert.NoError(t, err)
+    mockDupRepo.AssertExpectations(t)
+}
+
+func TestMergeDuplicates_RejectsDifferentAmount(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockDupRepo := new(repomock.MockDuplicateRepository)
+    svc := service.NewDuplicateService(mockTxRepo, mockDupRepo)
+
+    userID := uuid.New()
+    keep   := expenseOn(userID, 25000, "Kopi", "2026-02-01")
+    dup    := expenseOn(userID, 30000, "Kopi", "2026-02-01")
+    mockTxRepo.On("FindByID", keep.ID, userID).Return(&keep, nil)
+    mockTxRepo.On("FindByID", dup.ID, userID).Return(&dup, nil)
+
+    _, err := svc.Merge(userID, service.MergeDuplicatesInput{KeepID: keep.ID.String(), DuplicateIDs: []string{dup.ID.String()}})
+
+    assert.ErrorContains(t, err, "different type or amount")
+    mockDupRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+}
+
+func TestMergeDuplicates_KeepListedAsDuplicate(t *testing.T) {
+    svc := service.NewDuplicateService(new(repomock.MockTransactionRepository), new(repomock.MockDuplicateRepository))
+
+    id := uuid.New().String()
+    _, err := svc.Merge(uuid.New(), service.MergeDuplicatesInput{KeepID: id, DuplicateIDs: []string{id}})
+
+    assert.ErrorContains(t, err, "listed twice")
+}
+
+func TestDismissDuplicates_StoresEveryPair(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockDupRepo := new(repomock.MockDuplicateRepository)
+    svc := service.NewDuplicateService(mockTxRepo, mockDupRepo)
+
+    userID := uuid.New()
+    ids := []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+    mockTxRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).Return(&domain.Transaction{}, nil)
+    mockDupRepo.On("Dismiss", mock.MatchedBy(func(d []domain.DuplicateDismissal) bool {
+        return len(d) == 3 && d[0].UserID == userID
+    })).Return(nil)
+
+    err := svc.Dismiss(userID, service.DismissDuplicatesInput{TransactionIDs: ids})
+
+    assert.NoError(t, err)
+    mockDupRepo.AssertExpectations(t)
+}
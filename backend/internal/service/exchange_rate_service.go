@@ -0,0 +1,233 @@
+package service
+
+import (
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+type UpsertExchangeRateInput struct {
+    FromCurrency string  `json:"from_currency" binding:"required"`
+    ToCurrency   string  `json:"to_currency" binding:"required"`
+    Rate         float64 `json:"rate" binding:"required,gt=0"`
+    Date         string  `json:"date" binding:"required"` // format: "2006-01-02"
+}
+
+type SetBaseCurrencyInput struct {
+    BaseCurrency string `json:"base_currency" binding:"required"`
+}
+
+type ImportRowError struct {
+    Line    int    `json:"line"`
+    Message string `json:"message"`
+}
+
+// ImportRatesResult: jika ada baris yang error, tidak ada kurs yang disimpan
+type ImportRatesResult struct {
+    Imported int              `json:"imported"`
+    Errors   []ImportRowError `json:"errors"`
+}
+
+type ExchangeRateService interface {
+    GetAll(userID uuid.UUID, filter repository.ExchangeRateFilter) ([]domain.ExchangeRate, error)
+    Upsert(userID uuid.UUID, input UpsertExchangeRateInput) (*domain.ExchangeRate, error)
+    Delete(id string, userID uuid.UUID) error
+    // ImportCSV membaca kolom date,from,to,rate (header opsional)
+    ImportCSV(userID uuid.UUID, r io.Reader) (*ImportRatesResult, error)
+    GetBaseCurrency(userID uuid.UUID) (string, error)
+    SetBaseCurrency(userID uuid.UUID, input SetBaseCurrencyInput) (string, error)
+}
+
+type exchangeRateService struct {
+    rateRepo repository.ExchangeRateRepository
+    userRepo repository.UserRepository
+}
+
+func NewExchangeRateService(rateRepo repository.ExchangeRateRepository, userRepo repository.UserRepository) ExchangeRateService {
+    return &exchangeRateService{rateRepo, userRepo}
+}
+
+func (s *exchangeRateService) GetAll(userID uuid.UUID, filter repository.ExchangeRateFilter) ([]domain.ExchangeRate, error) {
+    var err error
+    if filter.FromCurrency != "" {
+        if filter.FromCurrency, err = domain.NormalizeCurrency(filter.FromCurrency); err != nil {
+            return nil, err
+        }
+    }
+    if filter.ToCurrency != "" {
+        if filter.ToCurrency, err = domain.NormalizeCurrency(filter.ToCurrency); err != nil {
+            return nil, err
+        }
+    }
+    return s.rateRepo.FindAllByUser(userID, filter)
+}
+
+func (s *exchangeRateService) Upsert(userID uuid.UUID, input UpsertExchangeRateInput) (*domain.ExchangeRate, error) {
+    rate, err := newExchangeRate(userID, input.FromCurrency, input.ToCurrency, input.Rate, input.Date)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.rateRepo.Upsert(rate); err != nil {
+        return nil, err
+    }
+    return rate, nil
+}
+
+func newExchangeRate(userID uuid.UUID, from, to string, value float64, date string) (*domain.ExchangeRate, error) {
+    from, err := domain.NormalizeCurrency(from)
+    if err != nil {
+        return nil, err
+    }
+    to, err = domain.NormalizeCurrency(to)
+    if err != nil {
+        return nil, err
+    }
+    if from == to {
+        return nil, errors.New("from and to currency must differ")
+    }
+    if value <= 0 {
+        return nil, errors.New("rate must be greater than 0")
+    }
+
+    d, err := time.Parse("2006-01-02", date)
+    if err != nil {
+        return nil, errors.New("invalid date format, use YYYY-MM-DD")
+    }
+
+    return &domain.ExchangeRate{
+        ID:           uuid.New(),
+        UserID:       userID,
+        FromCurrency: from,
+        ToCurrency:   to,
+        Rate:         value,
+        Date:         d,
+    }, nil
+}
+
+func (s *exchangeRateService) Delete(id string, userID uuid.UUID) error {
+    rateID, err := uuid.Parse(id)
+    if err != nil {
+        return errors.New("invalid exchange rate id")
+    }
+    return s.rateRepo.Delete(rateID, userID)
+}
+
+func (s *exchangeRateService) ImportCSV(userID uuid.UUID, r io.Reader) (*ImportRatesResult, error) {
+    reader := csv.NewReader(r)
+    reader.FieldsPerRecord = -1
+    reader.TrimLeadingSpace = true
+
+    records, err := reader.ReadAll()
+    if err != nil {
+        return nil, fmt.Errorf("invalid CSV file: %w", err)
+    }
+    if len(records) == 0 {
+        return nil, errors.New("CSV file is empty")
+    }
+
+    // Default urutan kolom jika file tidak punya header
+    columns := map[string]int{"date": 0, "from": 1, "to": 2, "rate": 3}
+    start := 0
+    if _, err := time.Parse("2006-01-02", strings.TrimSpace(records[0][0])); err != nil {
+        columns, err = rateColumns(records[0])
+        if err != nil {
+            return nil, err
+        }
+        start = 1
+    }
+
+    result := &ImportRatesResult{Errors: []ImportRowError{}}
+    var rates []domain.ExchangeRate
+    seen := map[string]int{}
+
+    for i := start; i < len(records); i++ {
+        line := i + 1
+        row := records[i]
+        if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+            continue // baris kosong
+        }
+
+        field := func(name string) string {
+            if idx := columns[name]; idx < len(row) {
+                return strings.TrimSpace(row[idx])
+            }
+            return ""
+        }
+
+        value, err := strconv.ParseFloat(field("rate"), 64)
+        if err != nil {
+            result.Errors = append(result.Errors, ImportRowError{line, "invalid rate"})
+            continue
+        }
+        rate, err := newExchangeRate(userID, field("from"), field("to"), value, field("date"))
+        if err != nil {
+            result.Errors = append(result.Errors, ImportRowError{line, err.Error()})
+            continue
+        }
+
+        // Baris ganda dalam satu file akan bentrok di upsert yang sama
+        key := rate.FromCurrency + rate.ToCurrency + rate.Date.Format("2006-01-02")
+        if prev, ok := seen[key]; ok {
+            result.Errors = append(result.Errors, ImportRowError{line, fmt.Sprintf("duplicate of line %d", prev)})
+            continue
+        }
+        seen[key] = line
+
+        rates = append(rates, *rate)
+    }
+
+    if len(result.Errors) > 0 {
+        return result, errors.New("CSV contains invalid rows, nothing imported")
+    }
+    if err := s.rateRepo.UpsertMany(rates); err != nil {
+        return nil, err
+    }
+
+    result.Imported = len(rates)
+    return result, nil
+}
+
+func rateColumns(header []string) (map[string]int, error) {
+    aliases := map[string]string{
+        "date": "date", "from": "from", "from_currency": "from",
+        "to": "to", "to_currency": "to", "rate": "rate",
+    }
+
+    columns := map[string]int{}
+    for i, h := range header {
+        if name, ok := aliases[strings.ToLower(strings.TrimSpace(h))]; ok {
+            columns[name] = i
+        }
+    }
+    for _, name := range []string{"date", "from", "to", "rate"} {
+        if _, ok := columns[name]; !ok {
+            return nil, fmt.Errorf("CSV header must contain date, from, to and rate columns (missing %s)", name)
+        }
+    }
+    return columns, nil
+}
+
+func (s *exchangeRateService) GetBaseCurrency(userID uuid.UUID) (string, error) {
+    return baseCurrency(s.userRepo, userID)
+}
+
+// SetBaseCurrency tidak mengonversi budget yang sudah ada; nominal budget
+// selalu dibaca dalam base currency yang sedang aktif
+func (s *exchangeRateService) SetBaseCurrency(userID uuid.UUID, input SetBaseCurrencyInput) (string, error) {
+    currency, err := domain.NormalizeCurrency(input.BaseCurrency)
+    if err != nil {
+        return "", err
+    }
+    if err := s.userRepo.UpdateBaseCurrency(userID, currency); err != nil {
+        return "", err
+    }
+    return currency, nil
+}
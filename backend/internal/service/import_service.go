@@ -0,0 +1,432 @@
+package service
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/importer"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+// MaxImportFileSize membatasi ukuran file statement yang di-upload
+const MaxImportFileSize = 5 << 20
+
+// Daftar lengkap source ada di importer.Sources()
+const (
+    ImportSourceCSV = "csv"
+    ImportSourceOFX = "ofx"
+    ImportSourceQIF = "qif"
+)
+
+var ErrImportHasInvalidRows = errors.New("some rows are invalid, fix them or import with skip_invalid")
+
+// ImportInput: DryRun hanya mengembalikan preview tanpa menyimpan apa pun.
+// Saat commit, semua baris valid disimpan dalam satu DB transaction; jika
+// ada baris invalid, commit ditolak kecuali SkipInvalid.
+type ImportInput struct {
+    Source            string
+    // AccountID kosong = akun yang cocok dengan source (mis. akun "BCA"
+    // untuk statement bca), atau akun default untuk format generik
+    AccountID         string
+    DefaultCategoryID string // dipakai baris tanpa kategori yang cocok
+    Options           json.RawMessage // "mapping": opsi khusus source (csv, qif)
+    DryRun            bool
+    SkipInvalid       bool
+    File              io.Reader
+}
+
+type ImportRow struct {
+    Row          int                    `json:"row"`
+    Date         time.Time              `json:"date"`
+    Type         domain.TransactionType `json:"type"`
+    Amount       domain.Money           `json:"amount"`
+    Description  string                 `json:"description"`
+    Payee        string                 `json:"payee"`
+    CategoryName string                 `json:"category_name"` // nama di file
+    CategoryID   *uuid.UUID             `json:"category_id"`   // hasil pencocokan
+    ExternalID   string                 `json:"external_id,omitempty"`
+    Tags         []string               `json:"tags,omitempty"`  // dari rule
+    Rules        []string               `json:"rules,omitempty"` // nama rule yang cocok
+    // Skipped: FITID sudah pernah di-import ke akun ini, atau baris yang
+    // menurut statement bukan transaksi final (pending, gagal)
+    Skipped      bool                   `json:"skipped,omitempty"`
+    Errors       []string               `json:"errors,omitempty"`
+    Warnings     []string               `json:"warnings,omitempty"`
+}
+
+type ImportResult struct {
+    Source    string      `json:"source"`
+    AccountID uuid.UUID   `json:"account_id"`
+    Currency  string      `json:"currency"` // semua baris memakai mata uang akun
+    DryRun    bool        `json:"dry_run"`
+    Valid     int         `json:"valid"`
+    Invalid   int         `json:"invalid"`
+    Skipped   int         `json:"skipped"`
+    Imported  int         `json:"imported"`
+    Rows      []ImportRow `json:"rows"`
+}
+
+type ImportService interface {
+    Import(userID uuid.UUID, input ImportInput) (*ImportResult, error)
+}
+
+type importService struct {
+    txRepo      repository.TransactionRepository
+    catRepo     repository.CategoryRepository
+    accountRepo repository.AccountRepository
+    userRepo    repository.UserRepository
+    ruleRepo    repository.RuleRepository
+}
+
+func NewImportService(
+    txRepo repository.TransactionRepository,
+    catRepo repository.CategoryRepository,
+    accountRepo repository.AccountRepository,
+    userRepo repository.UserRepository,
+    ruleRepo repository.RuleRepository,
+) ImportService {
+    return &importService{txRepo, catRepo, accountRepo, userRepo, ruleRepo}
+}
+
+func (s *importService) Import(userID uuid.UUID, input ImportInput) (*ImportResult, error) {
+    source, ok := importer.Lookup(input.Source)
+    if !ok {
+        return nil, fmt.Errorf("unsupported import source %q", input.Source)
+    }
+    parser, err := source.New(input.Options)
+    if err != nil {
+        return nil, err
+    }
+
+    account, err := s.targetAccount(userID, source, input.AccountID)
+    if err != nil {
+        return nil, err
+    }
+
+    // Kecocokan tipe kategori default dicek per baris
+    var fallback *domain.Category
+    if input.DefaultCategoryID != "" {
+        catID, err := uuid.Parse(input.DefaultCategoryID)
+        if err != nil {
+            return nil, errors.New("invalid default_category_id")
+        }
+        if fallback, err = s.catRepo.FindByID(catID, userID); err != nil {
+            return nil, errors.New("default category not found")
+        }
+        if fallback.IsArchived {
+            return nil, errors.New("default category is archived")
+        }
+    }
+
+    drafts, err := parser.Parse(input.File)
+    if err != nil {
+        return nil, err
+    }
+
+    categories, err := s.catRepo.FindAllByUser(userID, repository.CategoryFilter{})
+    if err != nil {
+        return nil, err
+    }
+    matcher := newCategoryMatcher(categories)
+    rules, err := s.ruleRepo.FindActiveByUser(userID)
+    if err != nil {
+        return nil, err
+    }
+
+    imported, err := s.importedIDs(account.ID, drafts)
+    if err != nil {
+        return nil, err
+    }
+
+    result := &ImportResult{
+        Source:    input.Source,
+        AccountID: account.ID,
+        Currency:  account.Currency,
+        DryRun:    input.DryRun,
+        Rows:      make([]ImportRow, 0, len(drafts)),
+    }
+    var transactions []domain.Transaction
+    var transactionRows []int // index result.Rows untuk tiap transactions
+    for _, d := range drafts {
+        row := ImportRow{
+            Row:          d.Row,
+            Date:         d.Date,
+            Type:         d.Type,
+            Amount:       d.Amount,
+            Description:  d.Description,
+            Payee:        d.Payee,
+            CategoryName: d.CategoryName,
+            ExternalID:   d.ExternalID,
+            Errors:       d.Errors,
+        }
+        if d.SkipReason != "" {
+            row.Skipped = true
+            row.Warnings = append(row.Warnings, d.SkipReason)
+            result.Skipped++
+            result.Rows = append(result.Rows, row)
+            continue
+        }
+        if d.ExternalID != "" {
+            if imported[d.ExternalID] {
+                row.Skipped = true
+                row.Warnings = append(row.Warnings, "already imported")
+                result.Skipped++
+                result.Rows = append(result.Rows, row)
+                continue
+            }
+            // FITID yang sama dua kali di satu file hanya diambil sekali
+            imported[d.ExternalID] = true
+        }
+        var tags []domain.Tag
+        if d.Valid() {
+            // Kategori dari file lebih diutamakan, lalu rule, lalu
+            // default_category_id
+            outcome := domain.ApplyRules(rules, &domain.Transaction{
+                AccountID:   account.ID,
+                Type:        d.Type,
+                Amount:      d.Amount,
+                Description: d.Description,
+                Payee:       d.Payee,
+            })
+            row.Rules = outcome.Rules
+            if outcome.Payee != "" {
+                row.Payee = outcome.Payee
+            }
+            tags = outcome.Tags
+            for _, tag := range tags {
+                row.Tags = append(row.Tags, tag.Name)
+            }
+
+            ruleFallback := fallback
+            if outcome.Category != nil {
+                ruleFallback = outcome.Category
+            }
+            if cat, err := matcher.match(d.CategoryName, d.Type, ruleFallback); err != nil {
+                row.Errors = append(row.Errors, err.Error())
+            } else {
+                row.CategoryID = &cat.ID
+            }
+        }
+
+        if len(row.Errors) > 0 {
+            result.Invalid++
+        } else {
+            result.Valid++
+            var externalID *string
+            if d.ExternalID != "" {
+                externalID = &d.ExternalID
+            }
+            transactions = append(transactions, domain.Transaction{
+                ID:          uuid.New(),
+                UserID:      userID,
+                CategoryID:  row.CategoryID,
+                AccountID:   account.ID,
+                Type:        row.Type,
+                Amount:      row.Amount,
+                Currency:    account.Currency,
+                Description: row.Description,
+                Payee:       row.Payee,
+                Date:        row.Date,
+                ExternalID:  externalID,
+                Tags:        tags,
+            })
+            transactionRows = append(transactionRows, len(result.Rows))
+        }
+        result.Rows = append(result.Rows, row)
+    }
+
+    if err := s.warnDuplicates(userID, transactions, transactionRows, result.Rows); err != nil {
+        return nil, err
+    }
+
+    if input.DryRun {
+        return result, nil
+    }
+    if result.Invalid > 0 && !input.SkipInvalid {
+        return result, ErrImportHasInvalidRows
+    }
+    if len(transactions) == 0 {
+        if result.Skipped > 0 && result.Invalid == 0 {
+            return result, errors.New("all rows were already imported or skipped")
+        }
+        return result, errors.New("no valid rows to import")
+    }
+    if err := s.txRepo.CreateBatch(transactions); err != nil {
+        return nil, err
+    }
+    result.Imported = len(transactions)
+    return result, nil
+}
+
+// targetAccount: tanpa account_id, statement bank/e-wallet masuk ke
+// satu-satunya akun aktif yang namanya memuat AccountHint ("BCA Tahapan"
+// untuk bca, "Gopay" untuk gopay)
+func (s *importService) targetAccount(userID uuid.UUID, source importer.Source, accountID string) (*domain.Account, error) {
+    var account *domain.Account
+    if accountID == "" && source.AccountHint != "" {
+        accounts, err := s.accountRepo.FindAllByUser(userID, false)
+        if err != nil {
+            return nil, err
+        }
+        hint := accountNameKey(source.AccountHint)
+        for i := range accounts {
+            if !strings.Contains(accountNameKey(accounts[i].Name), hint) {
+                continue
+            }
+            if account != nil {
+                return nil, fmt.Errorf("several accounts match %s, set account_id", source.AccountHint)
+            }
+            account = &accounts[i]
+        }
+        if account == nil {
+            return nil, fmt.Errorf("no account named %s found, create one or set account_id", source.AccountHint)
+        }
+    } else {
+        var err error
+        if account, err = usableAccount(s.accountRepo, s.userRepo, accountID, userID); err != nil {
+            return nil, err
+        }
+    }
+
+    if source.Currency != "" && account.Currency != source.Currency {
+        return nil, fmt.Errorf("%s statements are in %s, account %s uses %s",
+            source.Name, source.Currency, account.Name, account.Currency)
+    }
+    return account, nil
+}
+
+func accountNameKey(name string) string {
+    return strings.ToLower(strings.Join(strings.Fields(name), ""))
+}
+
+// warnDuplicates memberi warning pada baris yang kemungkinan sudah dicatat
+// manual atau lewat import lain (lihat domain.IsLikelyDuplicate). Baris
+// tetap di-import; duplikat bisa di-merge setelahnya.
+func (s *importService) warnDuplicates(userID uuid.UUID, transactions []domain.Transaction, rowIndexes []int, rows []ImportRow) error {
+    if len(transactions) == 0 {
+        return nil
+    }
+    start, end := transactions[0].Date, transactions[0].Date
+    for _, tx := range transactions {
+        if tx.Date.Before(start) {
+            start = tx.Date
+        }
+        if tx.Date.After(end) {
+            end = tx.Date
+        }
+    }
+    start = start.Add(-domain.DefaultDuplicateWindow)
+    end = end.Add(domain.DefaultDuplicateWindow)
+
+    existing, err := s.txRepo.FindAllByUser(userID, repository.TransactionFilter{StartDate: &start, EndDate: &end})
+    if err != nil {
+        return err
+    }
+    for i := range transactions {
+        for j := range existing {
+            if domain.IsLikelyDuplicate(&transactions[i], &existing[j], domain.DefaultDuplicateWindow) {
+                row := &rows[rowIndexes[i]]
+                row.Warnings = append(row.Warnings, fmt.Sprintf("possible duplicate of %q on %s",
+                    duplicateLabel(&existing[j]), existing[j].Date.Format("2006-01-02")))
+                break
+            }
+        }
+    }
+    return nil
+}
+
+func duplicateLabel(tx *domain.Transaction) string {
+    if tx.Description != "" {
+        return tx.Description
+    }
+    return tx.Payee
+}
+
+// importedIDs: FITID dari file yang sudah ada di akun tujuan
+func (s *importService) importedIDs(accountID uuid.UUID, drafts []importer.Draft) (map[string]bool, error) {
+    var ids []string
+    for _, d := range drafts {
+        if d.ExternalID != "" {
+            ids = append(ids, d.ExternalID)
+        }
+    }
+    existing := map[string]bool{}
+    if len(ids) == 0 {
+        return existing, nil
+    }
+
+    found, err := s.txRepo.FindExternalIDs(accountID, ids)
+    if err != nil {
+        return nil, err
+    }
+    for _, id := range found {
+        existing[id] = true
+    }
+    return existing, nil
+}
+
+// categoryMatcher mencocokkan nama kategori dari file (tidak
+// case-sensitive) dengan nama kategori atau path "Parent > Child". Jika
+// nama yang sama dipakai kategori sistem dan kategori user, milik user
+// yang dipilih.
+type categoryMatcher struct {
+    byName map[string]*domain.Category
+}
+
+func newCategoryMatcher(categories []domain.Category) *categoryMatcher {
+    byID := make(map[uuid.UUID]*domain.Category, len(categories))
+    for i := range categories {
+        byID[categories[i].ID] = &categories[i]
+    }
+
+    m := &categoryMatcher{byName: map[string]*domain.Category{}}
+    add := func(key string, cat *domain.Category) {
+        if existing, ok := m.byName[key]; ok && (existing.UserID != nil || cat.UserID == nil) {
+            return
+        }
+        m.byName[key] = cat
+    }
+    for i := range categories {
+        cat := &categories[i]
+        add(categoryKey(cat.Name), cat)
+        if cat.ParentID != nil {
+            if parent, ok := byID[*cat.ParentID]; ok {
+                add(categoryKey(parent.Name+">"+cat.Name), cat)
+            }
+        }
+    }
+    return m
+}
+
+func categoryKey(name string) string {
+    parts := strings.Split(name, ">")
+    for i := range parts {
+        parts[i] = strings.ToLower(strings.TrimSpace(parts[i]))
+    }
+    return strings.Join(parts, ">")
+}
+
+// match: nama yang tidak ditemukan memakai fallback (default_category_id)
+func (m *categoryMatcher) match(name string, txType domain.TransactionType, fallback *domain.Category) (*domain.Category, error) {
+    cat := fallback
+    if name != "" {
+        if found, ok := m.byName[categoryKey(name)]; ok {
+            cat = found
+        } else if fallback == nil {
+            return nil, fmt.Errorf("category %q not found", name)
+        }
+    }
+    if cat == nil {
+        return nil, errors.New("category is required, map a category column or set default_category_id")
+    }
+    if !cat.Allows(txType) {
+        return nil, errCategoryKind(cat, txType)
+    }
+    return cat, nil
+}
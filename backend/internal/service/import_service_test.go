@@ -0,0 +1,286 @@
+package service_test
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+const importCSV = "date,description,amount,category\n" +
+    "2026-02-01,Gaji,10000000,gaji\n" +
+    "2026-02-02,Nasi goreng,-25000,Makanan > Restoran\n" +
+    "2026-02-03,Parkir,-5000,\n"
+
+type importFixture struct {
+    svc         service.ImportService
+    txRepo      *repomock.MockTransactionRepository
+    catRepo     *repomock.MockCategoryRepository
+    accountRepo *repomock.MockAccountRepository
+    userID   uuid.UUID
+    account  *domain.Account
+    gaji     domain.Category
+    restoran domain.Category
+    lainnya  domain.Category
+}
+
+// newImportFixture: existing = transaksi yang sudah ada di sekitar tanggal
+// file, untuk deteksi duplikat
+func newImportFixture(existing ...domain.Transaction) *importFixture {
+    f := &importFixture{
+        txRepo:  new(repomock.MockTransactionRepository),
+        catRepo: new(repomock.MockCategoryRepository),
+        userID:  uuid.New(),
+    }
+    f.accountRepo = new(repomock.MockAccountRepository)
+    f.svc = service.NewImportService(f.txRepo, f.catRepo, f.accountRepo, idrUserRepo(), noRules())
+
+    f.account = &domain.Account{ID: uuid.New(), UserID: f.userID, Currency: "IDR", IsDefault: true}
+    f.accountRepo.On("FindDefault", f.userID).Return(f.account, nil)
+    f.txRepo.On("FindAllByUser", f.userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return(append([]domain.Transaction{}, existing...), nil).Maybe()
+
+    makanan := domain.Category{ID: uuid.New(), Name: "Makanan", Kind: domain.CategoryKindExpense}
+    f.gaji = domain.Category{ID: uuid.New(), Name: "Gaji", Kind: domain.CategoryKindIncome}
+    f.restoran = domain.Category{ID: uuid.New(), Name: "Restoran", ParentID: &makanan.ID, UserID: &f.userID, Kind: domain.CategoryKindExpense}
+    f.lainnya = domain.Category{ID: uuid.New(), Name: "Lainnya", Kind: domain.CategoryKindBoth}
+    f.catRepo.On("FindAllByUser", f.userID, repository.CategoryFilter{}).
+        Return([]domain.Category{f.gaji, makanan, f.restoran, f.lainnya}, nil)
+    return f
+}
+
+func csvInput(file string) service.ImportInput {
+    return service.ImportInput{
+        Source:  service.ImportSourceCSV,
+        Options: json.RawMessage(`{"date":"date","amount":"amount","description":"description","category":"category"}`),
+        File:    strings.NewReader(file),
+    }
+}
+
+func TestImport_DryRunMatchesCategories(t *testing.T) {
+    f := newImportFixture()
+    input := csvInput(importCSV)
+    input.DryRun = true
+
+    result, err := f.svc.Import(f.userID, input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, 2, result.Valid)
+    assert.Equal(t, 1, result.Invalid)
+    assert.Equal(t, 0, result.Imported)
+    assert.Equal(t, f.gaji.ID, *result.Rows[0].CategoryID)
+    assert.Equal(t, f.restoran.ID, *result.Rows[1].CategoryID)
+    assert.Contains(t, result.Rows[2].Errors[0], "category is required")
+    f.txRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestImport_CommitRejectsInvalidRows(t *testing.T) {
+    f := newImportFixture()
+
+    result, err := f.svc.Import(f.userID, csvInput(importCSV))
+
+    assert.ErrorIs(t, err, service.ErrImportHasInvalidRows)
+    assert.Equal(t, 1, result.Invalid)
+    f.txRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestImport_CommitWithDefaultCategory(t *testing.T) {
+    f := newImportFixture()
+    f.catRepo.On("FindByID", f.lainnya.ID, f.userID).Return(&f.lainnya, nil)
+    f.txRepo.On("CreateBatch", mock.MatchedBy(func(txs []domain.Transaction) bool {
+        return len(txs) == 3 &&
+            *txs[2].CategoryID == f.lainnya.ID &&
+            txs[2].Type == domain.Expense &&
+            txs[2].Amount == domain.NewMoney(5000) &&
+            txs[2].AccountID == f.account.ID &&
+            txs[2].Currency == "IDR"
+    })).Return(nil)
+
+    input := csvInput(importCSV)
+    input.DefaultCategoryID = f.lainnya.ID.String()
+    result, err := f.svc.Import(f.userID, input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, 3, result.Imported)
+    f.txRepo.AssertExpectations(t)
+}
+
+func TestImport_SkipInvalid(t *testing.T) {
+    f := newImportFixture()
+    f.txRepo.On("CreateBatch", mock.MatchedBy(func(txs []domain.Transaction) bool {
+        return len(txs) == 2
+    })).Return(nil)
+
+    input := csvInput(importCSV)
+    input.SkipInvalid = true
+    result, err := f.svc.Import(f.userID, input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, 2, result.Imported)
+    assert.Equal(t, 1, result.Invalid)
+}
+
+func TestImport_CategoryKindMismatch(t *testing.T) {
+    f := newImportFixture()
+    input := csvInput("date,description,amount,category\n2026-02-01,Refund,-10000,Gaji\n")
+    input.DryRun = true
+
+    result, err := f.svc.Import(f.userID, input)
+
+    assert.NoError(t, err)
+    assert.Contains(t, result.Rows[0].Errors[0], "cannot be used for expense")
+}
+
+func TestImport_UnknownSource(t *testing.T) {
+    f := newImportFixture()
+    input := csvInput(importCSV)
+    input.Source = "xls"
+
+    _, err := f.svc.Import(f.userID, input)
+
+    assert.ErrorContains(t, err, "unsupported import source")
+}
+
+// ──────────────────────────────────────────
+// OFX / QIF TESTS
+// ──────────────────────────────────────────
+
+const importOFX = `<OFX>
+<STMTTRN><DTPOSTED>20260201<TRNAMT>-25000<FITID>A1<NAME>Parkir</STMTTRN>
+<STMTTRN><DTPOSTED>20260202<TRNAMT>-30000<FITID>A2<NAME>Bensin</STMTTRN>
+<STMTTRN><DTPOSTED>20260202<TRNAMT>-30000<FITID>A2<NAME>Bensin</STMTTRN>
+</OFX>`
+
+func TestImport_OFXSkipsAlreadyImportedFITID(t *testing.T) {
+    f := newImportFixture()
+    f.catRepo.On("FindByID", f.lainnya.ID, f.userID).Return(&f.lainnya, nil)
+    f.txRepo.On("FindExternalIDs", f.account.ID, []string{"A1", "A2", "A2"}).Return([]string{"A1"}, nil)
+    f.txRepo.On("CreateBatch", mock.MatchedBy(func(txs []domain.Transaction) bool {
+        return len(txs) == 1 && *txs[0].ExternalID == "A2" && txs[0].Payee == "Bensin"
+    })).Return(nil)
+
+    result, err := f.svc.Import(f.userID, service.ImportInput{
+        Source:            service.ImportSourceOFX,
+        DefaultCategoryID: f.lainnya.ID.String(),
+        File:              strings.NewReader(importOFX),
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, 1, result.Imported)
+    // A1 sudah ada di akun, A2 kedua duplikat di file yang sama
+    assert.Equal(t, 2, result.Skipped)
+    assert.True(t, result.Rows[0].Skipped)
+    assert.True(t, result.Rows[2].Skipped)
+    f.txRepo.AssertExpectations(t)
+}
+
+func TestImport_OFXAllAlreadyImported(t *testing.T) {
+    f := newImportFixture()
+    f.catRepo.On("FindByID", f.lainnya.ID, f.userID).Return(&f.lainnya, nil)
+    f.txRepo.On("FindExternalIDs", f.account.ID, mock.Anything).Return([]string{"A1", "A2"}, nil)
+
+    _, err := f.svc.Import(f.userID, service.ImportInput{
+        Source:            service.ImportSourceOFX,
+        DefaultCategoryID: f.lainnya.ID.String(),
+        File:              strings.NewReader(importOFX),
+    })
+
+    assert.ErrorContains(t, err, "already imported")
+    f.txRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestImport_QIFMatchesSubcategoryPath(t *testing.T) {
+    f := newImportFixture()
+
+    result, err := f.svc.Import(f.userID, service.ImportInput{
+        Source: service.ImportSourceQIF,
+        DryRun: true,
+        File:   strings.NewReader("!Type:Bank\nD02/03/2026\nT-75,000.00\nPWarteg\nLMakanan:Restoran\n^\n"),
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, 1, result.Valid)
+    assert.Equal(t, f.restoran.ID, *result.Rows[0].CategoryID)
+    assert.Equal(t, domain.NewMoney(75000), result.Rows[0].Amount)
+}
+
+// ──────────────────────────────────────────
+// BANK / E-WALLET TESTS
+// ──────────────────────────────────────────
+
+const importOVO = "Tanggal,Waktu,Jenis Transaksi,Keterangan,Nominal,Status\n" +
+    "03/02/2026,08:12,Pembayaran,GRAB* A-5XYZ,Rp32.000,Berhasil\n" +
+    "04/02/2026,19:05,Pembayaran,Tokopedia,Rp150.000,Gagal\n"
+
+func TestImport_AssignsAccountFromSource(t *testing.T) {
+    f := newImportFixture()
+    f.catRepo.On("FindByID", f.lainnya.ID, f.userID).Return(&f.lainnya, nil)
+    ovo := domain.Account{ID: uuid.New(), UserID: f.userID, Name: "Dompet OVO", Currency: "IDR"}
+    f.accountRepo.On("FindAllByUser", f.userID, false).
+        Return([]domain.Account{*f.account, {ID: uuid.New(), Name: "GoPay", Currency: "IDR"}, ovo}, nil)
+    f.txRepo.On("CreateBatch", mock.MatchedBy(func(txs []domain.Transaction) bool {
+        return len(txs) == 1 && txs[0].AccountID == ovo.ID && txs[0].Payee == "GRAB* A-5XYZ"
+    })).Return(nil)
+
+    result, err := f.svc.Import(f.userID, service.ImportInput{
+        Source:            "ovo",
+        DefaultCategoryID: f.lainnya.ID.String(),
+        File:              strings.NewReader(importOVO),
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, ovo.ID, result.AccountID)
+    assert.Equal(t, 1, result.Imported)
+    // Transaksi gagal dilewati, tidak membuat commit ditolak
+    assert.Equal(t, 1, result.Skipped)
+    assert.True(t, result.Rows[1].Skipped)
+    assert.Equal(t, []string{"transaction status is Gagal"}, result.Rows[1].Warnings)
+    f.txRepo.AssertExpectations(t)
+}
+
+func TestImport_AmbiguousSourceAccount(t *testing.T) {
+    f := newImportFixture()
+    f.accountRepo.On("FindAllByUser", f.userID, false).
+        Return([]domain.Account{{Name: "OVO"}, {Name: "ovo bisnis"}}, nil)
+
+    _, err := f.svc.Import(f.userID, service.ImportInput{Source: "ovo", File: strings.NewReader(importOVO)})
+
+    assert.EqualError(t, err, "several accounts match OVO, set account_id")
+}
+
+func TestImport_SourceCurrencyMustMatchAccount(t *testing.T) {
+    f := newImportFixture()
+    wise := &domain.Account{ID: uuid.New(), UserID: f.userID, Name: "Wise", Currency: "USD"}
+    f.accountRepo.On("FindByID", wise.ID, f.userID).Return(wise, nil)
+
+    _, err := f.svc.Import(f.userID, service.ImportInput{
+        Source:    "ovo",
+        AccountID: wise.ID.String(),
+        File:      strings.NewReader(importOVO),
+    })
+
+    assert.EqualError(t, err, "ovo statements are in IDR, account Wise uses USD")
+}
+
+func TestImport_WarnsAboutPossibleDuplicates(t *testing.T) {
+    f := newImportFixture(domain.Transaction{
+        ID: uuid.New(), Type: domain.Expense, Amount: domain.NewMoney(25000), Currency: "IDR",
+        Description: "nasi goreng", Date: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC),
+    })
+    input := csvInput(importCSV)
+    input.DryRun = true
+
+    result, err := f.svc.Import(f.userID, input)
+
+    assert.NoError(t, err)
+    assert.Empty(t, result.Rows[0].Warnings)
+    assert.Equal(t, []string{`possible duplicate of "nasi goreng" on 2026-02-03`}, result.Rows[1].Warnings)
+}
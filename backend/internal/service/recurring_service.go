@@ -0,0 +1,335 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+type CreateRecurringInput struct {
+    AccountID      string       `json:"account_id"` // kosong = akun default
+    CategoryID     string       `json:"category_id" binding:"required"`
+    Type           string       `json:"type" binding:"required,oneof=income expense"`
+    Amount         domain.Money `json:"amount" binding:"required,gt=0"`
+    Description    string       `json:"description"`
+    Frequency      string       `json:"frequency" binding:"required,oneof=daily weekly monthly yearly"`
+    Interval       int          `json:"interval" binding:"omitempty,min=1,max=365"` // default 1
+    DayOfMonth     int          `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+    StartDate      string       `json:"start_date" binding:"required"` // format: "2006-01-02"
+    EndDate        string       `json:"end_date"`
+    MaxOccurrences *int         `json:"max_occurrences" binding:"omitempty,min=1"`
+}
+
+// UpdateRecurringInput: field kosong/nil tidak diubah. EndDate "" dan
+// MaxOccurrences 0 menghapus batasnya.
+type UpdateRecurringInput struct {
+    AccountID      string       `json:"account_id"`
+    CategoryID     string       `json:"category_id"`
+    Type           string       `json:"type" binding:"omitempty,oneof=income expense"`
+    Amount         domain.Money `json:"amount" binding:"omitempty,gt=0"`
+    Description    string       `json:"description"`
+    Frequency      string       `json:"frequency" binding:"omitempty,oneof=daily weekly monthly yearly"`
+    Interval       int          `json:"interval" binding:"omitempty,min=1,max=365"`
+    DayOfMonth     int          `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+    StartDate      string       `json:"start_date"`
+    EndDate        *string      `json:"end_date"`
+    MaxOccurrences *int         `json:"max_occurrences" binding:"omitempty,min=0"`
+}
+
+type RecurringService interface {
+    GetAll(userID uuid.UUID) ([]domain.RecurringRule, error)
+    GetByID(id string, userID uuid.UUID) (*domain.RecurringRule, error)
+    Create(userID uuid.UUID, input CreateRecurringInput) (*domain.RecurringRule, error)
+    Update(id string, userID uuid.UUID, input UpdateRecurringInput) (*domain.RecurringRule, error)
+    Delete(id string, userID uuid.UUID) error
+    Pause(id string, userID uuid.UUID) (*domain.RecurringRule, error)
+    // Resume melewati occurrence yang terlewat selama pause
+    Resume(id string, userID uuid.UUID) (*domain.RecurringRule, error)
+    // Skip melewati occurrence berikutnya tanpa membuat transaksi
+    Skip(id string, userID uuid.UUID) (*domain.RecurringRule, error)
+    // RunDue membuat transaksi untuk semua rule yang jatuh tempo, dipanggil scheduler
+    RunDue(now time.Time) (int, error)
+}
+
+type recurringService struct {
+    recurringRepo repository.RecurringRepository
+    catRepo       repository.CategoryRepository
+    accountRepo   repository.AccountRepository
+    userRepo      repository.UserRepository
+}
+
+func NewRecurringService(
+    recurringRepo repository.RecurringRepository,
+    catRepo repository.CategoryRepository,
+    accountRepo repository.AccountRepository,
+    userRepo repository.UserRepository,
+) RecurringService {
+    return &recurringService{recurringRepo, catRepo, accountRepo, userRepo}
+}
+
+func (s *recurringService) GetAll(userID uuid.UUID) ([]domain.RecurringRule, error) {
+    return s.recurringRepo.FindAllByUser(userID)
+}
+
+func (s *recurringService) GetByID(id string, userID uuid.UUID) (*domain.RecurringRule, error) {
+    ruleID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid recurring rule id")
+    }
+    rule, err := s.recurringRepo.FindByID(ruleID, userID)
+    if err != nil {
+        return nil, errors.New("recurring rule not found")
+    }
+    return rule, nil
+}
+
+func (s *recurringService) Create(userID uuid.UUID, input CreateRecurringInput) (*domain.RecurringRule, error) {
+    txType := domain.TransactionType(input.Type)
+    cat, err := usableCategory(s.catRepo, input.CategoryID, userID, txType)
+    if err != nil {
+        return nil, err
+    }
+    account, err := usableAccount(s.accountRepo, s.userRepo, input.AccountID, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    start, err := time.Parse("2006-01-02", input.StartDate)
+    if err != nil {
+        return nil, errors.New("invalid start_date format, use YYYY-MM-DD")
+    }
+
+    rule := &domain.RecurringRule{
+        ID:             uuid.New(),
+        UserID:         userID,
+        AccountID:      account.ID,
+        CategoryID:     cat.ID,
+        Type:           txType,
+        Amount:         input.Amount,
+        Description:    input.Description,
+        Frequency:      domain.RecurrenceFrequency(input.Frequency),
+        Interval:       input.Interval,
+        DayOfMonth:     input.DayOfMonth,
+        StartDate:      start,
+        MaxOccurrences: input.MaxOccurrences,
+    }
+    if rule.Interval == 0 {
+        rule.Interval = 1
+    }
+    if input.EndDate != "" {
+        if rule.EndDate, err = parseEndDate(input.EndDate, start); err != nil {
+            return nil, err
+        }
+    }
+
+    fixDayOfMonth(rule, start)
+    // Start date di masa lalu akan di-backfill oleh scheduler
+    rule.Reschedule(start)
+
+    if err := s.recurringRepo.Create(rule); err != nil {
+        return nil, err
+    }
+    return s.recurringRepo.FindByID(rule.ID, userID)
+}
+
+func parseEndDate(value string, start time.Time) (*time.Time, error) {
+    end, err := time.Parse("2006-01-02", value)
+    if err != nil {
+        return nil, errors.New("invalid end_date format, use YYYY-MM-DD")
+    }
+    if end.Before(start) {
+        return nil, errors.New("end_date must not be before start_date")
+    }
+    return &end, nil
+}
+
+// fixDayOfMonth: monthly/yearly tanpa day_of_month memakai tanggal mulai,
+// disimpan eksplisit supaya tidak ikut bergeser setelah tanggal di-clamp
+// (mis. mulai 31 Jan → 28 Feb → tetap 31 Mar)
+func fixDayOfMonth(rule *domain.RecurringRule, start time.Time) {
+    switch rule.Frequency {
+    case domain.FrequencyMonthly, domain.FrequencyYearly:
+        if rule.DayOfMonth == 0 {
+            rule.DayOfMonth = start.Day()
+        }
+    default:
+        rule.DayOfMonth = 0
+    }
+}
+
+func (s *recurringService) Update(id string, userID uuid.UUID, input UpdateRecurringInput) (*domain.RecurringRule, error) {
+    existing, err := s.GetByID(id, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    return s.recurringRepo.Modify(existing.ID, userID, func(rule *domain.RecurringRule) error {
+        if input.Type != "" || input.CategoryID != "" {
+            txType := rule.Type
+            if input.Type != "" {
+                txType = domain.TransactionType(input.Type)
+            }
+            categoryID := input.CategoryID
+            if categoryID == "" {
+                categoryID = rule.CategoryID.String()
+            }
+            cat, err := usableCategory(s.catRepo, categoryID, userID, txType)
+            if err != nil {
+                return err
+            }
+            rule.Type = txType
+            rule.CategoryID = cat.ID
+            rule.Category = domain.Category{}
+        }
+        if input.AccountID != "" && input.AccountID != rule.AccountID.String() {
+            account, err := usableAccount(s.accountRepo, s.userRepo, input.AccountID, userID)
+            if err != nil {
+                return err
+            }
+            rule.AccountID = account.ID
+            rule.Account = domain.Account{}
+        }
+        if input.Amount > 0 {
+            rule.Amount = input.Amount
+        }
+        if input.Description != "" {
+            rule.Description = input.Description
+        }
+
+        // Perubahan pola jadwal menghitung ulang occurrence berikutnya. Tanpa
+        // start_date baru, jadwal baru dimulai dari occurrence yang belum dibuat
+        // sehingga transaksi lama tidak dibuat ulang.
+        rescheduleFrom := rule.NextRunDate
+        reschedule := false
+        if input.Frequency != "" && domain.RecurrenceFrequency(input.Frequency) != rule.Frequency {
+            rule.Frequency = domain.RecurrenceFrequency(input.Frequency)
+            rule.DayOfMonth = 0
+            reschedule = true
+        }
+        if input.Interval > 0 && input.Interval != rule.Interval {
+            rule.Interval = input.Interval
+            reschedule = true
+        }
+        if input.DayOfMonth > 0 && input.DayOfMonth != rule.DayOfMonth {
+            rule.DayOfMonth = input.DayOfMonth
+            reschedule = true
+        }
+        if input.StartDate != "" {
+            start, err := time.Parse("2006-01-02", input.StartDate)
+            if err != nil {
+                return errors.New("invalid start_date format, use YYYY-MM-DD")
+            }
+            rule.StartDate = start
+            rescheduleFrom = start
+            reschedule = true
+        }
+
+        if input.EndDate != nil {
+            rule.EndDate = nil
+            if *input.EndDate != "" {
+                end, err := parseEndDate(*input.EndDate, rule.StartDate)
+                if err != nil {
+                    return err
+                }
+                rule.EndDate = end
+            }
+        }
+        if input.MaxOccurrences != nil {
+            rule.MaxOccurrences = nil
+            if *input.MaxOccurrences > 0 {
+                rule.MaxOccurrences = input.MaxOccurrences
+            }
+        }
+
+        if reschedule {
+            fixDayOfMonth(rule, rescheduleFrom)
+            rule.Reschedule(rescheduleFrom)
+        } else {
+            rule.CheckFinished()
+        }
+        return nil
+    })
+}
+
+func (s *recurringService) Delete(id string, userID uuid.UUID) error {
+    rule, err := s.GetByID(id, userID)
+    if err != nil {
+        return err
+    }
+    return s.recurringRepo.Delete(rule.ID, userID)
+}
+
+func (s *recurringService) Pause(id string, userID uuid.UUID) (*domain.RecurringRule, error) {
+    rule, err := s.GetByID(id, userID)
+    if err != nil {
+        return nil, err
+    }
+    return s.modifyActive(rule.ID, userID, func(rule *domain.RecurringRule) {
+        rule.IsPaused = true
+    })
+}
+
+func (s *recurringService) Resume(id string, userID uuid.UUID) (*domain.RecurringRule, error) {
+    rule, err := s.GetByID(id, userID)
+    if err != nil {
+        return nil, err
+    }
+    // Scheduler mem-pause rule yang kategorinya di-archive
+    if rule.Category.IsArchived {
+        return nil, errors.New("category is archived, change the rule's category first")
+    }
+
+    return s.modifyActive(rule.ID, userID, func(rule *domain.RecurringRule) {
+        rule.IsPaused = false
+        today := domain.DateOnly(time.Now())
+        for !rule.IsFinished && rule.NextRunDate.Before(today) {
+            rule.Advance()
+        }
+    })
+}
+
+func (s *recurringService) Skip(id string, userID uuid.UUID) (*domain.RecurringRule, error) {
+    rule, err := s.GetByID(id, userID)
+    if err != nil {
+        return nil, err
+    }
+    return s.modifyActive(rule.ID, userID, func(rule *domain.RecurringRule) {
+        rule.Advance()
+    })
+}
+
+// modifyActive menjalankan fn pada rule yang terkunci; rule yang sudah
+// selesai (juga jika baru selesai oleh scheduler) ditolak
+func (s *recurringService) modifyActive(id, userID uuid.UUID, fn func(rule *domain.RecurringRule)) (*domain.RecurringRule, error) {
+    return s.recurringRepo.Modify(id, userID, func(rule *domain.RecurringRule) error {
+        if rule.IsFinished {
+            return errors.New("recurring rule is finished")
+        }
+        fn(rule)
+        return nil
+    })
+}
+
+// RunDue tetap memproses rule lain jika satu rule gagal
+func (s *recurringService) RunDue(now time.Time) (int, error) {
+    ids, err := s.recurringRepo.FindDue(now)
+    if err != nil {
+        return 0, err
+    }
+
+    created := 0
+    var errs []error
+    for _, id := range ids {
+        n, err := s.recurringRepo.Materialize(id, now)
+        if err != nil {
+            errs = append(errs, fmt.Errorf("recurring rule %s: %w", id, err))
+            continue
+        }
+        created += n
+    }
+    return created, errors.Join(errs...)
+}
@@ -0,0 +1,284 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+func newRecurringService() (service.RecurringService, *repomock.MockRecurringRepository, *repomock.MockCategoryRepository, *repomock.MockAccountRepository) {
+    mockRecurringRepo := new(repomock.MockRecurringRepository)
+    mockCatRepo       := new(repomock.MockCategoryRepository)
+    mockAccountRepo   := new(repomock.MockAccountRepository)
+    svc := service.NewRecurringService(mockRecurringRepo, mockCatRepo, mockAccountRepo, idrUserRepo())
+    return svc, mockRecurringRepo, mockCatRepo, mockAccountRepo
+}
+
+// ──────────────────────────────────────────
+// CREATE RECURRING TESTS
+// ──────────────────────────────────────────
+
+func TestCreateRecurring_SchedulesFirstOccurrence(t *testing.T) {
+    svc, mockRecurringRepo, mockCatRepo, mockAccountRepo := newRecurringService()
+
+    userID  := uuid.New()
+    cat     := &domain.Category{ID: uuid.New(), Name: "Sewa", Kind: domain.CategoryKindExpense}
+    account := &domain.Account{ID: uuid.New(), UserID: userID, Name: "BCA", Currency: "IDR"}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+
+    var saved *domain.RecurringRule
+    mockRecurringRepo.On("Create", mock.AnythingOfType("*domain.RecurringRule")).
+        Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.RecurringRule) }).
+        Return(nil)
+    mockRecurringRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
+        Return(&domain.RecurringRule{ID: uuid.New()}, nil)
+
+    _, err := svc.Create(userID, service.CreateRecurringInput{
+        AccountID:  account.ID.String(),
+        CategoryID: cat.ID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(2500000),
+        Frequency:  "monthly",
+        StartDate:  "2026-01-31",
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, 1, saved.Interval)
+    assert.Equal(t, 31, saved.DayOfMonth) // dikunci dari start_date
+    assert.Equal(t, "2026-01-31", saved.NextRunDate.Format("2006-01-02"))
+    assert.False(t, saved.IsFinished)
+}
+
+func TestCreateRecurring_RejectsEndBeforeStart(t *testing.T) {
+    svc, mockRecurringRepo, mockCatRepo, mockAccountRepo := newRecurringService()
+
+    userID  := uuid.New()
+    cat     := &domain.Category{ID: uuid.New(), Name: "Gaji", Kind: domain.CategoryKindIncome}
+    account := &domain.Account{ID: uuid.New(), UserID: userID, Name: "BCA"}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockAccountRepo.On("FindByID", account.ID, userID).Return(account, nil)
+
+    _, err := svc.Create(userID, service.CreateRecurringInput{
+        AccountID:  account.ID.String(),
+        CategoryID: cat.ID.String(),
+        Type:       "income",
+        Amount:     domain.NewMoney(8000000),
+        Frequency:  "monthly",
+        StartDate:  "2026-03-25",
+        EndDate:    "2026-01-25",
+    })
+
+    assert.EqualError(t, err, "end_date must not be before start_date")
+    mockRecurringRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateRecurring_RejectsCategoryOfOtherKind(t *testing.T) {
+    svc, mockRecurringRepo, mockCatRepo, _ := newRecurringService()
+
+    userID := uuid.New()
+    cat    := &domain.Category{ID: uuid.New(), Name: "Gaji", Kind: domain.CategoryKindIncome}
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+
+    _, err := svc.Create(userID, service.CreateRecurringInput{
+        CategoryID: cat.ID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(50000),
+        Frequency:  "weekly",
+        StartDate:  "2026-03-02",
+    })
+
+    assert.Error(t, err)
+    mockRecurringRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// ──────────────────────────────────────────
+// UPDATE / SKIP / RESUME TESTS
+// ──────────────────────────────────────────
+
+func monthlyRule(userID uuid.UUID, start string) *domain.RecurringRule {
+    d, _ := time.Parse("2006-01-02", start)
+    rule := &domain.RecurringRule{
+        ID:         uuid.New(),
+        UserID:     userID,
+        Frequency:  domain.FrequencyMonthly,
+        Interval:   1,
+        DayOfMonth: d.Day(),
+        StartDate:  d,
+    }
+    rule.Reschedule(d)
+    return rule
+}
+
+func TestUpdateRecurring_ScheduleChangeStartsFromNextOccurrence(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    rule   := monthlyRule(userID, "2026-01-25")
+    rule.Materialized() // Januari sudah dibuat, berikutnya 25 Feb
+
+    mockRecurringRepo.On("FindByID", rule.ID, userID).Return(rule, nil)
+    mockRecurringRepo.On("Modify", rule.ID, userID).Return(rule, nil)
+
+    _, err := svc.Update(rule.ID.String(), userID, service.UpdateRecurringInput{DayOfMonth: 1})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "2026-03-01", rule.NextRunDate.Format("2006-01-02"))
+    assert.Equal(t, 1, rule.OccurrenceCount)
+}
+
+func TestUpdateRecurring_LowerMaxOccurrencesFinishesRule(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    rule   := monthlyRule(userID, "2026-01-25")
+    rule.Materialized()
+    rule.Materialized()
+
+    mockRecurringRepo.On("FindByID", rule.ID, userID).Return(rule, nil)
+    mockRecurringRepo.On("Modify", rule.ID, userID).Return(rule, nil)
+
+    max := 2
+    _, err := svc.Update(rule.ID.String(), userID, service.UpdateRecurringInput{MaxOccurrences: &max})
+
+    assert.NoError(t, err)
+    assert.True(t, rule.IsFinished)
+}
+
+func TestSkipRecurring_AdvancesWithoutTransaction(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    rule   := monthlyRule(userID, "2026-01-25")
+
+    mockRecurringRepo.On("FindByID", rule.ID, userID).Return(rule, nil)
+    mockRecurringRepo.On("Modify", rule.ID, userID).Return(rule, nil)
+
+    skipped, err := svc.Skip(rule.ID.String(), userID)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "2026-02-25", skipped.NextRunDate.Format("2006-01-02"))
+    assert.Equal(t, 0, skipped.OccurrenceCount)
+    mockRecurringRepo.AssertNotCalled(t, "Materialize", mock.Anything, mock.Anything)
+}
+
+func TestSkipRecurring_FinishedRule(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    rule   := monthlyRule(userID, "2026-01-25")
+    rule.IsFinished = true
+    mockRecurringRepo.On("FindByID", rule.ID, userID).Return(rule, nil)
+    mockRecurringRepo.On("Modify", rule.ID, userID).Return(rule, nil)
+
+    _, err := svc.Skip(rule.ID.String(), userID)
+
+    assert.EqualError(t, err, "recurring rule is finished")
+}
+
+func TestSkipRecurring_AppliesToLockedRow(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    stale  := monthlyRule(userID, "2026-01-25")
+    // Scheduler sudah membuat occurrence Januari setelah rule dibaca
+    fresh := monthlyRule(userID, "2026-01-25")
+    fresh.ID = stale.ID
+    fresh.Materialized()
+
+    mockRecurringRepo.On("FindByID", stale.ID, userID).Return(stale, nil)
+    mockRecurringRepo.On("Modify", stale.ID, userID).Return(fresh, nil)
+
+    skipped, err := svc.Skip(stale.ID.String(), userID)
+
+    assert.NoError(t, err)
+    assert.Equal(t, "2026-03-25", skipped.NextRunDate.Format("2006-01-02"))
+    assert.Equal(t, 1, skipped.OccurrenceCount)
+}
+
+func TestResumeRecurring_ArchivedCategory(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    rule   := monthlyRule(userID, "2026-01-25")
+    rule.IsPaused = true
+    rule.Category = domain.Category{ID: uuid.New(), Name: "Sewa", IsArchived: true}
+    mockRecurringRepo.On("FindByID", rule.ID, userID).Return(rule, nil)
+
+    _, err := svc.Resume(rule.ID.String(), userID)
+
+    assert.EqualError(t, err, "category is archived, change the rule's category first")
+    mockRecurringRepo.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+}
+
+func TestResumeRecurring_SkipsOccurrencesMissedWhilePaused(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    rule   := &domain.RecurringRule{ID: uuid.New(), UserID: userID, Frequency: domain.FrequencyDaily, Interval: 1}
+    rule.Reschedule(time.Now().AddDate(0, 0, -10))
+    rule.IsPaused = true
+
+    mockRecurringRepo.On("FindByID", rule.ID, userID).Return(rule, nil)
+    mockRecurringRepo.On("Modify", rule.ID, userID).Return(rule, nil)
+
+    resumed, err := svc.Resume(rule.ID.String(), userID)
+
+    assert.NoError(t, err)
+    assert.False(t, resumed.IsPaused)
+    assert.Equal(t, domain.DateOnly(time.Now()), resumed.NextRunDate)
+    assert.Equal(t, 0, resumed.OccurrenceCount)
+}
+
+func TestResumeRecurring_MissedOccurrencesKeepMaxOccurrences(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    userID := uuid.New()
+    max    := 3
+    rule   := &domain.RecurringRule{ID: uuid.New(), UserID: userID, Frequency: domain.FrequencyDaily, Interval: 1, MaxOccurrences: &max}
+    rule.Reschedule(time.Now().AddDate(0, 0, -10))
+    rule.Materialized()
+    rule.IsPaused = true
+
+    mockRecurringRepo.On("FindByID", rule.ID, userID).Return(rule, nil)
+    mockRecurringRepo.On("Modify", rule.ID, userID).Return(rule, nil)
+
+    resumed, err := svc.Resume(rule.ID.String(), userID)
+
+    assert.NoError(t, err)
+    // Occurrence yang terlewat selama pause tidak memakai jatah max_occurrences
+    assert.False(t, resumed.IsFinished)
+    assert.Equal(t, 1, resumed.OccurrenceCount)
+    assert.Equal(t, domain.DateOnly(time.Now()), resumed.NextRunDate)
+}
+
+// ──────────────────────────────────────────
+// RUN DUE TESTS
+// ──────────────────────────────────────────
+
+func TestRunDue_ContinuesAfterFailedRule(t *testing.T) {
+    svc, mockRecurringRepo, _, _ := newRecurringService()
+
+    now    := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+    failed := uuid.New()
+    ok     := uuid.New()
+
+    mockRecurringRepo.On("FindDue", now).Return([]uuid.UUID{failed, ok}, nil)
+    mockRecurringRepo.On("Materialize", failed, now).Return(0, errors.New("account not found"))
+    mockRecurringRepo.On("Materialize", ok, now).Return(2, nil)
+
+    created, err := svc.RunDue(now)
+
+    assert.Equal(t, 2, created)
+    assert.ErrorContains(t, err, failed.String())
+    mockRecurringRepo.AssertExpectations(t)
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+// MaxRuleTestSamples membatasi contoh transaksi di hasil test rule
+const MaxRuleTestSamples = 50
+
+// RuleInput dipakai untuk create, update (semua field diganti) dan test
+type RuleInput struct {
+    Name                string        `json:"name" binding:"required,max=100"`
+    Priority            int           `json:"priority"`
+    IsActive            *bool         `json:"is_active"` // nil = aktif
+    DescriptionContains string        `json:"description_contains" binding:"max=255"`
+    DescriptionRegex    string        `json:"description_regex"`
+    MinAmount           *domain.Money `json:"min_amount"`
+    MaxAmount           *domain.Money `json:"max_amount"`
+    AccountID           string        `json:"account_id"`
+    Type                string        `json:"type" binding:"omitempty,oneof=income expense"`
+    CategoryID          string        `json:"category_id"`
+    Tags                []string      `json:"tags"` // nama tag, yang belum ada otomatis dibuat
+    SetPayee            string        `json:"set_payee" binding:"max=255"`
+}
+
+// RuleTestResult: transaksi dalam rentang yang cocok dengan rule; Changed
+// = yang kategori/payee/tag-nya akan berubah jika rule ini di-apply
+type RuleTestResult struct {
+    Matched      int                  `json:"matched"`
+    Changed      int                  `json:"changed"`
+    Transactions []domain.Transaction `json:"transactions"` // maks. MaxRuleTestSamples
+}
+
+// ApplyRulesInput: RuleIDs kosong = semua rule aktif
+type ApplyRulesInput struct {
+    StartDate string   `json:"start_date" binding:"required"`
+    EndDate   string   `json:"end_date" binding:"required"`
+    RuleIDs   []string `json:"rule_ids"`
+    DryRun    bool     `json:"dry_run"`
+}
+
+type ApplyRulesResult struct {
+    DryRun  bool `json:"dry_run"`
+    Matched int  `json:"matched"`
+    Updated int  `json:"updated"`
+}
+
+type RuleService interface {
+    GetAll(userID uuid.UUID) ([]domain.TransactionRule, error)
+    Create(userID uuid.UUID, input RuleInput) (*domain.TransactionRule, error)
+    Update(id string, userID uuid.UUID, input RuleInput) (*domain.TransactionRule, error)
+    Delete(id string, userID uuid.UUID) error
+    // Test menjalankan rule (belum disimpan) terhadap histori transaksi
+    Test(userID uuid.UUID, input RuleInput, start, end time.Time) (*RuleTestResult, error)
+    // Apply menerapkan rule ke transaksi yang sudah ada dalam rentang tanggal
+    Apply(userID uuid.UUID, input ApplyRulesInput) (*ApplyRulesResult, error)
+}
+
+type ruleService struct {
+    ruleRepo    repository.RuleRepository
+    txRepo      repository.TransactionRepository
+    catRepo     repository.CategoryRepository
+    accountRepo repository.AccountRepository
+    tagRepo     repository.TagRepository
+}
+
+func NewRuleService(
+    ruleRepo repository.RuleRepository,
+    txRepo repository.TransactionRepository,
+    catRepo repository.CategoryRepository,
+    accountRepo repository.AccountRepository,
+    tagRepo repository.TagRepository,
+) RuleService {
+    return &ruleService{ruleRepo, txRepo, catRepo, accountRepo, tagRepo}
+}
+
+func (s *ruleService) GetAll(userID uuid.UUID) ([]domain.TransactionRule, error) {
+    return s.ruleRepo.FindAllByUser(userID)
+}
+
+func (s *ruleService) Create(userID uuid.UUID, input RuleInput) (*domain.TransactionRule, error) {
+    rule := &domain.TransactionRule{ID: uuid.New(), UserID: userID}
+    if err := s.fill(rule, userID, input, s.resolveTags); err != nil {
+        return nil, err
+    }
+    if err := s.ruleRepo.Create(rule); err != nil {
+        return nil, err
+    }
+    return s.ruleRepo.FindByID(rule.ID, userID)
+}
+
+func (s *ruleService) Update(id string, userID uuid.UUID, input RuleInput) (*domain.TransactionRule, error) {
+    rule, err := s.findRule(id, userID)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.fill(rule, userID, input, s.resolveTags); err != nil {
+        return nil, err
+    }
+    if err := s.ruleRepo.Update(rule); err != nil {
+        return nil, err
+    }
+    return s.ruleRepo.FindByID(rule.ID, userID)
+}
+
+func (s *ruleService) Delete(id string, userID uuid.UUID) error {
+    rule, err := s.findRule(id, userID)
+    if err != nil {
+        return err
+    }
+    return s.ruleRepo.Delete(rule.ID, userID)
+}
+
+func (s *ruleService) findRule(id string, userID uuid.UUID) (*domain.TransactionRule, error) {
+    ruleID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid rule ID")
+    }
+    rule, err := s.ruleRepo.FindByID(ruleID, userID)
+    if err != nil {
+        return nil, errors.New("rule not found")
+    }
+    return rule, nil
+}
+
+// fill memvalidasi input lalu mengisi rule; akun, kategori dan tag harus
+// milik user
+func (s *ruleService) fill(rule *domain.TransactionRule, userID uuid.UUID, input RuleInput,
+    tagsOf func(uuid.UUID, []string) ([]domain.Tag, error)) error {
+    rule.Name = strings.TrimSpace(input.Name)
+    if rule.Name == "" {
+        return errors.New("name is required")
+    }
+    rule.Priority = input.Priority
+    rule.IsActive = input.IsActive == nil || *input.IsActive
+    rule.DescriptionContains = strings.TrimSpace(input.DescriptionContains)
+    rule.DescriptionRegex = input.DescriptionRegex
+    rule.MinAmount = input.MinAmount
+    rule.MaxAmount = input.MaxAmount
+    rule.Type = domain.TransactionType(input.Type)
+    rule.SetPayee = strings.TrimSpace(input.SetPayee)
+
+    rule.AccountID = nil
+    if input.AccountID != "" {
+        accountID, err := uuid.Parse(input.AccountID)
+        if err != nil {
+            return errors.New("invalid account_id")
+        }
+        if _, err := s.accountRepo.FindByID(accountID, userID); err != nil {
+            return errors.New("account not found")
+        }
+        rule.AccountID = &accountID
+    }
+
+    rule.CategoryID, rule.Category = nil, nil
+    if input.CategoryID != "" {
+        catID, err := uuid.Parse(input.CategoryID)
+        if err != nil {
+            return errors.New("invalid category_id")
+        }
+        cat, err := s.catRepo.FindByID(catID, userID)
+        if err != nil {
+            return errors.New("category not found")
+        }
+        if cat.IsArchived {
+            return errors.New("category is archived")
+        }
+        if rule.Type != "" && !cat.Allows(rule.Type) {
+            return errCategoryKind(cat, rule.Type)
+        }
+        rule.CategoryID = &cat.ID
+        rule.Category = cat
+    }
+
+    tags, err := tagsOf(userID, input.Tags)
+    if err != nil {
+        return err
+    }
+    rule.Tags = tags
+
+    return rule.Validate()
+}
+
+func (s *ruleService) resolveTags(userID uuid.UUID, names []string) ([]domain.Tag, error) {
+    return resolveTags(s.tagRepo, userID, names)
+}
+
+// previewTags seperti resolveTags tapi tidak membuat tag baru, untuk Test
+func (s *ruleService) previewTags(userID uuid.UUID, names []string) ([]domain.Tag, error) {
+    var tags []domain.Tag
+    for _, raw := range names {
+        name, err := domain.NormalizeTagName(raw)
+        if err != nil {
+            return nil, fmt.Errorf("tag %q: %w", raw, err)
+        }
+        tag, err := s.tagRepo.FindByName(name, userID)
+        if err != nil {
+            tag = &domain.Tag{ID: uuid.New(), UserID: userID, Name: name}
+        }
+        tags = append(tags, *tag)
+    }
+    return tags, nil
+}
+
+func (s *ruleService) Test(userID uuid.UUID, input RuleInput, start, end time.Time) (*RuleTestResult, error) {
+    rule := &domain.TransactionRule{ID: uuid.New(), UserID: userID}
+    if err := s.fill(rule, userID, input, s.previewTags); err != nil {
+        return nil, err
+    }
+    rule.IsActive = true
+
+    transactions, err := s.txRepo.FindAllByUser(userID, repository.TransactionFilter{StartDate: &start, EndDate: &end})
+    if err != nil {
+        return nil, err
+    }
+
+    rules := []domain.TransactionRule{*rule}
+    result := &RuleTestResult{Transactions: []domain.Transaction{}}
+    for i := range transactions {
+        tx := &transactions[i]
+        if !rule.Matches(tx) {
+            continue
+        }
+        result.Matched++
+        outcome := domain.ApplyRules(rules, tx)
+        if applyOutcome(tx, outcome) {
+            result.Changed++
+        }
+        // Contoh ditampilkan dalam kondisi setelah rule diterapkan
+        if outcome.Category != nil && len(tx.Splits) == 0 {
+            tx.Category = *outcome.Category
+        }
+        if len(result.Transactions) < MaxRuleTestSamples {
+            result.Transactions = append(result.Transactions, *tx)
+        }
+    }
+    return result, nil
+}
+
+func (s *ruleService) Apply(userID uuid.UUID, input ApplyRulesInput) (*ApplyRulesResult, error) {
+    start, err := time.Parse("2006-01-02", input.StartDate)
+    if err != nil {
+        return nil, errors.New("invalid start_date format, use YYYY-MM-DD")
+    }
+    end, err := time.Parse("2006-01-02", input.EndDate)
+    if err != nil {
+        return nil, errors.New("invalid end_date format, use YYYY-MM-DD")
+    }
+    if start.After(end) {
+        return nil, errors.New("start_date must not be after end_date")
+    }
+
+    rules, err := s.ruleRepo.FindActiveByUser(userID)
+    if err != nil {
+        return nil, err
+    }
+    if len(input.RuleIDs) > 0 {
+        wanted := map[string]bool{}
+        for _, id := range input.RuleIDs {
+            wanted[id] = true
+        }
+        var selected []domain.TransactionRule
+        for _, rule := range rules {
+            if wanted[rule.ID.String()] {
+                selected = append(selected, rule)
+                delete(wanted, rule.ID.String())
+            }
+        }
+        for id := range wanted {
+            return nil, fmt.Errorf("rule %s not found or inactive", id)
+        }
+        rules = selected
+    }
+    if len(rules) == 0 {
+        return nil, errors.New("no active rules to apply")
+    }
+
+    transactions, err := s.txRepo.FindAllByUser(userID, repository.TransactionFilter{StartDate: &start, EndDate: &end})
+    if err != nil {
+        return nil, err
+    }
+
+    result := &ApplyRulesResult{DryRun: input.DryRun}
+    var changed []domain.Transaction
+    for i := range transactions {
+        tx := &transactions[i]
+        outcome := domain.ApplyRules(rules, tx)
+        if len(outcome.Rules) == 0 {
+            continue
+        }
+        result.Matched++
+        if applyOutcome(tx, outcome) {
+            changed = append(changed, *tx)
+        }
+    }
+    result.Updated = len(changed)
+
+    // Semua perubahan disimpan dalam satu DB transaction supaya apply
+    // tidak berhenti di tengah jalan
+    if !input.DryRun && len(changed) > 0 {
+        if err := s.txRepo.UpdateBatch(changed); err != nil {
+            return nil, err
+        }
+    }
+    return result, nil
+}
+
+// applyOutcome menerapkan hasil rule ke transaksi yang sudah ada dan
+// mengembalikan true jika ada yang berubah. Kategori transaksi split tidak
+// diubah karena kategorinya ditentukan per split.
+func applyOutcome(tx *domain.Transaction, outcome domain.RuleOutcome) bool {
+    changed := false
+    if outcome.Category != nil && len(tx.Splits) == 0 &&
+        (tx.CategoryID == nil || *tx.CategoryID != outcome.Category.ID) {
+        tx.CategoryID = &outcome.Category.ID
+        tx.Category = domain.Category{}
+        changed = true
+    }
+    if outcome.Payee != "" && tx.Payee != outcome.Payee {
+        tx.Payee = outcome.Payee
+        changed = true
+    }
+    if tags := appendTags(tx.Tags, outcome.Tags); len(tags) != len(tx.Tags) {
+        tx.Tags = tags
+        changed = true
+    }
+    return changed
+}
+
+// appendTags menambahkan tag dari rule yang belum ada, sampai batas
+// MaxTagsPerTransaction
+func appendTags(tags []domain.Tag, extra []domain.Tag) []domain.Tag {
+    has := make(map[uuid.UUID]bool, len(tags))
+    for _, tag := range tags {
+        has[tag.ID] = true
+    }
+    for _, tag := range extra {
+        if !has[tag.ID] && len(tags) < MaxTagsPerTransaction {
+            has[tag.ID] = true
+            tags = append(tags, tag)
+        }
+    }
+    return tags
+}
@@ -0,0 +1,236 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/storage"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+func grabRule(userID uuid.UUID, transport *domain.Category) domain.TransactionRule {
+    return domain.TransactionRule{ID: uuid.New(), UserID: userID, Name: "Grab", IsActive: true,
+        DescriptionContains: "grab", CategoryID: &transport.ID, Category: transport, SetPayee: "Grab"}
+}
+
+// ──────────────────────────────────────────
+// RULE CRUD TESTS
+// ──────────────────────────────────────────
+
+func TestCreateRule_CategoryMustMatchType(t *testing.T) {
+    mockRuleRepo := new(repomock.MockRuleRepository)
+    mockCatRepo  := new(repomock.MockCategoryRepository)
+    svc := service.NewRuleService(mockRuleRepo, new(repomock.MockTransactionRepository), mockCatRepo,
+        new(repomock.MockAccountRepository), new(repomock.MockTagRepository))
+
+    userID := uuid.New()
+    gaji   := &domain.Category{ID: uuid.New(), Name: "Gaji", Kind: domain.CategoryKindIncome}
+    mockCatRepo.On("FindByID", gaji.ID, userID).Return(gaji, nil)
+
+    _, err := svc.Create(userID, service.RuleInput{
+        Name:                "Grab",
+        DescriptionContains: "grab",
+        Type:                "expense",
+        CategoryID:          gaji.ID.String(),
+    })
+
+    assert.ErrorContains(t, err, "cannot be used for expense")
+    mockRuleRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateRule_RequiresAction(t *testing.T) {
+    mockRuleRepo := new(repomock.MockRuleRepository)
+    svc := service.NewRuleService(mockRuleRepo, new(repomock.MockTransactionRepository), new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), new(repomock.MockTagRepository))
+
+    _, err := svc.Create(uuid.New(), service.RuleInput{Name: "Grab", DescriptionContains: "grab"})
+
+    assert.ErrorIs(t, err, domain.ErrRuleNoAction)
+}
+
+// ──────────────────────────────────────────
+// RULE EVALUATION TESTS
+// ──────────────────────────────────────────
+
+func TestCreateTransaction_CategoryFromRule(t *testing.T) {
+    mockTxRepo      := new(repomock.MockTransactionRepository)
+    mockCatRepo     := new(repomock.MockCategoryRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    mockRuleRepo    := new(repomock.MockRuleRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo, idrUserRepo(),
+        new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), mockRuleRepo, storage.NewMemoryStorage())
+
+    userID    := uuid.New()
+    transport := &domain.Category{ID: uuid.New(), Name: "Transport", Kind: domain.CategoryKindExpense}
+    account   := &domain.Account{ID: uuid.New(), UserID: userID, Name: "OVO", IsDefault: true}
+
+    mockRuleRepo.On("FindActiveByUser", userID).Return([]domain.TransactionRule{grabRule(userID, transport)}, nil)
+    mockCatRepo.On("FindByID", transport.ID, userID).Return(transport, nil)
+    mockAccountRepo.On("FindDefault", userID).Return(account, nil)
+    mockTxRepo.On("Create", mock.MatchedBy(func(tx *domain.Transaction) bool {
+        return *tx.CategoryID == transport.ID && tx.Payee == "Grab"
+    })).Return(nil)
+    mockTxRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).Return(&domain.Transaction{}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        Type:        "expense",
+        Amount:      domain.NewMoney(32000),
+        Description: "GRAB* A-5XYZ",
+        Date:        "2026-02-03",
+    })
+
+    assert.NoError(t, err)
+    mockTxRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_NoCategoryAndNoRule(t *testing.T) {
+    mockTxRepo      := new(repomock.MockTransactionRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository), mockAccountRepo, idrUserRepo(),
+        new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    mockAccountRepo.On("FindDefault", userID).Return(&domain.Account{ID: uuid.New(), IsDefault: true}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        Type:   "expense",
+        Amount: domain.NewMoney(10000),
+        Date:   "2026-02-03",
+    })
+
+    assert.EqualError(t, err, "category_id is required, no rule matched this transaction")
+    mockTxRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestImport_RuleCategoryBeforeDefault(t *testing.T) {
+    f := newImportFixture()
+    transport := &domain.Category{ID: uuid.New(), Name: "Transport", Kind: domain.CategoryKindExpense}
+    ruleRepo := new(repomock.MockRuleRepository)
+    ruleRepo.On("FindActiveByUser", f.userID).Return([]domain.TransactionRule{grabRule(f.userID, transport)}, nil)
+    f.svc = service.NewImportService(f.txRepo, f.catRepo, f.accountRepo, idrUserRepo(), ruleRepo)
+    f.catRepo.On("FindByID", f.lainnya.ID, f.userID).Return(&f.lainnya, nil)
+
+    input := csvInput("date,description,amount,category\n" +
+        "2026-02-03,GRAB* A-5XYZ,-32000,\n" +
+        "2026-02-03,Grab refund,-5000,Makanan > Restoran\n" +
+        "2026-02-04,Parkir,-5000,\n")
+    input.DefaultCategoryID = f.lainnya.ID.String()
+    input.DryRun = true
+    result, err := f.svc.Import(f.userID, input)
+
+    assert.NoError(t, err)
+    assert.Equal(t, transport.ID, *result.Rows[0].CategoryID)
+    assert.Equal(t, "Grab", result.Rows[0].Payee)
+    assert.Equal(t, []string{"Grab"}, result.Rows[0].Rules)
+    // Kategori yang disebut di file tetap menang
+    assert.Equal(t, f.restoran.ID, *result.Rows[1].CategoryID)
+    assert.Equal(t, f.lainnya.ID, *result.Rows[2].CategoryID)
+}
+
+// ──────────────────────────────────────────
+// TEST / APPLY TESTS
+// ──────────────────────────────────────────
+
+func TestTestRule_DoesNotCreateTags(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockTagRepo := new(repomock.MockTagRepository)
+    svc := service.NewRuleService(new(repomock.MockRuleRepository), mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), mockTagRepo)
+
+    userID := uuid.New()
+    grab   := expenseOn(userID, 32000, "GRAB* A-5XYZ", "2026-02-03")
+    parkir := expenseOn(userID, 5000, "Parkir", "2026-02-04")
+    tagged := expenseOn(userID, 20000, "grab food", "2026-02-05")
+    ojol   := domain.Tag{ID: uuid.New(), UserID: userID, Name: "ojol"}
+    tagged.Tags = []domain.Tag{ojol}
+    mockTagRepo.On("FindByName", "ojol", userID).Return(&ojol, nil)
+    mockTxRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return([]domain.Transaction{grab, parkir, tagged}, nil)
+
+    result, err := svc.Test(userID, service.RuleInput{Name: "Grab", DescriptionContains: "grab", Tags: []string{"Ojol"}},
+        time.Now().AddDate(-1, 0, 0), time.Now())
+
+    assert.NoError(t, err)
+    assert.Equal(t, 2, result.Matched)
+    assert.Equal(t, 1, result.Changed)
+    assert.Equal(t, []domain.Tag{ojol}, result.Transactions[0].Tags)
+    mockTagRepo.AssertNotCalled(t, "FindOrCreate", mock.Anything, mock.Anything)
+}
+
+func TestApplyRules_UpdatesChangedTransactions(t *testing.T) {
+    mockRuleRepo := new(repomock.MockRuleRepository)
+    mockTxRepo   := new(repomock.MockTransactionRepository)
+    svc := service.NewRuleService(mockRuleRepo, mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), new(repomock.MockTagRepository))
+
+    userID    := uuid.New()
+    transport := &domain.Category{ID: uuid.New(), Name: "Transport", Kind: domain.CategoryKindExpense}
+    lainnya   := uuid.New()
+    grab      := expenseOn(userID, 32000, "GRAB* A-5XYZ", "2026-02-03")
+    grab.CategoryID = &lainnya
+    done      := expenseOn(userID, 15000, "grab", "2026-02-04")
+    done.CategoryID, done.Payee = &transport.ID, "Grab"
+    split     := expenseOn(userID, 50000, "grab mart", "2026-02-05")
+    split.Payee = "Grab"
+    split.Splits = []domain.TransactionSplit{{CategoryID: lainnya, Amount: domain.NewMoney(50000)}}
+
+    mockRuleRepo.On("FindActiveByUser", userID).Return([]domain.TransactionRule{grabRule(userID, transport)}, nil)
+    mockTxRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return([]domain.Transaction{grab, done, split}, nil)
+    mockTxRepo.On("UpdateBatch", mock.MatchedBy(func(txs []domain.Transaction) bool {
+        return len(txs) == 1 && txs[0].ID == grab.ID && *txs[0].CategoryID == transport.ID && txs[0].Payee == "Grab"
+    })).Return(nil).Once()
+
+    result, err := svc.Apply(userID, service.ApplyRulesInput{StartDate: "2026-02-01", EndDate: "2026-02-28"})
+
+    assert.NoError(t, err)
+    assert.Equal(t, 3, result.Matched)
+    // Transaksi yang sudah sesuai dan transaksi split tidak diubah
+    assert.Equal(t, 1, result.Updated)
+    mockTxRepo.AssertExpectations(t)
+}
+
+func TestApplyRules_BatchFailureReturnsError(t *testing.T) {
+    mockRuleRepo := new(repomock.MockRuleRepository)
+    mockTxRepo   := new(repomock.MockTransactionRepository)
+    svc := service.NewRuleService(mockRuleRepo, mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), new(repomock.MockTagRepository))
+
+    userID    := uuid.New()
+    transport := &domain.Category{ID: uuid.New(), Name: "Transport", Kind: domain.CategoryKindExpense}
+    first     := expenseOn(userID, 32000, "GRAB* A-5XYZ", "2026-02-03")
+    second    := expenseOn(userID, 18000, "GRAB* B-7QWE", "2026-02-04")
+
+    mockRuleRepo.On("FindActiveByUser", userID).Return([]domain.TransactionRule{grabRule(userID, transport)}, nil)
+    mockTxRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.TransactionFilter")).
+        Return([]domain.Transaction{first, second}, nil)
+    // Kedua transaksi disimpan sekaligus, gagal = tidak ada yang berubah
+    mockTxRepo.On("UpdateBatch", mock.MatchedBy(func(txs []domain.Transaction) bool { return len(txs) == 2 })).
+        Return(errors.New("db down")).Once()
+
+    result, err := svc.Apply(userID, service.ApplyRulesInput{StartDate: "2026-02-01", EndDate: "2026-02-28"})
+
+    assert.Nil(t, result)
+    assert.EqualError(t, err, "db down")
+    mockTxRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestApplyRules_UnknownRule(t *testing.T) {
+    mockRuleRepo := new(repomock.MockRuleRepository)
+    svc := service.NewRuleService(mockRuleRepo, new(repomock.MockTransactionRepository), new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), new(repomock.MockTagRepository))
+
+    userID := uuid.New()
+    mockRuleRepo.On("FindActiveByUser", userID).Return([]domain.TransactionRule{}, nil)
+    id := uuid.NewString()
+
+    _, err := svc.Apply(userID, service.ApplyRulesInput{StartDate: "2026-02-01", EndDate: "2026-02-28", RuleIDs: []string{id}})
+
+    assert.EqualError(t, err, "rule "+id+" not found or inactive")
+}
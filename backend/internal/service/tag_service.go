@@ -0,0 +1,221 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+// MaxTagsPerTransaction membatasi jumlah tag dalam satu transaksi
+const MaxTagsPerTransaction = 20
+
+type CreateTagInput struct {
+    Name string `json:"name" binding:"required"`
+}
+
+type RenameTagInput struct {
+    Name string `json:"name" binding:"required"`
+}
+
+type MergeTagInput struct {
+    TargetID string `json:"target_id" binding:"required"`
+}
+
+// TagSummary: total pemasukan & pengeluaran bertag dalam base currency.
+// Transaksi dengan beberapa tag dihitung penuh di setiap tag-nya.
+type TagSummary struct {
+    Tag      domain.Tag   `json:"tag"`
+    Income   domain.Money `json:"income"`
+    Expense  domain.Money `json:"expense"`
+    Count    int          `json:"count"`
+    Currency string       `json:"currency"`
+}
+
+type TagService interface {
+    GetAll(userID uuid.UUID) ([]domain.Tag, error)
+    Create(userID uuid.UUID, input CreateTagInput) (*domain.Tag, error)
+    Rename(id string, userID uuid.UUID, input RenameTagInput) (*domain.Tag, error)
+    Delete(id string, userID uuid.UUID) error
+    // Merge memindahkan transaksi tag id ke target lalu menghapus tag id
+    Merge(id string, userID uuid.UUID, input MergeTagInput) (*domain.Tag, error)
+    GetReport(userID uuid.UUID, start, end time.Time) ([]TagSummary, error)
+}
+
+type tagService struct {
+    tagRepo  repository.TagRepository
+    userRepo repository.UserRepository
+    rateRepo repository.ExchangeRateRepository
+}
+
+func NewTagService(tagRepo repository.TagRepository, userRepo repository.UserRepository, rateRepo repository.ExchangeRateRepository) TagService {
+    return &tagService{tagRepo, userRepo, rateRepo}
+}
+
+func (s *tagService) GetAll(userID uuid.UUID) ([]domain.Tag, error) {
+    return s.tagRepo.FindAllByUser(userID)
+}
+
+func (s *tagService) Create(userID uuid.UUID, input CreateTagInput) (*domain.Tag, error) {
+    name, err := domain.NormalizeTagName(input.Name)
+    if err != nil {
+        return nil, err
+    }
+    if _, err := s.tagRepo.FindByName(name, userID); err == nil {
+        return nil, fmt.Errorf("tag %s already exists", name)
+    }
+
+    tag := &domain.Tag{ID: uuid.New(), UserID: userID, Name: name}
+    if err := s.tagRepo.Create(tag); err != nil {
+        return nil, err
+    }
+    return tag, nil
+}
+
+func (s *tagService) findTag(id string, userID uuid.UUID) (*domain.Tag, error) {
+    tagID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid tag id")
+    }
+    tag, err := s.tagRepo.FindByID(tagID, userID)
+    if err != nil {
+        return nil, errors.New("tag not found")
+    }
+    return tag, nil
+}
+
+func (s *tagService) Rename(id string, userID uuid.UUID, input RenameTagInput) (*domain.Tag, error) {
+    tag, err := s.findTag(id, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    name, err := domain.NormalizeTagName(input.Name)
+    if err != nil {
+        return nil, err
+    }
+    if name == tag.Name {
+        return tag, nil
+    }
+    if _, err := s.tagRepo.FindByName(name, userID); err == nil {
+        return nil, fmt.Errorf("tag %s already exists, merge the tags instead", name)
+    }
+
+    tag.Name = name
+    if err := s.tagRepo.Update(tag); err != nil {
+        return nil, err
+    }
+    return tag, nil
+}
+
+func (s *tagService) Delete(id string, userID uuid.UUID) error {
+    tag, err := s.findTag(id, userID)
+    if err != nil {
+        return err
+    }
+    return s.tagRepo.Delete(tag.ID, userID)
+}
+
+func (s *tagService) Merge(id string, userID uuid.UUID, input MergeTagInput) (*domain.Tag, error) {
+    tag, err := s.findTag(id, userID)
+    if err != nil {
+        return nil, err
+    }
+    target, err := s.findTag(input.TargetID, userID)
+    if err != nil {
+        return nil, errors.New("target tag not found")
+    }
+    if target.ID == tag.ID {
+        return nil, errors.New("cannot merge a tag into itself")
+    }
+
+    if err := s.tagRepo.Merge(tag.ID, target.ID, userID); err != nil {
+        return nil, err
+    }
+    return target, nil
+}
+
+func (s *tagService) GetReport(userID uuid.UUID, start, end time.Time) ([]TagSummary, error) {
+    if end.Before(start) {
+        return nil, errors.New("end_date must not be before start_date")
+    }
+
+    tags, err := s.tagRepo.FindAllByUser(userID)
+    if err != nil {
+        return nil, err
+    }
+    totals, err := s.tagRepo.SumByTag(userID, start, end)
+    if err != nil {
+        return nil, err
+    }
+
+    base, err := baseCurrency(s.userRepo, userID)
+    if err != nil {
+        return nil, err
+    }
+    converter := newCurrencyConverter(s.rateRepo, userID)
+
+    byTag := map[uuid.UUID]*TagSummary{}
+    for _, tag := range tags {
+        byTag[tag.ID] = &TagSummary{Tag: tag, Currency: base}
+    }
+    for _, t := range totals {
+        summary, ok := byTag[t.TagID]
+        if !ok {
+            continue
+        }
+        amount, err := converter.convert(t.Amount, t.Currency, base, t.Date)
+        if err != nil {
+            return nil, err
+        }
+        if t.Type == domain.Income {
+            summary.Income += amount
+        } else {
+            summary.Expense += amount
+        }
+        summary.Count += t.Count
+    }
+
+    // Tag tanpa transaksi di periode ini tidak ditampilkan
+    result := []TagSummary{}
+    for _, summary := range byTag {
+        if summary.Count > 0 {
+            result = append(result, *summary)
+        }
+    }
+    sort.Slice(result, func(i, j int) bool {
+        if result[i].Expense != result[j].Expense {
+            return result[i].Expense > result[j].Expense
+        }
+        return result[i].Tag.Name < result[j].Tag.Name
+    })
+    return result, nil
+}
+
+// resolveTags menormalkan nama tag, membuang duplikat, lalu mengambil atau
+// membuat tag milik user
+func resolveTags(tagRepo repository.TagRepository, userID uuid.UUID, names []string) ([]domain.Tag, error) {
+    seen := map[string]bool{}
+    var normalized []string
+    for _, raw := range names {
+        name, err := domain.NormalizeTagName(raw)
+        if err != nil {
+            return nil, fmt.Errorf("tag %q: %w", raw, err)
+        }
+        if !seen[name] {
+            seen[name] = true
+            normalized = append(normalized, name)
+        }
+    }
+    if len(normalized) > MaxTagsPerTransaction {
+        return nil, fmt.Errorf("a transaction can have at most %d tags", MaxTagsPerTransaction)
+    }
+    if len(normalized) == 0 {
+        return []domain.Tag{}, nil
+    }
+    return tagRepo.FindOrCreate(userID, normalized)
+}
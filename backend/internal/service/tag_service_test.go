@@ -0,0 +1,186 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/storage"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+// ──────────────────────────────────────────
+// TAG CRUD TESTS
+// ──────────────────────────────────────────
+
+func TestCreateTag_NormalizesName(t *testing.T) {
+    mockTagRepo := new(repomock.MockTagRepository)
+    svc := service.NewTagService(mockTagRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    mockTagRepo.On("FindByName", "trip-bali-2026", userID).Return(nil, errors.New("record not found"))
+    mockTagRepo.On("Create", mock.AnythingOfType("*domain.Tag")).Return(nil)
+
+    tag, err := svc.Create(userID, service.CreateTagInput{Name: "  Trip Bali 2026 "})
+
+    assert.NoError(t, err)
+    assert.Equal(t, "trip-bali-2026", tag.Name)
+}
+
+func TestRenameTag_ToExistingNameAsksForMerge(t *testing.T) {
+    mockTagRepo := new(repomock.MockTagRepository)
+    svc := service.NewTagService(mockTagRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    tag    := &domain.Tag{ID: uuid.New(), UserID: userID, Name: "reimburse"}
+    mockTagRepo.On("FindByID", tag.ID, userID).Return(tag, nil)
+    mockTagRepo.On("FindByName", "reimbursable", userID).
+        Return(&domain.Tag{ID: uuid.New(), UserID: userID, Name: "reimbursable"}, nil)
+
+    _, err := svc.Rename(tag.ID.String(), userID, service.RenameTagInput{Name: "Reimbursable"})
+
+    assert.EqualError(t, err, "tag reimbursable already exists, merge the tags instead")
+    mockTagRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestMergeTag_IntoItself(t *testing.T) {
+    mockTagRepo := new(repomock.MockTagRepository)
+    svc := service.NewTagService(mockTagRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    tag    := &domain.Tag{ID: uuid.New(), UserID: userID, Name: "kantor"}
+    mockTagRepo.On("FindByID", tag.ID, userID).Return(tag, nil)
+
+    _, err := svc.Merge(tag.ID.String(), userID, service.MergeTagInput{TargetID: tag.ID.String()})
+
+    assert.EqualError(t, err, "cannot merge a tag into itself")
+    mockTagRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMergeTag_Success(t *testing.T) {
+    mockTagRepo := new(repomock.MockTagRepository)
+    svc := service.NewTagService(mockTagRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    from   := &domain.Tag{ID: uuid.New(), UserID: userID, Name: "bali"}
+    to     := &domain.Tag{ID: uuid.New(), UserID: userID, Name: "trip-bali-2026"}
+    mockTagRepo.On("FindByID", from.ID, userID).Return(from, nil)
+    mockTagRepo.On("FindByID", to.ID, userID).Return(to, nil)
+    mockTagRepo.On("Merge", from.ID, to.ID, userID).Return(nil)
+
+    result, err := svc.Merge(from.ID.String(), userID, service.MergeTagInput{TargetID: to.ID.String()})
+
+    assert.NoError(t, err)
+    assert.Equal(t, to.ID, result.ID)
+    mockTagRepo.AssertExpectations(t)
+}
+
+// ──────────────────────────────────────────
+// TAG REPORT TESTS
+// ──────────────────────────────────────────
+
+func TestTagReport_ConvertsAndSortsBySpending(t *testing.T) {
+    mockTagRepo  := new(repomock.MockTagRepository)
+    mockRateRepo := new(repomock.MockExchangeRateRepository)
+    svc := service.NewTagService(mockTagRepo, idrUserRepo(), mockRateRepo)
+
+    userID := uuid.New()
+    trip   := domain.Tag{ID: uuid.New(), UserID: userID, Name: "trip-bali-2026"}
+    office := domain.Tag{ID: uuid.New(), UserID: userID, Name: "reimbursable"}
+    unused := domain.Tag{ID: uuid.New(), UserID: userID, Name: "lama"}
+    start  := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+    end    := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+    day    := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+    mockTagRepo.On("FindAllByUser", userID).Return([]domain.Tag{office, trip, unused}, nil)
+    mockTagRepo.On("SumByTag", userID, start, end).Return([]repository.TagTotal{
+        {TagID: trip.ID, Type: domain.Expense, Currency: "IDR", Date: day, Amount: domain.NewMoney(1500000), Count: 2},
+        {TagID: trip.ID, Type: domain.Expense, Currency: "USD", Date: day, Amount: domain.NewMoney(100), Count: 1},
+        {TagID: office.ID, Type: domain.Expense, Currency: "IDR", Date: day, Amount: domain.NewMoney(200000), Count: 1},
+        {TagID: office.ID, Type: domain.Income, Currency: "IDR", Date: day, Amount: domain.NewMoney(200000), Count: 1},
+    }, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "USD", ToCurrency: "IDR"}).
+        Return([]domain.ExchangeRate{rateOn("USD", "IDR", 16000, "2026-02-01")}, nil)
+    mockRateRepo.On("FindAllByUser", userID, repository.ExchangeRateFilter{FromCurrency: "IDR", ToCurrency: "USD"}).
+        Return([]domain.ExchangeRate{}, nil)
+
+    report, err := svc.GetReport(userID, start, end)
+
+    assert.NoError(t, err)
+    assert.Len(t, report, 2) // tag tanpa transaksi tidak ikut
+    assert.Equal(t, trip.ID, report[0].Tag.ID)
+    assert.Equal(t, domain.NewMoney(3100000), report[0].Expense)
+    assert.Equal(t, 3, report[0].Count)
+    assert.Equal(t, domain.NewMoney(200000), report[1].Income)
+    assert.Equal(t, "IDR", report[1].Currency)
+}
+
+// ──────────────────────────────────────────
+// TRANSACTION TAGS TESTS
+// ──────────────────────────────────────────
+
+func TestCreateTransaction_ResolvesTags(t *testing.T) {
+    mockTxRepo      := new(repomock.MockTransactionRepository)
+    mockCatRepo     := new(repomock.MockCategoryRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    mockTagRepo     := new(repomock.MockTagRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository), mockTagRepo, noRules(), storage.NewMemoryStorage())
+
+    userID  := uuid.New()
+    cat     := &domain.Category{ID: uuid.New(), Name: "Transport"}
+    account := &domain.Account{ID: uuid.New(), UserID: userID, Name: "Cash", IsDefault: true}
+    tags    := []domain.Tag{{ID: uuid.New(), Name: "reimbursable"}, {ID: uuid.New(), Name: "trip-bali-2026"}}
+
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockAccountRepo.On("FindDefault", userID).Return(account, nil)
+    // Nama dirapikan dan duplikat dibuang sebelum ke repository
+    mockTagRepo.On("FindOrCreate", userID, []string{"trip-bali-2026", "reimbursable"}).Return(tags, nil)
+
+    var saved *domain.Transaction
+    mockTxRepo.On("Create", mock.AnythingOfType("*domain.Transaction")).
+        Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.Transaction) }).
+        Return(nil)
+    mockTxRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
+        Return(&domain.Transaction{ID: uuid.New()}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        CategoryID: cat.ID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(150000),
+        Date:       "2026-02-20",
+        Tags:       []string{"Trip Bali 2026", "reimbursable", "trip-bali-2026"},
+    })
+
+    assert.NoError(t, err)
+    assert.Equal(t, tags, saved.Tags)
+}
+
+func TestCreateTransaction_InvalidTag(t *testing.T) {
+    mockTxRepo      := new(repomock.MockTransactionRepository)
+    mockCatRepo     := new(repomock.MockCategoryRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID  := uuid.New()
+    cat     := &domain.Category{ID: uuid.New(), Name: "Transport"}
+    account := &domain.Account{ID: uuid.New(), UserID: userID, Name: "Cash", IsDefault: true}
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+    mockAccountRepo.On("FindDefault", userID).Return(account, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        CategoryID: cat.ID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(150000),
+        Date:       "2026-02-20",
+        Tags:       []string{"   "},
+    })
+
+    assert.ErrorIs(t, err, domain.ErrInvalidTag)
+    mockTxRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
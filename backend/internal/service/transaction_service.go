@@ -2,65 +2,137 @@ package service
 
 import (
     "errors"
+    "fmt"
+    "sort"
+    "strings"
     "time"
 
     "github.com/google/uuid"
     "github.com/myfarism/finance-tracker/internal/domain"
     "github.com/myfarism/finance-tracker/internal/repository"
+    "github.com/myfarism/finance-tracker/pkg/storage"
 )
 
+type SplitInput struct {
+    CategoryID string       `json:"category_id" binding:"required"`
+    Amount     domain.Money `json:"amount" binding:"required,gt=0"`
+    Note       string       `json:"note"`
+}
+
+// CreateTransactionInput: dengan splits, category_id boleh kosong dan
+// otomatis diisi kategori split terbesar
 type CreateTransactionInput struct {
-    CategoryID  string  `json:"category_id" binding:"required"`
-    Type        string  `json:"type" binding:"required,oneof=income expense"`
-    Amount      float64 `json:"amount" binding:"required,gt=0"`
-    Description string  `json:"description"`
-    Date        string  `json:"date" binding:"required"` // format: "2006-01-02"
+    AccountID   string       `json:"account_id"` // kosong = akun default
+    CategoryID  string       `json:"category_id"` // kosong = dari split terbesar atau rule
+    Type        string       `json:"type" binding:"required,oneof=income expense"`
+    Amount      domain.Money `json:"amount" binding:"required,gt=0"`
+    Currency    string       `json:"currency"` // opsional, harus sama dengan mata uang akun
+    Description string       `json:"description"`
+    Payee       string       `json:"payee" binding:"max=255"`
+    Date        string       `json:"date" binding:"required"` // format: "2006-01-02"
+    Splits      []SplitInput `json:"splits" binding:"omitempty,dive"`
+    Tags        []string     `json:"tags"` // nama tag, yang belum ada otomatis dibuat
 }
 
+// UpdateTransactionInput: splits/tags/payee nil = tidak diubah, [] / "" = hapus
 type UpdateTransactionInput struct {
-    CategoryID  string  `json:"category_id"`
-    Type        string  `json:"type" binding:"omitempty,oneof=income expense"`
-    Amount      float64 `json:"amount" binding:"omitempty,gt=0"`
-    Description string  `json:"description"`
-    Date        string  `json:"date"`
+    AccountID   string        `json:"account_id"`
+    CategoryID  string        `json:"category_id"`
+    Type        string        `json:"type" binding:"omitempty,oneof=income expense"`
+    Amount      domain.Money  `json:"amount" binding:"omitempty,gt=0"`
+    Description string        `json:"description"`
+    Payee       *string       `json:"payee" binding:"omitempty,max=255"`
+    Date        string        `json:"date"`
+    Splits      *[]SplitInput `json:"splits" binding:"omitempty,dive"`
+    Tags        *[]string     `json:"tags"`
 }
 
 type SummaryResponse struct {
-    Income  float64 `json:"income"`
-    Expense float64 `json:"expense"`
-    Balance float64 `json:"balance"`
-    Month   int     `json:"month"`
-    Year    int     `json:"year"`
+    Income   domain.Money `json:"income"`
+    Expense  domain.Money `json:"expense"`
+    Balance  domain.Money `json:"balance"`
+    Currency string       `json:"currency"` // base currency user
+    Month    int          `json:"month"`
+    Year     int          `json:"year"`
+}
+
+// CategorySummary: Amount hanya transaksi di kategori itu sendiri,
+// Total termasuk semua sub-kategorinya, keduanya dalam base currency
+type CategorySummary struct {
+    Category domain.Category `json:"category"`
+    Amount   domain.Money    `json:"amount"`
+    Total    domain.Money    `json:"total"`
+    Currency string          `json:"currency"`
+}
+
+// TransactionPage: satu halaman list transaksi. TotalCount dan Totals
+// dihitung dari seluruh baris yang lolos filter, bukan hanya halaman ini.
+type TransactionPage struct {
+    Items      []domain.Transaction
+    NextCursor string // kosong = halaman terakhir
+    TotalCount int64
+    Totals     PageTotals
+}
+
+type PageTotals struct {
+    Income   domain.Money `json:"income"`
+    Expense  domain.Money `json:"expense"`
+    Net      domain.Money `json:"net"`
+    Currency string       `json:"currency"` // base currency user
 }
 
 type TransactionService interface {
     Create(userID uuid.UUID, input CreateTransactionInput) (*domain.Transaction, error)
     GetAll(userID uuid.UUID, filter repository.TransactionFilter) ([]domain.Transaction, error)
+    GetPage(userID uuid.UUID, filter repository.TransactionFilter, page repository.PageQuery) (*TransactionPage, error)
+    Search(userID uuid.UUID, query string, filter repository.TransactionFilter, limit int) ([]repository.SearchHit, error)
     GetByID(id string, userID uuid.UUID) (*domain.Transaction, error)
     Update(id string, userID uuid.UUID, input UpdateTransactionInput) (*domain.Transaction, error)
     Delete(id string, userID uuid.UUID) error
     GetSummary(userID uuid.UUID, month, year int) (*SummaryResponse, error)
+    GetCategorySummary(userID uuid.UUID, txType domain.TransactionType, month, year int) ([]CategorySummary, error)
 }
 
 type transactionService struct {
-    txRepo  repository.TransactionRepository
-    catRepo repository.CategoryRepository
+    txRepo      repository.TransactionRepository
+    catRepo     repository.CategoryRepository
+    accountRepo repository.AccountRepository
+    userRepo    repository.UserRepository
+    rateRepo    repository.ExchangeRateRepository
+    tagRepo     repository.TagRepository
+    ruleRepo    repository.RuleRepository
+    blobs       storage.Storage
 }
 
-func NewTransactionService(txRepo repository.TransactionRepository, catRepo repository.CategoryRepository) TransactionService {
-    return &transactionService{txRepo, catRepo}
+func NewTransactionService(
+    txRepo repository.TransactionRepository,
+    catRepo repository.CategoryRepository,
+    accountRepo repository.AccountRepository,
+    userRepo repository.UserRepository,
+    rateRepo repository.ExchangeRateRepository,
+    tagRepo repository.TagRepository,
+    ruleRepo repository.RuleRepository,
+    blobs storage.Storage,
+) TransactionService {
+    return &transactionService{txRepo, catRepo, accountRepo, userRepo, rateRepo, tagRepo, ruleRepo, blobs}
 }
 
 func (s *transactionService) Create(userID uuid.UUID, input CreateTransactionInput) (*domain.Transaction, error) {
-    catID, err := uuid.Parse(input.CategoryID)
+    txType := domain.TransactionType(input.Type)
+    splits, err := s.buildSplits(input.Splits, input.Amount, userID, txType)
     if err != nil {
-        return nil, errors.New("invalid category_id")
+        return nil, err
     }
 
-    // Validasi kategori ada
-    _, err = s.catRepo.FindByID(catID)
-    if err != nil {
-        return nil, errors.New("category not found")
+    categoryID := input.CategoryID
+    if categoryID == "" && len(splits) > 0 {
+        categoryID = mainSplit(splits).CategoryID.String()
+    }
+    var cat *domain.Category
+    if categoryID != "" {
+        if cat, err = usableCategory(s.catRepo, categoryID, userID, txType); err != nil {
+            return nil, err
+        }
     }
 
     date, err := time.Parse("2006-01-02", input.Date)
@@ -68,16 +140,53 @@ func (s *transactionService) Create(userID uuid.UUID, input CreateTransactionInp
         return nil, errors.New("invalid date format, use YYYY-MM-DD")
     }
 
+    account, err := usableAccount(s.accountRepo, s.userRepo, input.AccountID, userID)
+    if err != nil {
+        return nil, err
+    }
+    if err := checkCurrency(input.Currency, account); err != nil {
+        return nil, err
+    }
+    tags, err := resolveTags(s.tagRepo, userID, input.Tags)
+    if err != nil {
+        return nil, err
+    }
+
     tx := &domain.Transaction{
         ID:          uuid.New(),
         UserID:      userID,
-        CategoryID:  catID,
+        AccountID:   account.ID,
         Type:        domain.TransactionType(input.Type),
         Amount:      input.Amount,
+        Currency:    account.Currency,
         Description: input.Description,
+        Payee:       strings.TrimSpace(input.Payee),
         Date:        date,
+        Splits:      splits,
+        Tags:        tags,
     }
 
+    // Rule mengisi kategori hanya jika user tidak memilih; rename payee dan
+    // tag dari rule selalu diterapkan
+    rules, err := s.ruleRepo.FindActiveByUser(userID)
+    if err != nil {
+        return nil, err
+    }
+    outcome := domain.ApplyRules(rules, tx)
+    if cat == nil {
+        if outcome.Category == nil {
+            return nil, errors.New("category_id is required, no rule matched this transaction")
+        }
+        if cat, err = usableCategory(s.catRepo, outcome.Category.ID.String(), userID, txType); err != nil {
+            return nil, err
+        }
+    }
+    tx.CategoryID = &cat.ID
+    if outcome.Payee != "" {
+        tx.Payee = outcome.Payee
+    }
+    tx.Tags = appendTags(tx.Tags, outcome.Tags)
+
     if err := s.txRepo.Create(tx); err != nil {
         return nil, err
     }
@@ -86,10 +195,176 @@ func (s *transactionService) Create(userID uuid.UUID, input CreateTransactionInp
     return s.txRepo.FindByID(tx.ID, userID)
 }
 
+// usableCategory memvalidasi kategori ada, boleh dipakai user ini, tidak
+// di-archive dan cocok dengan tipe transaksi
+func usableCategory(catRepo repository.CategoryRepository, id string, userID uuid.UUID, txType domain.TransactionType) (*domain.Category, error) {
+    catID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid category_id")
+    }
+
+    cat, err := catRepo.FindByID(catID, userID)
+    if err != nil {
+        return nil, errors.New("category not found")
+    }
+    if cat.IsArchived {
+        return nil, errors.New("category is archived")
+    }
+    if !cat.Allows(txType) {
+        return nil, errCategoryKind(cat, txType)
+    }
+    return cat, nil
+}
+
+// usableAccount memvalidasi akun milik user; id kosong = akun default
+func usableAccount(accountRepo repository.AccountRepository, userRepo repository.UserRepository, id string, userID uuid.UUID) (*domain.Account, error) {
+    if id == "" {
+        return defaultAccount(accountRepo, userRepo, userID)
+    }
+
+    accountID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid account_id")
+    }
+    account, err := accountRepo.FindByID(accountID, userID)
+    if err != nil {
+        return nil, errors.New("account not found")
+    }
+    if account.IsArchived {
+        return nil, errors.New("account is archived")
+    }
+    return account, nil
+}
+
+// buildSplits memvalidasi kategori tiap split dan memastikan totalnya sama
+// dengan nominal transaksi
+func (s *transactionService) buildSplits(inputs []SplitInput, amount domain.Money, userID uuid.UUID, txType domain.TransactionType) ([]domain.TransactionSplit, error) {
+    if len(inputs) == 0 {
+        return nil, nil
+    }
+
+    var total domain.Money
+    splits := make([]domain.TransactionSplit, 0, len(inputs))
+    for i, in := range inputs {
+        if in.Amount <= 0 {
+            return nil, fmt.Errorf("split %d: amount must be greater than 0", i+1)
+        }
+        cat, err := usableCategory(s.catRepo, in.CategoryID, userID, txType)
+        if err != nil {
+            return nil, fmt.Errorf("split %d: %w", i+1, err)
+        }
+        total += in.Amount
+        splits = append(splits, domain.TransactionSplit{
+            ID:         uuid.New(),
+            CategoryID: cat.ID,
+            Amount:     in.Amount,
+            Note:       in.Note,
+        })
+    }
+
+    if total != amount {
+        return nil, fmt.Errorf("splits total %s does not match transaction amount %s", total, amount)
+    }
+    return splits, nil
+}
+
+// checkSplits memvalidasi ulang split yang sudah ada setelah nominal atau
+// tipe transaksi berubah
+func checkSplits(tx *domain.Transaction, typeChanged bool) error {
+    var total domain.Money
+    for _, split := range tx.Splits {
+        total += split.Amount
+    }
+    if total != tx.Amount {
+        return fmt.Errorf("splits total %s does not match transaction amount %s, update the splits too", total, tx.Amount)
+    }
+    if typeChanged {
+        for _, split := range tx.Splits {
+            if !split.Category.Allows(tx.Type) {
+                return errCategoryKind(&split.Category, tx.Type)
+            }
+        }
+    }
+    return nil
+}
+
+// mainSplit: split dengan nominal terbesar, dipakai sebagai kategori utama
+func mainSplit(splits []domain.TransactionSplit) domain.TransactionSplit {
+    main := splits[0]
+    for _, split := range splits[1:] {
+        if split.Amount > main.Amount {
+            main = split
+        }
+    }
+    return main
+}
+
+var errTransferLeg = errors.New("transaction is part of a transfer, update or delete the transfer instead")
+
+// checkCurrency: transaksi selalu dalam mata uang akunnya
+func checkCurrency(currency string, account *domain.Account) error {
+    if currency == "" {
+        return nil
+    }
+    code, err := domain.NormalizeCurrency(currency)
+    if err != nil {
+        return err
+    }
+    if code != account.Currency {
+        return fmt.Errorf("account %s uses %s, not %s", account.Name, account.Currency, code)
+    }
+    return nil
+}
+
+func errCategoryKind(cat *domain.Category, txType domain.TransactionType) error {
+    return fmt.Errorf("category %s cannot be used for %s transactions", cat.Name, txType)
+}
+
 func (s *transactionService) GetAll(userID uuid.UUID, filter repository.TransactionFilter) ([]domain.Transaction, error) {
     return s.txRepo.FindAllByUser(userID, filter)
 }
 
+func (s *transactionService) GetPage(userID uuid.UUID, filter repository.TransactionFilter, page repository.PageQuery) (*TransactionPage, error) {
+    items, next, err := s.txRepo.FindPageByUser(userID, filter, page)
+    if err != nil {
+        return nil, err
+    }
+
+    count, totals, err := s.txRepo.SumByFilter(userID, filter)
+    if err != nil {
+        return nil, err
+    }
+    income, expense, base, err := s.sumInBase(userID, totals)
+    if err != nil {
+        return nil, err
+    }
+
+    result := &TransactionPage{
+        Items:      items,
+        TotalCount: count,
+        Totals: PageTotals{
+            Income:   income,
+            Expense:  expense,
+            Net:      income - expense,
+            Currency: base,
+        },
+    }
+    if next != nil {
+        result.NextCursor = next.Encode()
+    }
+    return result, nil
+}
+
+func (s *transactionService) Search(userID uuid.UUID, query string, filter repository.TransactionFilter, limit int) ([]repository.SearchHit, error) {
+    if strings.TrimSpace(query) == "" {
+        return nil, errors.New("search query is required")
+    }
+    if repository.PrefixQuery(query) == "" {
+        return nil, errors.New("search query must contain letters or digits")
+    }
+    return s.txRepo.Search(userID, query, filter, limit)
+}
+
 func (s *transactionService) GetByID(id string, userID uuid.UUID) (*domain.Transaction, error) {
     txID, err := uuid.Parse(id)
     if err != nil {
@@ -108,23 +383,60 @@ func (s *transactionService) Update(id string, userID uuid.UUID, input UpdateTra
     if err != nil {
         return nil, errors.New("transaction not found")
     }
+    if tx.IsTransferLeg() {
+        return nil, errTransferLeg
+    }
 
+    category := tx.Category
+    categoryChanged := false
     if input.CategoryID != "" {
         catID, err := uuid.Parse(input.CategoryID)
         if err != nil {
             return nil, errors.New("invalid category_id")
         }
-        tx.CategoryID = catID
+        if tx.CategoryID == nil || catID != *tx.CategoryID {
+            cat, err := s.catRepo.FindByID(catID, userID)
+            if err != nil {
+                return nil, errors.New("category not found")
+            }
+            if cat.IsArchived {
+                return nil, errors.New("category is archived")
+            }
+            category = *cat
+            categoryChanged = true
+        }
+        tx.CategoryID = &catID
+        // Kosongkan relasi lama agar Save tidak menimpa category_id
+        tx.Category = domain.Category{}
     }
+    typeChanged := false
     if input.Type != "" {
+        typeChanged = domain.TransactionType(input.Type) != tx.Type
         tx.Type = domain.TransactionType(input.Type)
     }
+    // Kombinasi lama dibiarkan walaupun kind kategori sudah berubah,
+    // validasi hanya saat tipe atau kategori diganti
+    if (categoryChanged || typeChanged) && !category.Allows(tx.Type) {
+        return nil, errCategoryKind(&category, tx.Type)
+    }
+    if input.AccountID != "" && input.AccountID != tx.AccountID.String() {
+        account, err := usableAccount(s.accountRepo, s.userRepo, input.AccountID, userID)
+        if err != nil {
+            return nil, err
+        }
+        tx.AccountID = account.ID
+        tx.Currency = account.Currency
+        tx.Account = domain.Account{}
+    }
     if input.Amount > 0 {
         tx.Amount = input.Amount
     }
     if input.Description != "" {
         tx.Description = input.Description
     }
+    if input.Payee != nil {
+        tx.Payee = strings.TrimSpace(*input.Payee)
+    }
     if input.Date != "" {
         date, err := time.Parse("2006-01-02", input.Date)
         if err != nil {
@@ -133,7 +445,37 @@ func (s *transactionService) Update(id string, userID uuid.UUID, input UpdateTra
         tx.Date = date
     }
 
-    if err := s.txRepo.Update(tx); err != nil {
+    if input.Splits != nil {
+        splits, err := s.buildSplits(*input.Splits, tx.Amount, userID, tx.Type)
+        if err != nil {
+            return nil, err
+        }
+        tx.Splits = splits
+        if len(splits) > 0 && input.CategoryID == "" {
+            main := mainSplit(splits).CategoryID
+            tx.CategoryID = &main
+            tx.Category = domain.Category{}
+        }
+    } else if len(tx.Splits) > 0 {
+        // Split lama tetap dipakai, jadi harus tetap valid
+        if err := checkSplits(tx, typeChanged); err != nil {
+            return nil, err
+        }
+    }
+    if input.Tags != nil {
+        tags, err := resolveTags(s.tagRepo, userID, *input.Tags)
+        if err != nil {
+            return nil, err
+        }
+        tx.Tags = tags
+    }
+
+    // Split hanya ditulis ulang jika request memang mengirim splits
+    save := s.txRepo.Update
+    if input.Splits != nil {
+        save = s.txRepo.UpdateWithSplits
+    }
+    if err := save(tx); err != nil {
         return nil, err
     }
 
@@ -146,25 +488,112 @@ func (s *transactionService) Delete(id string, userID uuid.UUID) error {
         return errors.New("invalid transaction id")
     }
 
-    _, err = s.txRepo.FindByID(txID, userID)
+    tx, err := s.txRepo.FindByID(txID, userID)
     if err != nil {
         return errors.New("transaction not found")
     }
+    if tx.IsTransferLeg() {
+        return errTransferLeg
+    }
 
-    return s.txRepo.Delete(txID, userID)
+    if err := s.txRepo.Delete(txID, userID); err != nil {
+        return err
+    }
+    removeBlobs(s.blobs, tx.Attachments)
+    return nil
 }
 
 func (s *transactionService) GetSummary(userID uuid.UUID, month, year int) (*SummaryResponse, error) {
-    income, expense, err := s.txRepo.GetSummaryByUser(userID, month, year)
+    totals, err := s.txRepo.GetSummaryByUser(userID, month, year)
+    if err != nil {
+        return nil, err
+    }
+
+    income, expense, base, err := s.sumInBase(userID, totals)
     if err != nil {
         return nil, err
     }
 
     return &SummaryResponse{
-        Income:  income,
-        Expense: expense,
-        Balance: income - expense,
-        Month:   month,
-        Year:    year,
+        Income:   income,
+        Expense:  expense,
+        Balance:  income - expense,
+        Currency: base,
+        Month:    month,
+        Year:     year,
     }, nil
 }
+
+// sumInBase menjumlahkan total per mata uang ke base currency user,
+// dikonversi dengan kurs di tanggal transaksinya
+func (s *transactionService) sumInBase(userID uuid.UUID, totals []repository.CurrencyTotal) (income, expense domain.Money, base string, err error) {
+    base, err = baseCurrency(s.userRepo, userID)
+    if err != nil {
+        return 0, 0, "", err
+    }
+    converter := newCurrencyConverter(s.rateRepo, userID)
+
+    for _, t := range totals {
+        amount, err := converter.convert(t.Amount, t.Currency, base, t.Date)
+        if err != nil {
+            return 0, 0, "", err
+        }
+        if t.Type == domain.Income {
+            income += amount
+        } else {
+            expense += amount
+        }
+    }
+    return income, expense, base, nil
+}
+
+func (s *transactionService) GetCategorySummary(userID uuid.UUID, txType domain.TransactionType, month, year int) ([]CategorySummary, error) {
+    startDate, endDate := monthRange(month, year)
+    transactions, err := s.txRepo.FindAllByUser(userID, repository.TransactionFilter{
+        Type:      string(txType),
+        StartDate: &startDate,
+        EndDate:   &endDate,
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    base, err := baseCurrency(s.userRepo, userID)
+    if err != nil {
+        return nil, err
+    }
+    converter := newCurrencyConverter(s.rateRepo, userID)
+
+    // Transaksi split dibagi ke kategori masing-masing split
+    amounts := map[uuid.UUID]domain.Money{}
+    for _, tx := range transactions {
+        for categoryID, amount := range tx.CategoryAmounts() {
+            converted, err := converter.convert(amount, tx.Currency, base, tx.Date)
+            if err != nil {
+                return nil, err
+            }
+            amounts[categoryID] += converted
+        }
+    }
+
+    categories, err := s.catRepo.FindAllByUser(userID, repository.CategoryFilter{IncludeArchived: true})
+    if err != nil {
+        return nil, err
+    }
+    tree := newCategoryTree(categories)
+    totals := tree.rollUp(amounts)
+
+    result := []CategorySummary{}
+    for id, total := range totals {
+        cat, ok := tree.byID[id]
+        if !ok {
+            continue
+        }
+        result = append(result, CategorySummary{Category: cat, Amount: amounts[id], Total: total, Currency: base})
+    }
+
+    sort.Slice(result, func(i, j int) bool {
+        return result[i].Total > result[j].Total
+    })
+    return result, nil
+}
@@ -10,6 +10,7 @@ import (
     "github.com/myfarism/finance-tracker/internal/repository"
     repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
     "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/storage"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/mock"
 )
@@ -17,25 +18,28 @@ import (
 func TestGetSummary_CalculatesBalanceCorrectly(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     mockTxRepo.On("GetSummaryByUser", userID, 2, 2026).
-        Return(5000000.0, 1500000.0, nil)
+        Return([]repository.CurrencyTotal{
+            {Type: domain.Income, Currency: "IDR", Amount: domain.NewMoney(5000000)},
+            {Type: domain.Expense, Currency: "IDR", Amount: domain.NewMoney(1500000)},
+        }, nil)
 
     summary, err := svc.GetSummary(userID, 2, 2026)
 
     assert.NoError(t, err)
-    assert.Equal(t, 5000000.0, summary.Income)
-    assert.Equal(t, 1500000.0, summary.Expense)
-    assert.Equal(t, 3500000.0, summary.Balance) // 5jt - 1.5jt
+    assert.Equal(t, domain.NewMoney(5000000), summary.Income)
+    assert.Equal(t, domain.NewMoney(1500000), summary.Expense)
+    assert.Equal(t, domain.NewMoney(3500000), summary.Balance) // 5jt - 1.5jt
     mockTxRepo.AssertExpectations(t)
 }
 
 func TestGetAll_WithFilter(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     catID  := uuid.New()
@@ -46,7 +50,7 @@ func TestGetAll_WithFilter(t *testing.T) {
             ID:     uuid.New(),
             UserID: userID,
             Type:   domain.Expense,
-            Amount: 50000,
+            Amount: domain.NewMoney(50000),
             Date:   time.Now(),
             Category: domain.Category{
                 ID:   catID,
@@ -70,7 +74,7 @@ func TestGetAll_WithFilter(t *testing.T) {
 func TestDelete_TransactionNotFound(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     randomID := uuid.New()
@@ -93,27 +97,32 @@ func TestDelete_TransactionNotFound(t *testing.T) {
 func TestCreate_Success(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     catID  := uuid.New()
     cat    := &domain.Category{ID: catID, Name: "Makan", Icon: "🍜"}
+    account := &domain.Account{ID: uuid.New(), UserID: userID, Name: "Cash", IsDefault: true}
 
-    mockCatRepo.On("FindByID", catID).Return(cat, nil)
-    mockTxRepo.On("Create", mock.AnythingOfType("*domain.Transaction")).Return(nil)
+    mockCatRepo.On("FindByID", catID, userID).Return(cat, nil)
+    mockAccountRepo.On("FindDefault", userID).Return(account, nil)
+    mockTxRepo.On("Create", mock.MatchedBy(func(tx *domain.Transaction) bool {
+        return tx.AccountID == account.ID
+    })).Return(nil)
     mockTxRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
         Return(&domain.Transaction{
             ID:       uuid.New(),
             UserID:   userID,
             Category: *cat,
             Type:     domain.Expense,
-            Amount:   50000,
+            Amount:   domain.NewMoney(50000),
         }, nil)
 
     result, err := svc.Create(userID, service.CreateTransactionInput{
         CategoryID:  catID.String(),
         Type:        "expense",
-        Amount:      50000,
+        Amount:      domain.NewMoney(50000),
         Description: "Makan siang",
         Date:        "2026-02-20",
     })
@@ -121,20 +130,165 @@ func TestCreate_Success(t *testing.T) {
     assert.NoError(t, err)
     assert.NotNil(t, result)
     assert.Equal(t, domain.Expense, result.Type)
-    assert.Equal(t, 50000.0, result.Amount)
+    assert.Equal(t, domain.NewMoney(50000), result.Amount)
     mockTxRepo.AssertExpectations(t)
     mockCatRepo.AssertExpectations(t)
+    mockAccountRepo.AssertExpectations(t)
+}
+
+func TestCreate_WithSplits(t *testing.T) {
+    mockTxRepo      := new(repomock.MockTransactionRepository)
+    mockCatRepo     := new(repomock.MockCategoryRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID    := uuid.New()
+    groceries := &domain.Category{ID: uuid.New(), Name: "Groceries"}
+    household := &domain.Category{ID: uuid.New(), Name: "Rumah Tangga"}
+    account   := &domain.Account{ID: uuid.New(), UserID: userID, Name: "Cash", IsDefault: true}
+
+    mockCatRepo.On("FindByID", groceries.ID, userID).Return(groceries, nil)
+    mockCatRepo.On("FindByID", household.ID, userID).Return(household, nil)
+    mockAccountRepo.On("FindDefault", userID).Return(account, nil)
+
+    var saved *domain.Transaction
+    mockTxRepo.On("Create", mock.AnythingOfType("*domain.Transaction")).
+        Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.Transaction) }).
+        Return(nil)
+    mockTxRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
+        Return(&domain.Transaction{ID: uuid.New()}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        Type:   "expense",
+        Amount: domain.NewMoney(300000),
+        Date:   "2026-02-20",
+        Splits: []service.SplitInput{
+            {CategoryID: household.ID.String(), Amount: domain.NewMoney(100000), Note: "sabun"},
+            {CategoryID: groceries.ID.String(), Amount: domain.NewMoney(200000)},
+        },
+    })
+
+    assert.NoError(t, err)
+    assert.Len(t, saved.Splits, 2)
+    // Kategori utama diambil dari split terbesar
+    assert.Equal(t, groceries.ID, *saved.CategoryID)
+}
+
+func TestCreate_SplitsMustSumToAmount(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    cat    := &domain.Category{ID: uuid.New(), Name: "Groceries"}
+    mockCatRepo.On("FindByID", cat.ID, userID).Return(cat, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        CategoryID: cat.ID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(300000),
+        Date:       "2026-02-20",
+        Splits: []service.SplitInput{
+            {CategoryID: cat.ID.String(), Amount: domain.NewMoney(100000)},
+            {CategoryID: cat.ID.String(), Amount: domain.NewMoney(150000)},
+        },
+    })
+
+    assert.EqualError(t, err, "splits total 250000 does not match transaction amount 300000")
+    mockTxRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdate_AmountChangeWithoutSplits(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository), new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    catID  := uuid.New()
+    tx     := &domain.Transaction{
+        ID:         uuid.New(),
+        UserID:     userID,
+        CategoryID: &catID,
+        Type:       domain.Expense,
+        Amount:     domain.NewMoney(300000),
+        Splits: []domain.TransactionSplit{
+            {CategoryID: catID, Amount: domain.NewMoney(100000)},
+            {CategoryID: uuid.New(), Amount: domain.NewMoney(200000)},
+        },
+    }
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+
+    _, err := svc.Update(tx.ID.String(), userID, service.UpdateTransactionInput{Amount: domain.NewMoney(350000)})
+
+    assert.ErrorContains(t, err, "update the splits too")
+    mockTxRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestCreate_CreatesDefaultAccountWhenMissing(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    catID  := uuid.New()
+    cat    := &domain.Category{ID: catID, Name: "Makan"}
+
+    mockCatRepo.On("FindByID", catID, userID).Return(cat, nil)
+    mockAccountRepo.On("FindDefault", userID).Return(nil, assert.AnError)
+    mockAccountRepo.On("Create", mock.MatchedBy(func(a *domain.Account) bool {
+        return a.UserID == userID && a.IsDefault && a.Name == domain.DefaultAccountName
+    })).Return(nil)
+    mockTxRepo.On("Create", mock.AnythingOfType("*domain.Transaction")).Return(nil)
+    mockTxRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
+        Return(&domain.Transaction{ID: uuid.New(), UserID: userID}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        CategoryID: catID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(10000),
+        Date:       "2026-02-20",
+    })
+
+    assert.NoError(t, err)
+    mockAccountRepo.AssertExpectations(t)
+}
+
+func TestCreate_ArchivedAccount(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, mockAccountRepo, idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    catID  := uuid.New()
+    accountID := uuid.New()
+
+    mockCatRepo.On("FindByID", catID, userID).Return(&domain.Category{ID: catID}, nil)
+    mockAccountRepo.On("FindByID", accountID, userID).
+        Return(&domain.Account{ID: accountID, UserID: userID, IsArchived: true}, nil)
+
+    result, err := svc.Create(userID, service.CreateTransactionInput{
+        AccountID:  accountID.String(),
+        CategoryID: catID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(10000),
+        Date:       "2026-02-20",
+    })
+
+    assert.Nil(t, result)
+    assert.EqualError(t, err, "account is archived")
+    mockTxRepo.AssertNotCalled(t, "Create")
 }
 
 func TestCreate_InvalidCategoryID(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     _, err := svc.Create(uuid.New(), service.CreateTransactionInput{
         CategoryID: "bukan-uuid-valid",
         Type:       "expense",
-        Amount:     50000,
+        Amount:     domain.NewMoney(50000),
         Date:       "2026-02-20",
     })
 
@@ -146,15 +300,16 @@ func TestCreate_InvalidCategoryID(t *testing.T) {
 func TestCreate_CategoryNotFound(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
-    catID := uuid.New()
-    mockCatRepo.On("FindByID", catID).Return(nil, errors.New("not found"))
+    catID  := uuid.New()
+    userID := uuid.New()
+    mockCatRepo.On("FindByID", catID, userID).Return(nil, errors.New("not found"))
 
-    _, err := svc.Create(uuid.New(), service.CreateTransactionInput{
+    _, err := svc.Create(userID, service.CreateTransactionInput{
         CategoryID: catID.String(),
         Type:       "expense",
-        Amount:     50000,
+        Amount:     domain.NewMoney(50000),
         Date:       "2026-02-20",
     })
 
@@ -163,19 +318,41 @@ func TestCreate_CategoryNotFound(t *testing.T) {
     mockTxRepo.AssertNotCalled(t, "Create")
 }
 
+func TestCreate_CategoryKindMismatch(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    gaji   := &domain.Category{ID: uuid.New(), Name: "Gaji", Kind: domain.CategoryKindIncome}
+    mockCatRepo.On("FindByID", gaji.ID, userID).Return(gaji, nil)
+
+    _, err := svc.Create(userID, service.CreateTransactionInput{
+        CategoryID: gaji.ID.String(),
+        Type:       "expense",
+        Amount:     domain.NewMoney(50000),
+        Date:       "2026-02-20",
+    })
+
+    assert.Error(t, err)
+    assert.Equal(t, "category Gaji cannot be used for expense transactions", err.Error())
+    mockTxRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
 func TestCreate_InvalidDateFormat(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
-    catID := uuid.New()
-    mockCatRepo.On("FindByID", catID).
+    catID  := uuid.New()
+    userID := uuid.New()
+    mockCatRepo.On("FindByID", catID, userID).
         Return(&domain.Category{ID: catID}, nil)
 
-    _, err := svc.Create(uuid.New(), service.CreateTransactionInput{
+    _, err := svc.Create(userID, service.CreateTransactionInput{
         CategoryID: catID.String(),
         Type:       "expense",
-        Amount:     50000,
+        Amount:     domain.NewMoney(50000),
         Date:       "20-02-2026", // ← format salah
     })
 
@@ -190,7 +367,7 @@ func TestCreate_InvalidDateFormat(t *testing.T) {
 func TestUpdate_Success(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     txID   := uuid.New()
@@ -199,16 +376,16 @@ func TestUpdate_Success(t *testing.T) {
     existingTx := &domain.Transaction{
         ID:         txID,
         UserID:     userID,
-        CategoryID: catID,
+        CategoryID: &catID,
         Type:       domain.Expense,
-        Amount:     50000,
+        Amount:     domain.NewMoney(50000),
         Date:       time.Now(),
     }
 
     updatedTx := &domain.Transaction{
         ID:     txID,
         UserID: userID,
-        Amount: 75000, // amount berubah
+        Amount: domain.NewMoney(75000), // amount berubah
     }
 
     mockTxRepo.On("FindByID", txID, userID).Return(existingTx, nil).Once()
@@ -216,18 +393,82 @@ func TestUpdate_Success(t *testing.T) {
     mockTxRepo.On("FindByID", txID, userID).Return(updatedTx, nil).Once()
 
     result, err := svc.Update(txID.String(), userID, service.UpdateTransactionInput{
-        Amount: 75000,
+        Amount: domain.NewMoney(75000),
     })
 
     assert.NoError(t, err)
-    assert.Equal(t, 75000.0, result.Amount)
+    assert.Equal(t, domain.NewMoney(75000), result.Amount)
     mockTxRepo.AssertExpectations(t)
+    // Tanpa splits di request, split lama tidak ditulis ulang
+    mockTxRepo.AssertNotCalled(t, "UpdateWithSplits", mock.Anything)
+}
+
+func TestUpdate_ReplacesSplits(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID    := uuid.New()
+    groceries := &domain.Category{ID: uuid.New(), Name: "Groceries"}
+    household := &domain.Category{ID: uuid.New(), Name: "Rumah Tangga"}
+    tx        := &domain.Transaction{
+        ID:         uuid.New(),
+        UserID:     userID,
+        CategoryID: &groceries.ID,
+        Type:       domain.Expense,
+        Amount:     domain.NewMoney(300000),
+    }
+
+    mockCatRepo.On("FindByID", groceries.ID, userID).Return(groceries, nil)
+    mockCatRepo.On("FindByID", household.ID, userID).Return(household, nil)
+    mockTxRepo.On("FindByID", tx.ID, userID).Return(tx, nil)
+    var saved *domain.Transaction
+    mockTxRepo.On("UpdateWithSplits", mock.AnythingOfType("*domain.Transaction")).
+        Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.Transaction) }).
+        Return(nil)
+
+    _, err := svc.Update(tx.ID.String(), userID, service.UpdateTransactionInput{
+        Splits: &[]service.SplitInput{
+            {CategoryID: groceries.ID.String(), Amount: domain.NewMoney(100000)},
+            {CategoryID: household.ID.String(), Amount: domain.NewMoney(200000)},
+        },
+    })
+
+    assert.NoError(t, err)
+    assert.Len(t, saved.Splits, 2)
+    assert.Equal(t, household.ID, *saved.CategoryID)
+    mockTxRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUpdate_TypeChangeMismatchesCategory(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    txID   := uuid.New()
+    makanan := domain.Category{ID: uuid.New(), Name: "Makanan", Kind: domain.CategoryKindExpense}
+
+    mockTxRepo.On("FindByID", txID, userID).Return(&domain.Transaction{
+        ID:         txID,
+        UserID:     userID,
+        CategoryID: &makanan.ID,
+        Category:   makanan,
+        Type:       domain.Expense,
+        Amount:     domain.NewMoney(50000),
+    }, nil)
+
+    _, err := svc.Update(txID.String(), userID, service.UpdateTransactionInput{Type: "income"})
+
+    assert.Error(t, err)
+    assert.Equal(t, "category Makanan cannot be used for income transactions", err.Error())
+    mockTxRepo.AssertNotCalled(t, "Update", mock.Anything)
 }
 
 func TestUpdate_InvalidID(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     _, err := svc.Update("bukan-uuid", uuid.New(), service.UpdateTransactionInput{})
 
@@ -235,6 +476,28 @@ func TestUpdate_InvalidID(t *testing.T) {
     assert.Equal(t, "invalid transaction id", err.Error())
 }
 
+func TestUpdate_CategoryOfAnotherUser(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID       := uuid.New()
+    txID         := uuid.New()
+    foreignCatID := uuid.New()
+
+    mockTxRepo.On("FindByID", txID, userID).
+        Return(&domain.Transaction{ID: txID, UserID: userID, Type: domain.Expense, Amount: domain.NewMoney(50000)}, nil)
+    mockCatRepo.On("FindByID", foreignCatID, userID).Return(nil, errors.New("not found"))
+
+    _, err := svc.Update(txID.String(), userID, service.UpdateTransactionInput{
+        CategoryID: foreignCatID.String(),
+    })
+
+    assert.Error(t, err)
+    assert.Equal(t, "category not found", err.Error())
+    mockTxRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
 // ──────────────────────────────────────────
 // DELETE TRANSACTION TESTS
 // ──────────────────────────────────────────
@@ -242,7 +505,7 @@ func TestUpdate_InvalidID(t *testing.T) {
 func TestDelete_Success(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     txID   := uuid.New()
@@ -260,7 +523,7 @@ func TestDelete_Success(t *testing.T) {
 func TestDelete_InvalidID(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     err := svc.Delete("bukan-uuid", uuid.New())
 
@@ -275,32 +538,180 @@ func TestDelete_InvalidID(t *testing.T) {
 func TestGetSummary_ZeroTransactions(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     mockTxRepo.On("GetSummaryByUser", userID, 1, 2026).
-        Return(0.0, 0.0, nil)
+        Return([]repository.CurrencyTotal{}, nil)
 
     summary, err := svc.GetSummary(userID, 1, 2026)
 
     assert.NoError(t, err)
-    assert.Equal(t, 0.0, summary.Income)
-    assert.Equal(t, 0.0, summary.Expense)
-    assert.Equal(t, 0.0, summary.Balance)
+    assert.Equal(t, domain.NewMoney(0), summary.Income)
+    assert.Equal(t, domain.NewMoney(0), summary.Expense)
+    assert.Equal(t, domain.NewMoney(0), summary.Balance)
 }
 
 func TestGetSummary_NegativeBalance(t *testing.T) {
     mockTxRepo  := new(repomock.MockTransactionRepository)
     mockCatRepo := new(repomock.MockCategoryRepository)
-    svc := service.NewTransactionService(mockTxRepo, mockCatRepo)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
 
     userID := uuid.New()
     // Pengeluaran lebih besar dari pemasukan
     mockTxRepo.On("GetSummaryByUser", userID, 2, 2026).
-        Return(1000000.0, 3000000.0, nil)
+        Return([]repository.CurrencyTotal{
+            {Type: domain.Income, Currency: "IDR", Amount: domain.NewMoney(1000000)},
+            {Type: domain.Expense, Currency: "IDR", Amount: domain.NewMoney(3000000)},
+        }, nil)
 
     summary, err := svc.GetSummary(userID, 2, 2026)
 
     assert.NoError(t, err)
-    assert.Equal(t, -2000000.0, summary.Balance)
-}
\ No newline at end of file
+    assert.Equal(t, domain.NewMoney(-2000000), summary.Balance)
+}
+func TestGetCategorySummary_RollsUpToParent(t *testing.T) {
+    mockTxRepo  := new(repomock.MockTransactionRepository)
+    mockCatRepo := new(repomock.MockCategoryRepository)
+    svc := service.NewTransactionService(mockTxRepo, mockCatRepo, new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID     := uuid.New()
+    makananID  := uuid.New()
+    restoranID := uuid.New()
+
+    mockTxRepo.On("FindAllByUser", userID, mock.MatchedBy(func(f repository.TransactionFilter) bool {
+        return f.Type == "expense" && f.StartDate != nil && f.EndDate != nil
+    })).Return([]domain.Transaction{
+        {CategoryID: &makananID, Type: domain.Expense, Amount: domain.NewMoney(100000)},
+        {CategoryID: &restoranID, Type: domain.Expense, Amount: domain.NewMoney(250000)},
+    }, nil)
+    mockCatRepo.On("FindAllByUser", userID, mock.AnythingOfType("repository.CategoryFilter")).
+        Return([]domain.Category{
+            {ID: makananID, Name: "Makanan"},
+            {ID: restoranID, Name: "Restoran", ParentID: &makananID},
+        }, nil)
+
+    summary, err := svc.GetCategorySummary(userID, domain.Expense, 2, 2026)
+
+    assert.NoError(t, err)
+    assert.Len(t, summary, 2)
+    assert.Equal(t, "Makanan", summary[0].Category.Name)
+    assert.Equal(t, domain.NewMoney(100000), summary[0].Amount)
+    assert.Equal(t, domain.NewMoney(350000), summary[0].Total)
+    assert.Equal(t, domain.NewMoney(250000), summary[1].Total)
+}
+
+// ──────────────────────────────────────────
+// GET PAGE TESTS
+// ──────────────────────────────────────────
+
+func TestGetPage_ReturnsCursorAndFilteredTotals(t *testing.T) {
+    mockTxRepo := new(repomock.MockTransactionRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    filter := repository.TransactionFilter{Search: "kopi"}
+    page := repository.PageQuery{Limit: 2, Sort: repository.TransactionSort{Field: repository.SortByDate, Desc: true}}
+    items := []domain.Transaction{
+        {ID: uuid.New(), Type: domain.Expense, Amount: domain.NewMoney(25000)},
+        {ID: uuid.New(), Type: domain.Expense, Amount: domain.NewMoney(30000)},
+    }
+    next := &repository.Cursor{Field: repository.SortByDate, Desc: true, Value: "2026-02-01T00:00:00Z", ID: items[1].ID}
+
+    mockTxRepo.On("FindPageByUser", userID, filter, page).Return(items, next, nil)
+    mockTxRepo.On("SumByFilter", userID, filter).
+        Return(int64(5), []repository.CurrencyTotal{
+            {Type: domain.Expense, Currency: "IDR", Amount: domain.NewMoney(120000)},
+            {Type: domain.Income, Currency: "IDR", Amount: domain.NewMoney(20000)},
+        }, nil)
+
+    result, err := svc.GetPage(userID, filter, page)
+
+    assert.NoError(t, err)
+    assert.Len(t, result.Items, 2)
+    // Total dari seluruh hasil filter, bukan hanya halaman ini
+    assert.Equal(t, int64(5), result.TotalCount)
+    assert.Equal(t, domain.NewMoney(120000), result.Totals.Expense)
+    assert.Equal(t, domain.NewMoney(-100000), result.Totals.Net)
+    assert.Equal(t, "IDR", result.Totals.Currency)
+
+    decoded, err := repository.DecodeCursor(result.NextCursor, page.Sort)
+    assert.NoError(t, err)
+    assert.Equal(t, *next, *decoded)
+}
+
+func TestGetPage_LastPageHasNoCursor(t *testing.T) {
+    mockTxRepo := new(repomock.MockTransactionRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    page := repository.PageQuery{Sort: repository.TransactionSort{Field: repository.SortByAmount}}
+    mockTxRepo.On("FindPageByUser", userID, repository.TransactionFilter{}, page).
+        Return([]domain.Transaction{}, nil, nil)
+    mockTxRepo.On("SumByFilter", userID, repository.TransactionFilter{}).
+        Return(int64(0), []repository.CurrencyTotal{}, nil)
+
+    result, err := svc.GetPage(userID, repository.TransactionFilter{}, page)
+
+    assert.NoError(t, err)
+    assert.Empty(t, result.NextCursor)
+    assert.Equal(t, int64(0), result.TotalCount)
+}
+
+func TestDecodeCursor_RejectsOtherSort(t *testing.T) {
+    byDate := repository.TransactionSort{Field: repository.SortByDate, Desc: true}
+    cursor := repository.Cursor{Field: repository.SortByDate, Desc: true, Value: "2026-02-01T00:00:00Z", ID: uuid.New()}
+
+    _, err := repository.DecodeCursor(cursor.Encode(), repository.TransactionSort{Field: repository.SortByAmount, Desc: true})
+    assert.Error(t, err)
+
+    _, err = repository.DecodeCursor(cursor.Encode(), repository.TransactionSort{Field: repository.SortByDate})
+    assert.Error(t, err)
+
+    _, err = repository.DecodeCursor("bukan-cursor", byDate)
+    assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+}
+
+// ──────────────────────────────────────────
+// SEARCH TESTS
+// ──────────────────────────────────────────
+
+func TestPrefixQuery(t *testing.T) {
+    assert.Equal(t, "kopi:* & sus:*", repository.PrefixQuery("  Kopi  sus"))
+    // Operator tsquery dan tanda baca dibuang
+    assert.Equal(t, "gaji:* & mei:*", repository.PrefixQuery("gaji & (mei)!:*"))
+    assert.Equal(t, "café:* & 2026:*", repository.PrefixQuery("Café, 2026"))
+    assert.Equal(t, "", repository.PrefixQuery("&|!"))
+}
+
+func TestSearch_Success(t *testing.T) {
+    mockTxRepo := new(repomock.MockTransactionRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID := uuid.New()
+    filter := repository.TransactionFilter{Type: "expense"}
+    hits := []repository.SearchHit{
+        {Transaction: domain.Transaction{ID: uuid.New(), Description: "Kopi susu"}, Rank: 0.5, Snippet: "<mark>Kopi</mark> susu"},
+    }
+    mockTxRepo.On("Search", userID, "kop", filter, 20).Return(hits, nil)
+
+    result, err := svc.Search(userID, "kop", filter, 20)
+
+    assert.NoError(t, err)
+    assert.Equal(t, hits, result)
+}
+
+func TestSearch_RejectsEmptyQuery(t *testing.T) {
+    mockTxRepo := new(repomock.MockTransactionRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository),
+        new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    for _, q := range []string{"", "   ", "&!"} {
+        _, err := svc.Search(uuid.New(), q, repository.TransactionFilter{}, 20)
+        assert.Error(t, err, q)
+    }
+    mockTxRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
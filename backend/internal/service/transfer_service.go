@@ -0,0 +1,205 @@
+package service
+
+import (
+    "errors"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+)
+
+type CreateTransferInput struct {
+    FromAccountID string  `json:"from_account_id" binding:"required"`
+    ToAccountID   string  `json:"to_account_id" binding:"required"`
+    Amount        domain.Money `json:"amount" binding:"required,gt=0"`
+    // ToAmount: nominal diterima jika mata uang akun berbeda; kosong =
+    // dikonversi dengan kurs di tanggal transfer
+    ToAmount      domain.Money `json:"to_amount" binding:"gte=0"`
+    Fee           domain.Money `json:"fee" binding:"gte=0"`
+    FeeCategoryID string       `json:"fee_category_id"` // wajib jika fee > 0
+    Description   string       `json:"description"`
+    Date          string       `json:"date" binding:"required"` // format: "2006-01-02"
+}
+
+type TransferService interface {
+    Create(userID uuid.UUID, input CreateTransferInput) (*domain.Transfer, error)
+    GetAll(userID uuid.UUID) ([]domain.Transfer, error)
+    GetByID(id string, userID uuid.UUID) (*domain.Transfer, error)
+    Delete(id string, userID uuid.UUID) error
+}
+
+type transferService struct {
+    transferRepo repository.TransferRepository
+    accountRepo  repository.AccountRepository
+    catRepo      repository.CategoryRepository
+    rateRepo     repository.ExchangeRateRepository
+}
+
+func NewTransferService(
+    transferRepo repository.TransferRepository,
+    accountRepo repository.AccountRepository,
+    catRepo repository.CategoryRepository,
+    rateRepo repository.ExchangeRateRepository,
+) TransferService {
+    return &transferService{transferRepo, accountRepo, catRepo, rateRepo}
+}
+
+func (s *transferService) Create(userID uuid.UUID, input CreateTransferInput) (*domain.Transfer, error) {
+    from, err := s.findAccount(input.FromAccountID, userID)
+    if err != nil {
+        return nil, err
+    }
+    to, err := s.findAccount(input.ToAccountID, userID)
+    if err != nil {
+        return nil, err
+    }
+    if from.ID == to.ID {
+        return nil, errors.New("cannot transfer to the same account")
+    }
+
+    date, err := time.Parse("2006-01-02", input.Date)
+    if err != nil {
+        return nil, errors.New("invalid date format, use YYYY-MM-DD")
+    }
+
+    toAmount := input.Amount
+    if from.Currency != to.Currency {
+        toAmount = input.ToAmount
+        if toAmount == 0 {
+            toAmount, err = newCurrencyConverter(s.rateRepo, userID).
+                convert(input.Amount, from.Currency, to.Currency, date)
+            if err != nil {
+                return nil, err
+            }
+        }
+    } else if input.ToAmount != 0 && input.ToAmount != input.Amount {
+        return nil, errors.New("to_amount must equal amount for accounts in the same currency")
+    }
+
+    transfer := &domain.Transfer{
+        ID:            uuid.New(),
+        UserID:        userID,
+        FromAccountID: from.ID,
+        ToAccountID:   to.ID,
+        Amount:        input.Amount,
+        ToAmount:      toAmount,
+        Fee:           input.Fee,
+        Description:   input.Description,
+        Date:          date,
+    }
+
+    description := input.Description
+    if description == "" {
+        description = "Transfer " + from.Name + " → " + to.Name
+    }
+
+    transfer.Legs = []domain.Transaction{
+        {
+            ID:          uuid.New(),
+            UserID:      userID,
+            AccountID:   from.ID,
+            Type:        domain.TransferOut,
+            Amount:      input.Amount,
+            Currency:    from.Currency,
+            Description: description,
+            Date:        date,
+        },
+        {
+            ID:          uuid.New(),
+            UserID:      userID,
+            AccountID:   to.ID,
+            Type:        domain.TransferIn,
+            Amount:      toAmount,
+            Currency:    to.Currency,
+            Description: description,
+            Date:        date,
+        },
+    }
+
+    // Biaya transfer adalah pengeluaran sungguhan, jadi dicatat sebagai
+    // expense agar tetap terhitung di summary dan budget
+    if input.Fee > 0 {
+        cat, err := s.feeCategory(input.FeeCategoryID, userID)
+        if err != nil {
+            return nil, err
+        }
+        transfer.Legs = append(transfer.Legs, domain.Transaction{
+            ID:          uuid.New(),
+            UserID:      userID,
+            AccountID:   from.ID,
+            CategoryID:  &cat.ID,
+            Type:        domain.Expense,
+            Amount:      input.Fee,
+            Currency:    from.Currency,
+            Description: "Biaya " + description,
+            Date:        date,
+        })
+    }
+
+    if err := s.transferRepo.Create(transfer); err != nil {
+        return nil, err
+    }
+
+    return s.transferRepo.FindByID(transfer.ID, userID)
+}
+
+func (s *transferService) findAccount(id string, userID uuid.UUID) (*domain.Account, error) {
+    accountID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid account id")
+    }
+    account, err := s.accountRepo.FindByID(accountID, userID)
+    if err != nil {
+        return nil, errors.New("account not found")
+    }
+    if account.IsArchived {
+        return nil, errors.New("account is archived")
+    }
+    return account, nil
+}
+
+func (s *transferService) feeCategory(id string, userID uuid.UUID) (*domain.Category, error) {
+    if id == "" {
+        return nil, errors.New("fee_category_id is required when fee is set")
+    }
+    catID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid fee_category_id")
+    }
+    cat, err := s.catRepo.FindByID(catID, userID)
+    if err != nil {
+        return nil, errors.New("category not found")
+    }
+    if cat.IsArchived {
+        return nil, errors.New("category is archived")
+    }
+    if !cat.Allows(domain.Expense) {
+        return nil, errCategoryKind(cat, domain.Expense)
+    }
+    return cat, nil
+}
+
+func (s *transferService) GetAll(userID uuid.UUID) ([]domain.Transfer, error) {
+    return s.transferRepo.FindAllByUser(userID)
+}
+
+func (s *transferService) GetByID(id string, userID uuid.UUID) (*domain.Transfer, error) {
+    transferID, err := uuid.Parse(id)
+    if err != nil {
+        return nil, errors.New("invalid transfer id")
+    }
+    transfer, err := s.transferRepo.FindByID(transferID, userID)
+    if err != nil {
+        return nil, errors.New("transfer not found")
+    }
+    return transfer, nil
+}
+
+func (s *transferService) Delete(id string, userID uuid.UUID) error {
+    transfer, err := s.GetByID(id, userID)
+    if err != nil {
+        return err
+    }
+    return s.transferRepo.Delete(transfer.ID, userID)
+}
@@ -0,0 +1,181 @@
+package service_test
+
+import (
+    "testing"
+
+    "github.com/google/uuid"
+    "github.com/myfarism/finance-tracker/internal/domain"
+    "github.com/myfarism/finance-tracker/internal/repository"
+    repomock "github.com/myfarism/finance-tracker/internal/repository/mock"
+    "github.com/myfarism/finance-tracker/internal/service"
+    "github.com/myfarism/finance-tracker/pkg/storage"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+)
+
+// ──────────────────────────────────────────
+// CREATE TRANSFER TESTS
+// ──────────────────────────────────────────
+
+func TestCreateTransfer_CreatesLinkedLegs(t *testing.T) {
+    mockTransferRepo := new(repomock.MockTransferRepository)
+    mockAccountRepo  := new(repomock.MockAccountRepository)
+    mockCatRepo      := new(repomock.MockCategoryRepository)
+    svc := service.NewTransferService(mockTransferRepo, mockAccountRepo, mockCatRepo, new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    bank   := &domain.Account{ID: uuid.New(), UserID: userID, Name: "BCA"}
+    gopay  := &domain.Account{ID: uuid.New(), UserID: userID, Name: "GoPay"}
+
+    mockAccountRepo.On("FindByID", bank.ID, userID).Return(bank, nil)
+    mockAccountRepo.On("FindByID", gopay.ID, userID).Return(gopay, nil)
+
+    var saved *domain.Transfer
+    mockTransferRepo.On("Create", mock.AnythingOfType("*domain.Transfer")).
+        Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.Transfer) }).
+        Return(nil)
+    mockTransferRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
+        Return(&domain.Transfer{ID: uuid.New(), UserID: userID}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransferInput{
+        FromAccountID: bank.ID.String(),
+        ToAccountID:   gopay.ID.String(),
+        Amount:        domain.NewMoney(200000),
+        Date:          "2026-02-20",
+    })
+
+    assert.NoError(t, err)
+    assert.Len(t, saved.Legs, 2)
+    assert.Equal(t, domain.TransferOut, saved.Legs[0].Type)
+    assert.Equal(t, bank.ID, saved.Legs[0].AccountID)
+    assert.Equal(t, domain.TransferIn, saved.Legs[1].Type)
+    assert.Equal(t, gopay.ID, saved.Legs[1].AccountID)
+    assert.Nil(t, saved.Legs[0].CategoryID)
+    mockCatRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransfer_FeeBecomesExpenseLeg(t *testing.T) {
+    mockTransferRepo := new(repomock.MockTransferRepository)
+    mockAccountRepo  := new(repomock.MockAccountRepository)
+    mockCatRepo      := new(repomock.MockCategoryRepository)
+    svc := service.NewTransferService(mockTransferRepo, mockAccountRepo, mockCatRepo, new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    bank   := &domain.Account{ID: uuid.New(), UserID: userID, Name: "BCA"}
+    ovo    := &domain.Account{ID: uuid.New(), UserID: userID, Name: "OVO"}
+    feeCat := &domain.Category{ID: uuid.New(), Name: "Tagihan", Kind: domain.CategoryKindExpense}
+
+    mockAccountRepo.On("FindByID", bank.ID, userID).Return(bank, nil)
+    mockAccountRepo.On("FindByID", ovo.ID, userID).Return(ovo, nil)
+    mockCatRepo.On("FindByID", feeCat.ID, userID).Return(feeCat, nil)
+
+    var saved *domain.Transfer
+    mockTransferRepo.On("Create", mock.AnythingOfType("*domain.Transfer")).
+        Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.Transfer) }).
+        Return(nil)
+    mockTransferRepo.On("FindByID", mock.AnythingOfType("uuid.UUID"), userID).
+        Return(&domain.Transfer{ID: uuid.New(), UserID: userID}, nil)
+
+    _, err := svc.Create(userID, service.CreateTransferInput{
+        FromAccountID: bank.ID.String(),
+        ToAccountID:   ovo.ID.String(),
+        Amount:        domain.NewMoney(100000),
+        Fee:           domain.NewMoney(2500),
+        FeeCategoryID: feeCat.ID.String(),
+        Date:          "2026-02-20",
+    })
+
+    assert.NoError(t, err)
+    assert.Len(t, saved.Legs, 3)
+    fee := saved.Legs[2]
+    assert.Equal(t, domain.Expense, fee.Type)
+    assert.Equal(t, domain.NewMoney(2500), fee.Amount)
+    assert.Equal(t, bank.ID, fee.AccountID)
+    assert.Equal(t, feeCat.ID, *fee.CategoryID)
+}
+
+func TestCreateTransfer_FeeWithoutCategory(t *testing.T) {
+    mockTransferRepo := new(repomock.MockTransferRepository)
+    mockAccountRepo  := new(repomock.MockAccountRepository)
+    svc := service.NewTransferService(mockTransferRepo, mockAccountRepo, new(repomock.MockCategoryRepository), new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    bank   := &domain.Account{ID: uuid.New(), UserID: userID}
+    ovo    := &domain.Account{ID: uuid.New(), UserID: userID}
+
+    mockAccountRepo.On("FindByID", bank.ID, userID).Return(bank, nil)
+    mockAccountRepo.On("FindByID", ovo.ID, userID).Return(ovo, nil)
+
+    _, err := svc.Create(userID, service.CreateTransferInput{
+        FromAccountID: bank.ID.String(),
+        ToAccountID:   ovo.ID.String(),
+        Amount:        domain.NewMoney(100000),
+        Fee:           domain.NewMoney(2500),
+        Date:          "2026-02-20",
+    })
+
+    assert.EqualError(t, err, "fee_category_id is required when fee is set")
+    mockTransferRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateTransfer_SameAccount(t *testing.T) {
+    mockTransferRepo := new(repomock.MockTransferRepository)
+    mockAccountRepo  := new(repomock.MockAccountRepository)
+    svc := service.NewTransferService(mockTransferRepo, mockAccountRepo, new(repomock.MockCategoryRepository), new(repomock.MockExchangeRateRepository))
+
+    userID := uuid.New()
+    bank   := &domain.Account{ID: uuid.New(), UserID: userID}
+    mockAccountRepo.On("FindByID", bank.ID, userID).Return(bank, nil)
+
+    _, err := svc.Create(userID, service.CreateTransferInput{
+        FromAccountID: bank.ID.String(),
+        ToAccountID:   bank.ID.String(),
+        Amount:        domain.NewMoney(100000),
+        Date:          "2026-02-20",
+    })
+
+    assert.EqualError(t, err, "cannot transfer to the same account")
+    mockTransferRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// ──────────────────────────────────────────
+// TRANSFER EFFECT TESTS
+// ──────────────────────────────────────────
+
+func TestDeleteTransaction_TransferLegRejected(t *testing.T) {
+    mockTxRepo := new(repomock.MockTransactionRepository)
+    svc := service.NewTransactionService(mockTxRepo, new(repomock.MockCategoryRepository), new(repomock.MockAccountRepository), idrUserRepo(), new(repomock.MockExchangeRateRepository), new(repomock.MockTagRepository), noRules(), storage.NewMemoryStorage())
+
+    userID     := uuid.New()
+    transferID := uuid.New()
+    leg := &domain.Transaction{ID: uuid.New(), UserID: userID, TransferID: &transferID, Type: domain.TransferOut}
+    mockTxRepo.On("FindByID", leg.ID, userID).Return(leg, nil)
+
+    err := svc.Delete(leg.ID.String(), userID)
+
+    assert.Error(t, err)
+    mockTxRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestGetAllAccounts_TransfersMoveBalances(t *testing.T) {
+    mockAccountRepo := new(repomock.MockAccountRepository)
+    svc := service.NewAccountService(mockAccountRepo, idrUserRepo())
+
+    userID := uuid.New()
+    bank   := domain.Account{ID: uuid.New(), UserID: userID, OpeningBalance: domain.NewMoney(1000000)}
+    gopay  := domain.Account{ID: uuid.New(), UserID: userID}
+
+    mockAccountRepo.On("FindAllByUser", userID, false).
+        Return([]domain.Account{bank, gopay}, nil)
+    mockAccountRepo.On("SumTransactions", userID, mock.Anything).
+        Return([]repository.AccountTotal{
+            {AccountID: bank.ID, Expense: domain.NewMoney(2500), TransferOut: domain.NewMoney(200000)},
+            {AccountID: gopay.ID, TransferIn: domain.NewMoney(200000)},
+        }, nil)
+
+    result, err := svc.GetAll(userID, false)
+
+    assert.NoError(t, err)
+    assert.Equal(t, domain.NewMoney(797500), result[0].Balance)
+    assert.Equal(t, domain.NewMoney(200000), result[1].Balance)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/myfarism/finance-tracker/internal/domain"
+	"github.com/myfarism/finance-tracker/pkg/otp"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -45,39 +46,334 @@ func Connect() {
         log.Fatal("Failed to connect to database:", err)
     }
 
+    migrateDefaultAccounts(db)
+    migrateMoneyColumns(db)
+
     // Auto migrate semua tabel
     db.AutoMigrate(
         &domain.User{},
         &domain.Category{},
+        &domain.Account{},
+        &domain.Transfer{},
+        &domain.RecurringRule{},
+        &domain.Tag{},
         &domain.Transaction{},
+        &domain.TransactionSplit{},
+        &domain.Attachment{},
+        &domain.DuplicateDismissal{},
+        &domain.TransactionRule{},
         &domain.Budget{},
+        &domain.Session{},
+        &domain.RefreshToken{},
+        &otp.OTPCode{},
+        &otp.OTPSend{},
+        &domain.ExchangeRate{},
     )
+    migrateSearchIndex(db)
     seedCategories(db)
+    backfillCategoryKinds(db)
+    scopeLegacyCategories(db)
 
     DB = db
     log.Println("✅ Database connected successfully")
 }
 
+// defaultCategories adalah kategori sistem (user_id NULL), read-only dan
+// terlihat oleh semua user
+func defaultCategories() []domain.Category {
+    return []domain.Category{
+        {ID: uuid.New(), Name: "Gaji", Icon: "💼", Kind: domain.CategoryKindIncome},
+        {ID: uuid.New(), Name: "Freelance", Icon: "💻", Kind: domain.CategoryKindIncome},
+        {ID: uuid.New(), Name: "Investasi", Icon: "📈", Kind: domain.CategoryKindIncome},
+        {ID: uuid.New(), Name: "Makanan", Icon: "🍜", Kind: domain.CategoryKindExpense},
+        {ID: uuid.New(), Name: "Transportasi", Icon: "🚗", Kind: domain.CategoryKindExpense},
+        {ID: uuid.New(), Name: "Belanja", Icon: "🛍️", Kind: domain.CategoryKindExpense},
+        {ID: uuid.New(), Name: "Kesehatan", Icon: "🏥", Kind: domain.CategoryKindExpense},
+        {ID: uuid.New(), Name: "Hiburan", Icon: "🎮", Kind: domain.CategoryKindExpense},
+        {ID: uuid.New(), Name: "Tagihan", Icon: "📄", Kind: domain.CategoryKindExpense},
+        {ID: uuid.New(), Name: "Lainnya", Icon: "📦", Kind: domain.CategoryKindBoth},
+    }
+}
+
 func seedCategories(db *gorm.DB) {
     var count int64
-    db.Model(&domain.Category{}).Count(&count)
+    db.Model(&domain.Category{}).Where("user_id IS NULL").Count(&count)
     if count > 0 {
         return // sudah ada data, skip
     }
 
-    categories := []domain.Category{
-        {ID: uuid.New(), Name: "Gaji", Icon: "💼"},
-        {ID: uuid.New(), Name: "Freelance", Icon: "💻"},
-        {ID: uuid.New(), Name: "Investasi", Icon: "📈"},
-        {ID: uuid.New(), Name: "Makanan", Icon: "🍜"},
-        {ID: uuid.New(), Name: "Transportasi", Icon: "🚗"},
-        {ID: uuid.New(), Name: "Belanja", Icon: "🛍️"},
-        {ID: uuid.New(), Name: "Kesehatan", Icon: "🏥"},
-        {ID: uuid.New(), Name: "Hiburan", Icon: "🎮"},
-        {ID: uuid.New(), Name: "Tagihan", Icon: "📄"},
-        {ID: uuid.New(), Name: "Lainnya", Icon: "📦"},
-    }
-
+    categories := defaultCategories()
     db.Create(&categories)
     log.Println("✅ Categories seeded")
 }
+
+// backfillCategoryKinds memberi kind yang benar untuk kategori sistem yang
+// di-seed sebelum kolom kind ada (saat migrasi semuanya menjadi "both")
+func backfillCategoryKinds(db *gorm.DB) {
+    for _, c := range defaultCategories() {
+        if c.Kind == domain.CategoryKindBoth {
+            continue
+        }
+        db.Model(&domain.Category{}).
+            Where("user_id IS NULL AND name = ? AND kind = ?", c.Name, domain.CategoryKindBoth).
+            Update("kind", c.Kind)
+    }
+}
+
+// legacyCategoryRefs: semua pemakaian kategori per user
+const legacyCategoryRefs = `
+    SELECT category_id, user_id FROM transactions WHERE category_id IS NOT NULL
+    UNION
+    SELECT s.category_id, t.user_id FROM transaction_splits s JOIN transactions t ON t.id = s.transaction_id
+    UNION
+    SELECT category_id, user_id FROM budgets
+    UNION
+    SELECT category_id, user_id FROM recurring_rules
+    UNION
+    SELECT category_id, user_id FROM transaction_rules WHERE category_id IS NOT NULL
+    UNION
+    SELECT parent_id, user_id FROM categories WHERE parent_id IS NOT NULL AND user_id IS NOT NULL`
+
+// scopeLegacyCategories memindahkan kategori buatan user dari masa sebelum
+// kategori per-user ke pemiliknya, supaya tidak terlihat sebagai kategori
+// sistem oleh user lain. Kategori non-default yang hanya dipakai satu user
+// menjadi milik user tersebut; yang dipakai beberapa user disalin per user
+// lalu transaksi, split, budget, recurring, rule dan sub-kategori user itu
+// dipindah ke salinannya; yang tidak dipakai siapa pun dihapus.
+func scopeLegacyCategories(db *gorm.DB) {
+    var names []string
+    for _, c := range defaultCategories() {
+        names = append(names, c.Name)
+    }
+
+    var owned, copied, deleted int64
+    err := db.Transaction(func(tx *gorm.DB) error {
+        result := tx.Exec(`
+            UPDATE categories c SET user_id = owner.user_id
+            FROM (
+                SELECT category_id, MIN(user_id::text)::uuid AS user_id
+                FROM (`+legacyCategoryRefs+`) refs
+                GROUP BY category_id
+                HAVING COUNT(DISTINCT user_id) = 1
+            ) owner
+            WHERE c.id = owner.category_id AND c.user_id IS NULL AND c.name NOT IN ?`, names)
+        if result.Error != nil {
+            return result.Error
+        }
+        owned = result.RowsAffected
+
+        // Sisa kategori legacy yang masih dipakai = dipakai beberapa user
+        err := tx.Exec(`
+            CREATE TEMP TABLE legacy_category_copies ON COMMIT DROP AS
+            SELECT c.id AS old_id, refs.user_id, gen_random_uuid() AS new_id
+            FROM categories c
+            JOIN (`+legacyCategoryRefs+`) refs ON refs.category_id = c.id
+            WHERE c.user_id IS NULL AND c.name NOT IN ?`, names).Error
+        if err != nil {
+            return err
+        }
+
+        result = tx.Exec(`
+            INSERT INTO categories (id, user_id, parent_id, name, icon, kind, is_archived)
+            SELECT m.new_id, m.user_id, c.parent_id, c.name, c.icon, c.kind, c.is_archived
+            FROM legacy_category_copies m JOIN categories c ON c.id = m.old_id`)
+        if result.Error != nil {
+            return result.Error
+        }
+        copied = result.RowsAffected
+
+        repoint := []string{
+            `UPDATE transactions t SET category_id = m.new_id FROM legacy_category_copies m
+             WHERE t.category_id = m.old_id AND t.user_id = m.user_id`,
+            `UPDATE transaction_splits s SET category_id = m.new_id FROM legacy_category_copies m, transactions t
+             WHERE s.transaction_id = t.id AND s.category_id = m.old_id AND t.user_id = m.user_id`,
+            `UPDATE budgets b SET category_id = m.new_id FROM legacy_category_copies m
+             WHERE b.category_id = m.old_id AND b.user_id = m.user_id`,
+            `UPDATE recurring_rules r SET category_id = m.new_id FROM legacy_category_copies m
+             WHERE r.category_id = m.old_id AND r.user_id = m.user_id`,
+            `UPDATE transaction_rules r SET category_id = m.new_id FROM legacy_category_copies m
+             WHERE r.category_id = m.old_id AND r.user_id = m.user_id`,
+            `UPDATE categories c SET parent_id = m.new_id FROM legacy_category_copies m
+             WHERE c.parent_id = m.old_id AND c.user_id = m.user_id`,
+        }
+        for _, query := range repoint {
+            if err := tx.Exec(query).Error; err != nil {
+                return err
+            }
+        }
+
+        result = tx.Exec("DELETE FROM categories WHERE user_id IS NULL AND name NOT IN ?", names)
+        if result.Error != nil {
+            return result.Error
+        }
+        deleted = result.RowsAffected
+        return nil
+    })
+    if err != nil {
+        log.Println("⚠️ Failed to scope legacy categories:", err)
+        return
+    }
+    if owned+copied+deleted > 0 {
+        log.Printf("✅ Legacy categories: %d assigned to their owners, %d copied per user, %d removed", owned, copied, deleted)
+    }
+}
+
+// migrateDefaultAccounts dijalankan sebelum AutoMigrate: transaksi dari masa
+// sebelum ada akun dipindah ke akun default pemiliknya, baru kemudian
+// account_id dijadikan NOT NULL
+func migrateDefaultAccounts(db *gorm.DB) {
+    m := db.Migrator()
+    if !m.HasTable(&domain.Transaction{}) || m.HasColumn(&domain.Transaction{}, "AccountID") {
+        return // database baru atau sudah dimigrasi
+    }
+    if err := m.AutoMigrate(&domain.Account{}); err != nil {
+        log.Println("⚠️ Failed to create accounts table:", err)
+        return
+    }
+
+    err := db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Exec("ALTER TABLE transactions ADD COLUMN account_id uuid").Error; err != nil {
+            return err
+        }
+
+        err := tx.Exec(`
+            INSERT INTO accounts (id, user_id, name, type, opening_balance, is_default, is_archived, created_at, updated_at)
+            SELECT gen_random_uuid(), u.user_id, ?, ?, 0, true, false, NOW(), NOW()
+            FROM (SELECT DISTINCT user_id FROM transactions) u
+            WHERE NOT EXISTS (
+                SELECT 1 FROM accounts a WHERE a.user_id = u.user_id AND a.is_default
+            )`, domain.DefaultAccountName, domain.AccountCash).Error
+        if err != nil {
+            return err
+        }
+
+        err = tx.Exec(`
+            UPDATE transactions t SET account_id = a.id
+            FROM accounts a
+            WHERE a.user_id = t.user_id AND a.is_default`).Error
+        if err != nil {
+            return err
+        }
+
+        return tx.Exec("ALTER TABLE transactions ALTER COLUMN account_id SET NOT NULL").Error
+    })
+    if err != nil {
+        log.Println("⚠️ Failed to migrate transactions to default accounts:", err)
+        return
+    }
+    log.Println("✅ Existing transactions moved to default accounts")
+}
+
+// migrateMoneyColumns mengubah kolom nominal lama (double precision) menjadi
+// NUMERIC(18,2), dibulatkan ke sen terdekat, sebelum AutoMigrate berjalan
+func migrateMoneyColumns(db *gorm.DB) {
+    columns := []struct{ table, column string }{
+        {"transactions", "amount"},
+        {"budgets", "amount"},
+        {"accounts", "opening_balance"},
+        {"transfers", "amount"},
+        {"transfers", "fee"},
+    }
+
+    for _, c := range columns {
+        var dataType string
+        db.Raw(`SELECT data_type FROM information_schema.columns
+            WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?`,
+            c.table, c.column).Scan(&dataType)
+        if dataType != "double precision" {
+            continue // tabel baru atau sudah dimigrasi
+        }
+
+        err := db.Exec(fmt.Sprintf(
+            "ALTER TABLE %s ALTER COLUMN %s TYPE numeric(18,2) USING ROUND(%s::numeric, 2)",
+            c.table, c.column, c.column)).Error
+        if err != nil {
+            log.Printf("⚠️ Failed to migrate %s.%s to numeric: %v", c.table, c.column, err)
+            continue
+        }
+        log.Printf("✅ %s.%s migrated to numeric", c.table, c.column)
+    }
+}
+
+// migrateSearchIndex menyiapkan full-text search transaksi. search_vector
+// berisi deskripsi & payee (bobot A), catatan split (B) dan nama kategori
+// (C). Karena sumbernya lintas tabel, kolom ini diisi trigger: transaksi
+// dihitung ulang setiap insert/update, dan perubahan split atau nama
+// kategori memicu update di transaksi terkait.
+func migrateSearchIndex(db *gorm.DB) {
+    statements := []string{
+        `ALTER TABLE transactions ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+        `CREATE INDEX IF NOT EXISTS idx_transactions_search ON transactions USING GIN (search_vector)`,
+
+        `CREATE OR REPLACE FUNCTION transactions_search_vector() RETURNS trigger AS $$
+        BEGIN
+            NEW.search_vector :=
+                setweight(to_tsvector('simple', coalesce(NEW.description, '')), 'A') ||
+                setweight(to_tsvector('simple', coalesce(NEW.payee, '')), 'A') ||
+                setweight(to_tsvector('simple', coalesce((
+                    SELECT string_agg(note, ' ') FROM transaction_splits
+                    WHERE transaction_id = NEW.id), '')), 'B') ||
+                setweight(to_tsvector('simple', coalesce((
+                    SELECT string_agg(name, ' ') FROM categories
+                    WHERE id = NEW.category_id
+                       OR id IN (SELECT category_id FROM transaction_splits WHERE transaction_id = NEW.id)), '')), 'C');
+            RETURN NEW;
+        END
+        $$ LANGUAGE plpgsql`,
+        `DROP TRIGGER IF EXISTS trg_transactions_search ON transactions`,
+        `CREATE TRIGGER trg_transactions_search BEFORE INSERT OR UPDATE ON transactions
+            FOR EACH ROW EXECUTE FUNCTION transactions_search_vector()`,
+
+        `CREATE OR REPLACE FUNCTION transaction_splits_touch_search() RETURNS trigger AS $$
+        BEGIN
+            IF TG_OP <> 'INSERT' THEN
+                UPDATE transactions SET search_vector = NULL WHERE id = OLD.transaction_id;
+            END IF;
+            IF TG_OP <> 'DELETE' THEN
+                UPDATE transactions SET search_vector = NULL WHERE id = NEW.transaction_id;
+            END IF;
+            RETURN NULL;
+        END
+        $$ LANGUAGE plpgsql`,
+        `DROP TRIGGER IF EXISTS trg_transaction_splits_search ON transaction_splits`,
+        `CREATE TRIGGER trg_transaction_splits_search AFTER INSERT OR UPDATE OR DELETE ON transaction_splits
+            FOR EACH ROW EXECUTE FUNCTION transaction_splits_touch_search()`,
+
+        `CREATE OR REPLACE FUNCTION categories_touch_search() RETURNS trigger AS $$
+        BEGIN
+            IF NEW.name IS DISTINCT FROM OLD.name THEN
+                UPDATE transactions SET search_vector = NULL
+                WHERE category_id = NEW.id
+                   OR id IN (SELECT transaction_id FROM transaction_splits WHERE category_id = NEW.id);
+            END IF;
+            RETURN NULL;
+        END
+        $$ LANGUAGE plpgsql`,
+        `DROP TRIGGER IF EXISTS trg_categories_search ON categories`,
+        `CREATE TRIGGER trg_categories_search AFTER UPDATE OF name ON categories
+            FOR EACH ROW EXECUTE FUNCTION categories_touch_search()`,
+    }
+
+    err := db.Transaction(func(tx *gorm.DB) error {
+        for _, stmt := range statements {
+            if err := tx.Exec(stmt).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        log.Println("⚠️ Failed to set up transaction search index:", err)
+        return
+    }
+
+    // Isi search_vector transaksi lama; trigger yang menghitungnya
+    result := db.Exec("UPDATE transactions SET search_vector = NULL WHERE search_vector IS NULL")
+    if result.Error != nil {
+        log.Println("⚠️ Failed to backfill transaction search index:", result.Error)
+        return
+    }
+    if result.RowsAffected > 0 {
+        log.Printf("✅ %d transactions indexed for search", result.RowsAffected)
+    }
+}
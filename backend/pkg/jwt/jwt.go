@@ -1,8 +1,13 @@
 package jwtpkg
 
 import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
     "errors"
     "os"
+    "strconv"
     "time"
 
     "github.com/golang-jwt/jwt/v5"
@@ -10,17 +15,36 @@ import (
 )
 
 type Claims struct {
-    UserID uuid.UUID `json:"user_id"`
-    Email  string    `json:"email"`
+    UserID    uuid.UUID `json:"user_id"`
+    Email     string    `json:"email"`
+    SessionID uuid.UUID `json:"sid"`
     jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uuid.UUID, email string) (string, error) {
+// Access token sengaja dibuat pendek; client memperpanjang lewat refresh token
+func AccessTokenTTL() time.Duration {
+    minutes, _ := strconv.Atoi(os.Getenv("ACCESS_TOKEN_TTL_MINUTES"))
+    if minutes <= 0 {
+        minutes = 15
+    }
+    return time.Duration(minutes) * time.Minute
+}
+
+func RefreshTokenTTL() time.Duration {
+    days, _ := strconv.Atoi(os.Getenv("REFRESH_TOKEN_TTL_DAYS"))
+    if days <= 0 {
+        days = 30
+    }
+    return time.Duration(days) * 24 * time.Hour
+}
+
+func GenerateToken(userID uuid.UUID, email string, sessionID uuid.UUID) (string, error) {
     claims := Claims{
-        UserID: userID,
-        Email:  email,
+        UserID:    userID,
+        Email:     email,
+        SessionID: sessionID,
         RegisteredClaims: jwt.RegisteredClaims{
-            ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+            ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL())),
             IssuedAt:  jwt.NewNumericDate(time.Now()),
         },
     }
@@ -47,4 +71,20 @@ func ValidateToken(tokenStr string) (*Claims, error) {
     }
 
     return claims, nil
-}
\ No newline at end of file
+}
+
+// GenerateRefreshToken return token acak (dikirim ke client) beserta hash-nya
+// (disimpan di database)
+func GenerateRefreshToken() (token string, hash string, err error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", "", err
+    }
+    token = base64.RawURLEncoding.EncodeToString(b)
+    return token, HashRefreshToken(token), nil
+}
+
+func HashRefreshToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
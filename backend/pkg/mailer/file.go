@@ -0,0 +1,53 @@
+package mailer
+
+import (
+    "fmt"
+    "log"
+    "os"
+    "path/filepath"
+    "strings"
+    "time"
+)
+
+// FileMailer menulis setiap email sebagai file .eml ke sebuah direktori.
+// Dipakai untuk development dan test: buka file-nya dengan mail client
+// untuk melihat hasil render.
+type FileMailer struct {
+    dir  string
+    from string
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+    return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(msg Message) error {
+    if err := os.MkdirAll(m.dir, 0o755); err != nil {
+        return err
+    }
+
+    recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+    name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), recipient)
+
+    f, err := os.Create(filepath.Join(m.dir, name))
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    _, err = buildMessage(m.from, msg).WriteTo(f)
+    return err
+}
+
+// LogMailer hanya mencetak email ke log, termasuk versi teksnya
+// (berisi kode OTP) supaya bisa dipakai tanpa akun email sama sekali
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+    return &LogMailer{}
+}
+
+func (m *LogMailer) Send(msg Message) error {
+    log.Printf("📧 [mail] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Text)
+    return nil
+}
@@ -0,0 +1,27 @@
+package mailer
+
+import (
+    "github.com/resendlabs/resend-go"
+)
+
+type ResendMailer struct {
+    client *resend.Client
+    from   string
+}
+
+func NewResendMailer(apiKey, from string) *ResendMailer {
+    return &ResendMailer{client: resend.NewClient(apiKey), from: from}
+}
+
+func (m *ResendMailer) Send(msg Message) error {
+    params := &resend.SendEmailRequest{
+        From:    m.from,
+        To:      []string{msg.To},
+        Subject: msg.Subject,
+        Html:    msg.HTML,
+        Text:    msg.Text,
+    }
+
+    _, err := m.client.Emails.Send(params)
+    return err
+}
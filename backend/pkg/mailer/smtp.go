@@ -0,0 +1,37 @@
+package mailer
+
+import (
+    "gopkg.in/gomail.v2"
+)
+
+// SMTPMailer mengirim lewat server SMTP biasa (Gmail, atau MailHog/Mailpit
+// lokal tanpa auth: kosongkan username)
+type SMTPMailer struct {
+    dialer *gomail.Dialer
+    from   string
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+    if from == "" {
+        from = username
+    }
+    return &SMTPMailer{dialer: gomail.NewDialer(host, port, username, password), from: from}
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+    return m.dialer.DialAndSend(buildMessage(m.from, msg))
+}
+
+func buildMessage(from string, msg Message) *gomail.Message {
+    gm := gomail.NewMessage()
+    gm.SetHeader("From", from)
+    gm.SetHeader("To", msg.To)
+    gm.SetHeader("Subject", msg.Subject)
+    if msg.Text != "" {
+        gm.SetBody("text/plain", msg.Text)
+        gm.AddAlternative("text/html", msg.HTML)
+    } else {
+        gm.SetBody("text/html", msg.HTML)
+    }
+    return gm
+}
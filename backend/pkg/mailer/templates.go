@@ -0,0 +1,97 @@
+package mailer
+
+import (
+    "bytes"
+    "fmt"
+    "html/template"
+    "time"
+)
+
+// layout dipakai bersama oleh semua email; tiap pesan hanya mengisi
+// paragraf pembuka, kode (opsional) dan catatan penutup
+var layout = template.Must(template.New("layout").Parse(`
+<!DOCTYPE html>
+<html>
+<body style="font-family: -apple-system, sans-serif; background: #f8fafc; padding: 40px 0;">
+  <div style="max-width: 480px; margin: 0 auto; background: white; border-radius: 12px; border: 1px solid #e2e8f0; overflow: hidden;">
+    <div style="padding: 24px 32px; border-bottom: 1px solid #f1f5f9;">
+      <span style="font-size: 18px; font-weight: 600; color: #0f172a;">
+        finance<span style="color: #6366f1;">.</span>
+      </span>
+    </div>
+    <div style="padding: 32px;">
+      <p style="color: #334155; font-size: 15px; margin: 0 0 8px;">Halo, <strong>{{.Name}}</strong> 👋</p>
+      <p style="color: #64748b; font-size: 14px; margin: 0 0 24px;">
+        {{.Intro}}
+      </p>
+      {{- if .Code}}
+      <div style="background: #f8fafc; border: 1px solid #e2e8f0; border-radius: 8px; padding: 20px; text-align: center; margin-bottom: 24px;">
+        <span style="font-size: 36px; font-weight: 700; letter-spacing: 8px; color: #6366f1;">{{.Code}}</span>
+      </div>
+      {{- end}}
+      {{- if .Note}}
+      <p style="color: #94a3b8; font-size: 13px; margin: 0;">
+        {{.Note}}
+      </p>
+      {{- end}}
+    </div>
+  </div>
+</body>
+</html>
+`))
+
+type layoutData struct {
+    Name  string
+    Intro string
+    Code  string
+    Note  string
+}
+
+func render(to, subject string, data layoutData) Message {
+    var buf bytes.Buffer
+    // Template statis dan sudah di-parse saat init, Execute tidak akan gagal
+    _ = layout.Execute(&buf, data)
+
+    text := fmt.Sprintf("Halo, %s\n\n%s\n", data.Name, data.Intro)
+    if data.Code != "" {
+        text += "\n" + data.Code + "\n"
+    }
+    if data.Note != "" {
+        text += "\n" + data.Note + "\n"
+    }
+
+    return Message{To: to, Subject: subject, HTML: buf.String(), Text: text}
+}
+
+func OTPMessage(to, name, code string, validFor time.Duration) Message {
+    return render(to, "Kode Verifikasi - Finance Tracker", layoutData{
+        Name:  name,
+        Intro: "Gunakan kode berikut untuk verifikasi akun kamu:",
+        Code:  code,
+        Note:  fmt.Sprintf("Kode berlaku selama %d menit. Jangan bagikan kode ini kepada siapapun.", int(validFor.Minutes())),
+    })
+}
+
+func PasswordResetMessage(to, name, code string, validFor time.Duration) Message {
+    return render(to, "Reset Password - Finance Tracker", layoutData{
+        Name:  name,
+        Intro: "Gunakan kode berikut untuk reset password akun kamu:",
+        Code:  code,
+        Note:  fmt.Sprintf("Kode berlaku selama %d menit. Abaikan email ini jika kamu tidak meminta reset password.", int(validFor.Minutes())),
+    })
+}
+
+func WelcomeMessage(to, name string) Message {
+    return render(to, "Selamat Datang di Finance Tracker", layoutData{
+        Name:  name,
+        Intro: "Akun kamu sudah terverifikasi. Mulai catat pemasukan dan pengeluaran pertamamu sekarang!",
+    })
+}
+
+func PasswordChangedMessage(to, name string) Message {
+    return render(to, "Password Kamu Telah Diubah - Finance Tracker", layoutData{
+        Name:  name,
+        Intro: "Password akun kamu baru saja diubah dan semua sesi login lain telah dikeluarkan.",
+        Note:  "Jika ini bukan kamu, segera reset password lewat halaman lupa password.",
+    })
+}
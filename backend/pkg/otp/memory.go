@@ -0,0 +1,93 @@
+package otp
+
+import (
+    "sync"
+    "time"
+
+    "github.com/patrickmn/go-cache"
+)
+
+// MemoryStore menyimpan OTP di memori proses. Cocok untuk development atau
+// deployment satu replica; semua kode hilang saat restart.
+type MemoryStore struct {
+    cache *cache.Cache
+    // mu menjaga agar cek + update counter berjalan atomik
+    mu sync.Mutex
+}
+
+func NewMemoryStore() *MemoryStore {
+    // Cache dengan expiry 5 menit, cleanup tiap 10 menit
+    return &MemoryStore{cache: cache.New(5*time.Minute, 10*time.Minute)}
+}
+
+func key(purpose Purpose, email string) string {
+    return string(purpose) + ":" + email
+}
+
+func (s *MemoryStore) Save(purpose Purpose, email, code string, expiry time.Duration) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.cache.Set(key(purpose, email), code, expiry)
+    // Kode baru → counter percobaan mulai dari nol
+    s.cache.Delete("attempts:" + key(purpose, email))
+    return nil
+}
+
+func (s *MemoryStore) Allow(purpose Purpose, email string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    cooldownKey := "cooldown:" + key(purpose, email)
+    if _, until, found := s.cache.GetWithExpiration(cooldownKey); found {
+        return errCooldown(time.Until(until))
+    }
+
+    dailyKey := "daily:" + email
+    if count, until, found := s.cache.GetWithExpiration(dailyKey); found && count.(int) >= dailyLimit() {
+        return errDailyLimit(time.Until(until))
+    }
+
+    s.cache.Set(cooldownKey, true, resendCooldown())
+    if err := s.cache.Increment(dailyKey, 1); err != nil {
+        s.cache.Set(dailyKey, 1, 24*time.Hour)
+    }
+    return nil
+}
+
+func (s *MemoryStore) Verify(purpose Purpose, email, code string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    k := key(purpose, email)
+    lockKey := "lock:" + k
+    if _, until, found := s.cache.GetWithExpiration(lockKey); found {
+        return errLocked(time.Until(until))
+    }
+
+    stored, found := s.cache.Get(k)
+    if !found {
+        return ErrInvalid
+    }
+
+    if stored.(string) != code {
+        attemptsKey := "attempts:" + k
+        attempts, err := s.cache.IncrementInt(attemptsKey, 1)
+        if err != nil {
+            attempts = 1
+            s.cache.Set(attemptsKey, attempts, lockDuration())
+        }
+        if attempts >= maxAttempts() {
+            s.cache.Delete(k)
+            s.cache.Delete(attemptsKey)
+            s.cache.Set(lockKey, true, lockDuration())
+            return errLocked(lockDuration())
+        }
+        return ErrInvalid
+    }
+
+    // Hapus setelah berhasil diverifikasi (one-time use)
+    s.cache.Delete(k)
+    s.cache.Delete("attempts:" + k)
+    return nil
+}
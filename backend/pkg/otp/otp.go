@@ -2,14 +2,70 @@ package otp
 
 import (
     "crypto/rand"
+    "errors"
     "fmt"
+    "math"
+    "os"
+    "strconv"
     "time"
+)
+
+// Purpose membedakan OTP registrasi dan reset password, sehingga kode
+// untuk satu keperluan tidak bisa dipakai untuk keperluan lain
+type Purpose string
 
-    "github.com/patrickmn/go-cache"
+const (
+    PurposeRegister      Purpose = "register"
+    PurposeResetPassword Purpose = "reset_password"
 )
 
-// Cache dengan expiry 5 menit, cleanup tiap 10 menit
-var otpCache = cache.New(5*time.Minute, 10*time.Minute)
+var ErrInvalid = errors.New("kode OTP tidak valid atau sudah kadaluarsa")
+
+// Store menyimpan kode OTP beserta counter percobaan dan throttle pengiriman.
+// Implementasi: MemoryStore (satu proses) dan PostgresStore (multi replica).
+type Store interface {
+    // Save menyimpan kode baru dan me-reset counter percobaan
+    Save(purpose Purpose, email, code string, expiry time.Duration) error
+    // Allow dicek sebelum mengirim OTP: menolak jika masih cooldown atau
+    // sudah mencapai limit harian per email, lalu mencatat pengiriman ini
+    Allow(purpose Purpose, email string) error
+    // Verify memvalidasi kode. Setelah OTP_MAX_ATTEMPTS kali salah, kode
+    // dihapus dan email dikunci selama OTP_LOCK_MINUTES
+    Verify(purpose Purpose, email, code string) error
+}
+
+// RateLimitError dikembalikan saat kode terkunci karena terlalu banyak
+// percobaan, atau saat pengiriman ulang masih dalam cooldown / kena limit harian
+type RateLimitError struct {
+    Reason     string
+    RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+    return fmt.Sprintf("%s, coba lagi dalam %s", e.Reason, humanizeDuration(e.RetryAfter))
+}
+
+func humanizeDuration(d time.Duration) string {
+    if d < time.Minute {
+        return fmt.Sprintf("%d detik", int(math.Ceil(d.Seconds())))
+    }
+    if d < time.Hour {
+        return fmt.Sprintf("%d menit", int(math.Ceil(d.Minutes())))
+    }
+    return fmt.Sprintf("%d jam", int(math.Ceil(d.Hours())))
+}
+
+func errLocked(retryAfter time.Duration) error {
+    return &RateLimitError{Reason: "terlalu banyak percobaan", RetryAfter: retryAfter}
+}
+
+func errCooldown(retryAfter time.Duration) error {
+    return &RateLimitError{Reason: "tunggu sebelum meminta kode baru", RetryAfter: retryAfter}
+}
+
+func errDailyLimit(retryAfter time.Duration) error {
+    return &RateLimitError{Reason: "batas pengiriman kode hari ini tercapai", RetryAfter: retryAfter}
+}
 
 // Generate OTP 6 digit
 func Generate() string {
@@ -18,21 +74,26 @@ func Generate() string {
     return fmt.Sprintf("%06d", int(b[0])<<16|int(b[1])<<8|int(b[2]))[:6]
 }
 
-// Simpan OTP ke cache dengan key = email
-func Save(email, code string, expiry time.Duration) {
-    otpCache.Set(email, code, expiry)
+func envInt(name string, fallback int) int {
+    v, _ := strconv.Atoi(os.Getenv(name))
+    if v <= 0 {
+        return fallback
+    }
+    return v
 }
 
-// Verifikasi OTP
-func Verify(email, code string) bool {
-    stored, found := otpCache.Get(email)
-    if !found {
-        return false
-    }
-    if stored.(string) != code {
-        return false
-    }
-    // Hapus setelah berhasil diverifikasi (one-time use)
-    otpCache.Delete(email)
-    return true
+func maxAttempts() int {
+    return envInt("OTP_MAX_ATTEMPTS", 5)
+}
+
+func lockDuration() time.Duration {
+    return time.Duration(envInt("OTP_LOCK_MINUTES", 15)) * time.Minute
+}
+
+func resendCooldown() time.Duration {
+    return time.Duration(envInt("OTP_RESEND_COOLDOWN_SECONDS", 60)) * time.Second
+}
+
+func dailyLimit() int {
+    return envInt("OTP_DAILY_LIMIT", 10)
 }
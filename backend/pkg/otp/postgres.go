@@ -0,0 +1,175 @@
+package otp
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "os"
+    "time"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+)
+
+// OTPCode menyimpan state OTP per (purpose, email): kode aktif dalam bentuk
+// hash, counter percobaan, serta waktu lock/cooldown. Row tetap ada setelah
+// kode dipakai supaya lock dan cooldown tetap berlaku.
+type OTPCode struct {
+    ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+    Purpose     string     `gorm:"type:varchar(32);not null;uniqueIndex:idx_otp_purpose_email"`
+    Email       string     `gorm:"not null;uniqueIndex:idx_otp_purpose_email"`
+    CodeHash    string     `gorm:"type:varchar(64)"`
+    ExpiresAt   *time.Time
+    Attempts    int        `gorm:"not null;default:0"`
+    LockedUntil *time.Time
+    LastSentAt  *time.Time
+    UpdatedAt   time.Time
+}
+
+// OTPSend mencatat setiap pengiriman OTP untuk menghitung limit harian
+type OTPSend struct {
+    ID      uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+    Email   string    `gorm:"not null;index:idx_otp_send_email_time"`
+    Purpose string    `gorm:"type:varchar(32);not null"`
+    SentAt  time.Time `gorm:"not null;index:idx_otp_send_email_time"`
+}
+
+// PostgresStore menyimpan OTP di tabel otp_codes sehingga kode tetap valid
+// setelah restart dan bisa dipakai bersama oleh beberapa replica backend.
+// Kode hanya disimpan dalam bentuk HMAC.
+type PostgresStore struct {
+    db *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+    return &PostgresStore{db}
+}
+
+func hashCode(purpose Purpose, email, code string) string {
+    secret := os.Getenv("OTP_HASH_SECRET")
+    if secret == "" {
+        secret = os.Getenv("JWT_SECRET")
+    }
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(string(purpose) + ":" + email + ":" + code))
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// lockRecord memastikan row (purpose, email) ada lalu mengambilnya dengan
+// SELECT ... FOR UPDATE, supaya request paralel di replica lain menunggu
+func lockRecord(tx *gorm.DB, purpose Purpose, email string) (*OTPCode, error) {
+    err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+        Create(&OTPCode{Purpose: string(purpose), Email: email}).Error
+    if err != nil {
+        return nil, err
+    }
+
+    var record OTPCode
+    err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("purpose = ? AND email = ?", purpose, email).
+        First(&record).Error
+    if err != nil {
+        return nil, err
+    }
+    return &record, nil
+}
+
+func (s *PostgresStore) Save(purpose Purpose, email, code string, expiry time.Duration) error {
+    return s.db.Transaction(func(tx *gorm.DB) error {
+        record, err := lockRecord(tx, purpose, email)
+        if err != nil {
+            return err
+        }
+
+        expiresAt := time.Now().Add(expiry)
+        return tx.Model(record).Updates(map[string]interface{}{
+            "code_hash":  hashCode(purpose, email, code),
+            "expires_at": expiresAt,
+            "attempts":   0,
+        }).Error
+    })
+}
+
+func (s *PostgresStore) Allow(purpose Purpose, email string) error {
+    return s.db.Transaction(func(tx *gorm.DB) error {
+        record, err := lockRecord(tx, purpose, email)
+        if err != nil {
+            return err
+        }
+
+        now := time.Now()
+        if record.LastSentAt != nil {
+            if until := record.LastSentAt.Add(resendCooldown()); now.Before(until) {
+                return errCooldown(until.Sub(now))
+            }
+        }
+
+        windowStart := now.Add(-24 * time.Hour)
+        var sent []OTPSend
+        err = tx.Where("email = ? AND sent_at > ?", email, windowStart).
+            Order("sent_at ASC").
+            Find(&sent).Error
+        if err != nil {
+            return err
+        }
+        if len(sent) >= dailyLimit() {
+            // Slot berikutnya terbuka saat pengiriman tertua keluar dari window 24 jam
+            oldest := sent[len(sent)-dailyLimit()]
+            return errDailyLimit(oldest.SentAt.Add(24 * time.Hour).Sub(now))
+        }
+
+        if err := tx.Model(record).Update("last_sent_at", now).Error; err != nil {
+            return err
+        }
+        return tx.Create(&OTPSend{Email: email, Purpose: string(purpose), SentAt: now}).Error
+    })
+}
+
+func (s *PostgresStore) Verify(purpose Purpose, email, code string) error {
+    var result error
+    err := s.db.Transaction(func(tx *gorm.DB) error {
+        record, err := lockRecord(tx, purpose, email)
+        if err != nil {
+            return err
+        }
+
+        now := time.Now()
+        if record.LockedUntil != nil && now.Before(*record.LockedUntil) {
+            result = errLocked(record.LockedUntil.Sub(now))
+            return nil
+        }
+
+        if record.CodeHash == "" || record.ExpiresAt == nil || now.After(*record.ExpiresAt) {
+            result = ErrInvalid
+            return nil
+        }
+
+        if !hmac.Equal([]byte(record.CodeHash), []byte(hashCode(purpose, email, code))) {
+            attempts := record.Attempts + 1
+            if attempts >= maxAttempts() {
+                lockedUntil := now.Add(lockDuration())
+                result = errLocked(lockDuration())
+                return tx.Model(record).Updates(map[string]interface{}{
+                    "code_hash":    "",
+                    "expires_at":   nil,
+                    "attempts":     0,
+                    "locked_until": lockedUntil,
+                }).Error
+            }
+            result = ErrInvalid
+            return tx.Model(record).Update("attempts", attempts).Error
+        }
+
+        // Hapus setelah berhasil diverifikasi (one-time use)
+        return tx.Model(record).Updates(map[string]interface{}{
+            "code_hash":  "",
+            "expires_at": nil,
+            "attempts":   0,
+        }).Error
+    })
+    if err != nil {
+        return err
+    }
+    return result
+}
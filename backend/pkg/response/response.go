@@ -8,10 +8,30 @@ type Response struct {
     Data    interface{} `json:"data,omitempty"`
 }
 
+// Page: metadata list berhalaman. NextCursor null = halaman terakhir,
+// TotalCount & Totals mencakup semua baris yang lolos filter
+type Page struct {
+    NextCursor *string     `json:"next_cursor"`
+    TotalCount int64       `json:"total_count"`
+    Totals     interface{} `json:"totals,omitempty"`
+}
+
+type PageResponse struct {
+    Success bool        `json:"success"`
+    Message string      `json:"message"`
+    Data    interface{} `json:"data"`
+    Page    Page        `json:"page"`
+}
+
 func OK(c *gin.Context, message string, data interface{}) {
     c.JSON(200, Response{Success: true, Message: message, Data: data})
 }
 
+// Paginated: data tetap berupa array, metadata halaman di field page
+func Paginated(c *gin.Context, message string, data interface{}, page Page) {
+    c.JSON(200, PageResponse{Success: true, Message: message, Data: data, Page: page})
+}
+
 func Created(c *gin.Context, message string, data interface{}) {
     c.JSON(201, Response{Success: true, Message: message, Data: data})
 }
@@ -20,10 +40,24 @@ func BadRequest(c *gin.Context, message string) {
     c.JSON(400, Response{Success: false, Message: message})
 }
 
+// BadRequestWithData dipakai jika client butuh detail error, mis. baris
+// yang gagal saat import
+func BadRequestWithData(c *gin.Context, message string, data interface{}) {
+    c.JSON(400, Response{Success: false, Message: message, Data: data})
+}
+
+func PayloadTooLarge(c *gin.Context, message string) {
+    c.JSON(413, Response{Success: false, Message: message})
+}
+
 func Unauthorized(c *gin.Context, message string) {
     c.JSON(401, Response{Success: false, Message: message})
 }
 
+func TooManyRequests(c *gin.Context, message string, data interface{}) {
+    c.JSON(429, Response{Success: false, Message: message, Data: data})
+}
+
 func InternalError(c *gin.Context, message string) {
     c.JSON(500, Response{Success: false, Message: message})
 }
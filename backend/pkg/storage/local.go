@@ -0,0 +1,78 @@
+package storage
+
+import (
+    "errors"
+    "io"
+    "os"
+    "path/filepath"
+)
+
+// LocalStorage menyimpan file di direktori lokal. Hanya cocok untuk satu
+// replica atau jika direktori berada di volume bersama.
+type LocalStorage struct {
+    dir string
+}
+
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+    if err := os.MkdirAll(dir, 0o750); err != nil {
+        return nil, err
+    }
+    return &LocalStorage{dir: dir}, nil
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+    if err := validKey(key); err != nil {
+        return "", err
+    }
+    return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put menulis ke file sementara dulu supaya file yang setengah jadi tidak
+// pernah terbaca
+func (s *LocalStorage) Put(key string, r io.Reader, size int64, contentType string) error {
+    path, err := s.path(key)
+    if err != nil {
+        return err
+    }
+    if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+        return err
+    }
+
+    tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+    if err != nil {
+        return err
+    }
+    defer os.Remove(tmp.Name())
+
+    if _, err := io.Copy(tmp, r); err != nil {
+        tmp.Close()
+        return err
+    }
+    if err := tmp.Close(); err != nil {
+        return err
+    }
+    return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStorage) Get(key string) (io.ReadCloser, error) {
+    path, err := s.path(key)
+    if err != nil {
+        return nil, err
+    }
+    f, err := os.Open(path)
+    if errors.Is(err, os.ErrNotExist) {
+        return nil, ErrNotFound
+    }
+    return f, err
+}
+
+func (s *LocalStorage) Delete(key string) error {
+    path, err := s.path(key)
+    if err != nil {
+        return err
+    }
+    if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+        return err
+    }
+    return nil
+}
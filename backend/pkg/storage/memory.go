@@ -0,0 +1,57 @@
+package storage
+
+import (
+    "bytes"
+    "io"
+    "sync"
+)
+
+// MemoryStorage menyimpan file di memori, untuk test dan development
+type MemoryStorage struct {
+    mu    sync.Mutex
+    files map[string][]byte
+}
+
+func NewMemoryStorage() *MemoryStorage {
+    return &MemoryStorage{files: map[string][]byte{}}
+}
+
+func (s *MemoryStorage) Put(key string, r io.Reader, size int64, contentType string) error {
+    if err := validKey(key); err != nil {
+        return err
+    }
+    data, err := io.ReadAll(r)
+    if err != nil {
+        return err
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.files[key] = data
+    return nil
+}
+
+func (s *MemoryStorage) Get(key string) (io.ReadCloser, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    data, ok := s.files[key]
+    if !ok {
+        return nil, ErrNotFound
+    }
+    return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemoryStorage) Delete(key string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    delete(s.files, key)
+    return nil
+}
+
+// Has dipakai test untuk cek file masih ada atau sudah dihapus
+func (s *MemoryStorage) Has(key string) bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    _, ok := s.files[key]
+    return ok
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+    "bytes"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+    "time"
+)
+
+type S3Config struct {
+    Endpoint  string // kosong = AWS S3 sesuai Region, mis. https://<account>.r2.cloudflarestorage.com
+    Region    string // default us-east-1 (R2 memakai "auto")
+    Bucket    string
+    AccessKey string
+    SecretKey string
+}
+
+// S3Storage bicara langsung ke API S3 (path-style) dengan signature V4,
+// sehingga bisa dipakai untuk AWS S3 maupun layanan S3-compatible
+type S3Storage struct {
+    cfg    S3Config
+    client *http.Client
+}
+
+func NewS3Storage(cfg S3Config) *S3Storage {
+    if cfg.Region == "" {
+        cfg.Region = "us-east-1"
+    }
+    if cfg.Endpoint == "" {
+        cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+    }
+    cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+    return &S3Storage{cfg: cfg, client: &http.Client{Timeout: 60 * time.Second}}
+}
+
+func (s *S3Storage) Put(key string, r io.Reader, size int64, contentType string) error {
+    // Body dibaca penuh untuk hash payload; ukuran lampiran sudah dibatasi
+    body, err := io.ReadAll(r)
+    if err != nil {
+        return err
+    }
+
+    res, err := s.do(http.MethodPut, key, body, contentType)
+    if err != nil {
+        return err
+    }
+    defer res.Body.Close()
+    if res.StatusCode != http.StatusOK {
+        return s3Error("put", res)
+    }
+    return nil
+}
+
+func (s *S3Storage) Get(key string) (io.ReadCloser, error) {
+    res, err := s.do(http.MethodGet, key, nil, "")
+    if err != nil {
+        return nil, err
+    }
+    switch res.StatusCode {
+    case http.StatusOK:
+        return res.Body, nil
+    case http.StatusNotFound:
+        res.Body.Close()
+        return nil, ErrNotFound
+    }
+    defer res.Body.Close()
+    return nil, s3Error("get", res)
+}
+
+func (s *S3Storage) Delete(key string) error {
+    res, err := s.do(http.MethodDelete, key, nil, "")
+    if err != nil {
+        return err
+    }
+    defer res.Body.Close()
+    if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+        return s3Error("delete", res)
+    }
+    return nil
+}
+
+func s3Error(op string, res *http.Response) error {
+    msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+    return fmt.Errorf("s3 %s failed: %s %s", op, res.Status, strings.TrimSpace(string(msg)))
+}
+
+func (s *S3Storage) do(method, key string, body []byte, contentType string) (*http.Response, error) {
+    if err := validKey(key); err != nil {
+        return nil, err
+    }
+
+    path := "/" + uriEncode(s.cfg.Bucket) + "/" + uriEncodePath(key)
+    req, err := http.NewRequest(method, s.cfg.Endpoint+path, bytes.NewReader(body))
+    if err != nil {
+        return nil, err
+    }
+    if contentType != "" {
+        req.Header.Set("Content-Type", contentType)
+    }
+    s.sign(req, path, body, time.Now().UTC())
+    return s.client.Do(req)
+}
+
+// sign menambahkan header Authorization AWS Signature Version 4
+func (s *S3Storage) sign(req *http.Request, path string, body []byte, now time.Time) {
+    amzDate := now.Format("20060102T150405Z")
+    date := now.Format("20060102")
+    payloadHash := sha256Hex(body)
+
+    req.Header.Set("x-amz-date", amzDate)
+    req.Header.Set("x-amz-content-sha256", payloadHash)
+
+    signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+    canonicalRequest := strings.Join([]string{
+        req.Method,
+        path,
+        "", // tanpa query string
+        "host:" + req.URL.Host + "\n" +
+            "x-amz-content-sha256:" + payloadHash + "\n" +
+            "x-amz-date:" + amzDate + "\n",
+        signedHeaders,
+        payloadHash,
+    }, "\n")
+
+    scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+    stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+    key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+    key = hmacSHA256(key, s.cfg.Region)
+    key = hmacSHA256(key, "s3")
+    key = hmacSHA256(key, "aws4_request")
+    signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+    req.Header.Set("Authorization", fmt.Sprintf(
+        "AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+        s.cfg.AccessKey, scope, signedHeaders, signature,
+    ))
+}
+
+func sha256Hex(data []byte) string {
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+    mac := hmac.New(sha256.New, key)
+    mac.Write([]byte(data))
+    return mac.Sum(nil)
+}
+
+// uriEncode mengikuti aturan SigV4: hanya A-Z a-z 0-9 - _ . ~ yang tidak di-encode
+func uriEncode(s string) string {
+    var b strings.Builder
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+        if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+            c == '-' || c == '_' || c == '.' || c == '~' {
+            b.WriteByte(c)
+        } else {
+            fmt.Fprintf(&b, "%%%02X", c)
+        }
+    }
+    return b.String()
+}
+
+func uriEncodePath(key string) string {
+    parts := strings.Split(key, "/")
+    for i, p := range parts {
+        parts[i] = uriEncode(p)
+    }
+    return strings.Join(parts, "/")
+}
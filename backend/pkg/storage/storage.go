@@ -0,0 +1,66 @@
+package storage
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+)
+
+var ErrNotFound = errors.New("file not found")
+
+// Storage menyimpan file (mis. lampiran struk) berdasarkan key.
+// Implementasi: LocalStorage (filesystem), S3Storage (S3-compatible:
+// AWS S3, MinIO, R2) dan MemoryStorage (test/dev).
+type Storage interface {
+    Put(key string, r io.Reader, size int64, contentType string) error
+    // Get mengembalikan ErrNotFound jika key tidak ada
+    Get(key string) (io.ReadCloser, error)
+    // Delete tidak error jika key memang sudah tidak ada
+    Delete(key string) error
+}
+
+// NewFromEnv memilih backend lewat STORAGE_DRIVER (local | s3)
+func NewFromEnv() (Storage, error) {
+    switch os.Getenv("STORAGE_DRIVER") {
+    case "", "local":
+        dir := os.Getenv("STORAGE_DIR")
+        if dir == "" {
+            dir = "data/attachments"
+        }
+        return NewLocalStorage(dir)
+
+    case "s3":
+        cfg := S3Config{
+            Endpoint:  os.Getenv("S3_ENDPOINT"),
+            Region:    os.Getenv("S3_REGION"),
+            Bucket:    os.Getenv("S3_BUCKET"),
+            AccessKey: os.Getenv("S3_ACCESS_KEY"),
+            SecretKey: os.Getenv("S3_SECRET_KEY"),
+        }
+        if cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+            return nil, fmt.Errorf("S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY must be set")
+        }
+        return NewS3Storage(cfg), nil
+
+    case "memory":
+        return NewMemoryStorage(), nil
+
+    default:
+        return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", os.Getenv("STORAGE_DRIVER"))
+    }
+}
+
+// validKey menolak key kosong, absolut atau yang keluar dari root ("..")
+func validKey(key string) error {
+    if key == "" || strings.HasPrefix(key, "/") {
+        return fmt.Errorf("invalid storage key %q", key)
+    }
+    for _, part := range strings.Split(key, "/") {
+        if part == "" || part == "." || part == ".." {
+            return fmt.Errorf("invalid storage key %q", key)
+        }
+    }
+    return nil
+}
@@ -20,4 +20,8 @@ export const authAPI = {
     const res = await api.post("/auth/login", data);
     return res.data.data;
   },
+
+  logout: async (refreshToken: string): Promise<void> => {
+    await api.post("/auth/logout", { refresh_token: refreshToken });
+  },
 };
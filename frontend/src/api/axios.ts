@@ -12,13 +12,54 @@ api.interceptors.request.use((config) => {
   return config;
 });
 
-// Auto handle 401 (token expired)
+const clearSession = () => {
+  localStorage.removeItem("token");
+  localStorage.removeItem("refresh_token");
+  window.location.href = "/login";
+};
+
+// Satu request refresh dipakai bersama oleh semua request yang kena 401,
+// karena refresh token hanya bisa dipakai sekali
+let refreshing: Promise<string> | null = null;
+
+const refreshAccessToken = (): Promise<string> => {
+  if (!refreshing) {
+    const refreshToken = localStorage.getItem("refresh_token");
+    refreshing = axios
+      .post(`${api.defaults.baseURL}/auth/refresh`, { refresh_token: refreshToken })
+      .then((res) => {
+        const { token, refresh_token } = res.data.data;
+        localStorage.setItem("token", token);
+        localStorage.setItem("refresh_token", refresh_token);
+        return token as string;
+      })
+      .finally(() => {
+        refreshing = null;
+      });
+  }
+  return refreshing;
+};
+
+// Auto handle 401 (token expired) → coba refresh sekali, lalu ulangi request
 api.interceptors.response.use(
   (res) => res,
-  (error) => {
-    if (error.response?.status === 401) {
-      localStorage.removeItem("token");
-      window.location.href = "/login";
+  async (error) => {
+    const original = error.config;
+    const isAuthCall = original?.url?.startsWith("/auth/");
+
+    if (error.response?.status === 401 && !isAuthCall) {
+      if (!original._retry && localStorage.getItem("refresh_token")) {
+        original._retry = true;
+        try {
+          const token = await refreshAccessToken();
+          original.headers.Authorization = `Bearer ${token}`;
+          return api(original);
+        } catch {
+          clearSession();
+        }
+      } else {
+        clearSession();
+      }
     }
     return Promise.reject(error);
   }
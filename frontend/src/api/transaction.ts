@@ -5,6 +5,8 @@ import {
   UpdateTransactionInput,
   TransactionSummary,
   TransactionFilter,
+  TransactionListResult,
+  CategorySummary,
   Category,
   Budget,
   UpsertBudgetInput,
@@ -23,9 +25,16 @@ export const transactionAPI = {
     return res.data.data;
   },
 
-  getAll: async (filter?: TransactionFilter): Promise<Transaction[]> => {
+  // Satu halaman; halaman berikutnya pakai cursor dari page.next_cursor
+  getPage: async (filter?: TransactionFilter): Promise<TransactionListResult> => {
     const res = await api.get("/transactions", { params: filter });
-    return res.data.data;
+    return { transactions: res.data.data ?? [], page: res.data.page };
+  },
+
+  // Semua transaksi tanpa paging, untuk export
+  getAll: async (filter?: TransactionFilter): Promise<Transaction[]> => {
+    const res = await api.get("/transactions", { params: { ...filter, all: true } });
+    return res.data.data ?? [];
   },
 
   getByID: async (id: string): Promise<Transaction> => {
@@ -46,6 +55,11 @@ export const transactionAPI = {
     const res = await api.get("/transactions/summary", { params: { month, year } });
     return res.data.data;
   },
+
+  getCategorySummary: async (month: number, year: number, type = "expense"): Promise<CategorySummary[]> => {
+    const res = await api.get("/transactions/summary/categories", { params: { month, year, type } });
+    return res.data.data ?? [];
+  },
 };
 
 export const budgetAPI = {
@@ -2,11 +2,14 @@ import {
   BarChart, Bar, XAxis, YAxis, Tooltip, ResponsiveContainer,
   Cell, PieChart, Pie,
 } from "recharts";
-import { Transaction } from "../types/transaction";
+import { PageTotals, CategorySummary } from "../types/transaction";
 import { formatCurrency } from "../utils/format";
 
+// Data dari server (page.totals per minggu dan summary per kategori),
+// bukan dari list transaksi yang hanya berisi halaman pertama
 interface Props {
-  transactions: Transaction[];
+  weeklyTotals: PageTotals[];
+  categorySummary: CategorySummary[];
 }
 
 const CustomTooltip = ({ active, payload, label }: any) => {
@@ -25,33 +28,17 @@ const CustomTooltip = ({ active, payload, label }: any) => {
 
 const PIE_COLORS = ["#6366f1", "#818cf8", "#a5b4fc", "#c7d2fe", "#e0e7ff"];
 
-export default function TransactionChart({ transactions }: Props) {
-  const barData = Array.from({ length: 4 }, (_, i) => ({
+export default function TransactionChart({ weeklyTotals, categorySummary }: Props) {
+  const barData = weeklyTotals.map((week, i) => ({
     name: `W${i + 1}`,
-    Masuk: transactions
-      .filter((t) => {
-        const day = new Date(t.date ?? "").getDate();
-        return t.type === "income" && day >= i * 7 + 1 && day <= (i + 1) * 7;
-      })
-      .reduce((s, t) => s + t.amount, 0),
-    Keluar: transactions
-      .filter((t) => {
-        const day = new Date(t.date ?? "").getDate();
-        return t.type === "expense" && day >= i * 7 + 1 && day <= (i + 1) * 7;
-      })
-      .reduce((s, t) => s + t.amount, 0),
+    Masuk: week.income,
+    Keluar: week.expense,
   }));
 
-  const expenseMap = transactions
-    .filter((t) => t.type === "expense")
-    .reduce((acc, t) => {
-      const name = t.category?.name ?? "Lainnya";
-      acc[name] = (acc[name] ?? 0) + t.amount;
-      return acc;
-    }, {} as Record<string, number>);
-
-  const pieData = Object.entries(expenseMap)
-    .map(([name, value]) => ({ name, value }))
+  // Sub-kategori sudah di-roll-up ke parent, jadi cukup ambil kategori utama
+  const pieData = categorySummary
+    .filter((c) => !c.category.parent_id && c.total > 0)
+    .map((c) => ({ name: c.category.name, value: c.total }))
     .sort((a, b) => b.value - a.value)
     .slice(0, 5);
 
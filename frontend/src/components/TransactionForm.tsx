@@ -34,6 +34,7 @@ export default function TransactionForm({ onClose, editData }: Props) {
     register,
     handleSubmit,
     reset,
+    watch,
     formState: { errors, isSubmitting },
   } = useForm<FormData>({
     resolver: zodResolver(schema),
@@ -43,13 +44,19 @@ export default function TransactionForm({ onClose, editData }: Props) {
     },
   });
 
+  // Hanya tampilkan kategori yang cocok dengan tipe transaksi
+  const selectedType = watch("type");
+  const availableCategories = categories.filter(
+    (cat) => !cat.kind || cat.kind === "both" || cat.kind === selectedType
+  );
+
   useEffect(() => {
     if (editData) {
       const rawDate = editData.date ?? "";
       const safeDate = rawDate.includes("T") ? rawDate.split("T")[0] : rawDate;
       reset({
         category_id: editData.category_id,
-        type: editData.type,
+        type: editData.type as FormData["type"],
         amount: editData.amount,
         description: editData.description ?? "",
         date: safeDate,
@@ -157,7 +164,7 @@ export default function TransactionForm({ onClose, editData }: Props) {
               className="mt-1.5 w-full border border-slate-200 bg-white rounded-lg px-3 py-2.5 text-sm text-slate-900 focus:outline-none focus:border-indigo-500 focus:ring-1 focus:ring-indigo-500 transition"
             >
               <option value="">Pilih kategori...</option>
-              {categories.map((cat) => (
+              {availableCategories.map((cat) => (
                 <option key={cat.id} value={cat.id}>
                   {cat.icon} {cat.name}
                 </option>
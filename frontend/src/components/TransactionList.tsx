@@ -75,19 +75,21 @@ export default function TransactionList({ transactions, isLoading }: Props) {
                   <span className={`text-sm font-semibold tabular-nums ${
                     tx.type === "income" ? "text-indigo-600" : "text-slate-700"
                   }`}>
-                    {tx.type === "income" ? "+" : "−"}
+                    {tx.type === "income" || tx.type === "transfer_in" ? "+" : "−"}
                     {formatCurrency(tx.amount)}
                   </span>
 
                   {/* Desktop: hover · Mobile: selalu tampil */}
                   <div className="flex gap-1 sm:opacity-0 sm:group-hover:opacity-100 transition-opacity">
-                    <button
-                      onClick={() => setEditData(tx)}
-                      className="text-xs text-slate-400 hover:text-indigo-600 p-1 rounded hover:bg-indigo-50 transition"
-                      title="Edit"
-                    >
-                      ✎
-                    </button>
+                    {!tx.transfer_id && (
+                      <button
+                        onClick={() => setEditData(tx)}
+                        className="text-xs text-slate-400 hover:text-indigo-600 p-1 rounded hover:bg-indigo-50 transition"
+                        title="Edit"
+                      >
+                        ✎
+                      </button>
+                    )}
                     <button
                       onClick={() => handleDelete(tx.id)}
                       className="text-xs text-slate-400 hover:text-red-500 p-1 rounded hover:bg-red-50 transition"
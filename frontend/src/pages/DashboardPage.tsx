@@ -7,6 +7,7 @@ import TransactionChart from "../components/TransactionChart";
 import TransactionList from "../components/TransactionList";
 import TransactionForm from "../components/TransactionForm";
 import { exportToCSV } from "../utils/export";
+import { transactionAPI } from "../api/transaction";
 import BudgetSection from "../components/BudgetSection";
 
 
@@ -18,9 +19,9 @@ const MONTH_NAMES = [
 export default function DashboardPage() {
   const { user, logout } = useAuthStore();
   const {
-    transactions, summary, isLoading,
-    fetchTransactions, fetchSummary, fetchCategories, setFilter,
-    budgets, fetchBudgets, categories,
+    transactions, summary, isLoading, nextCursor, totalCount,
+    fetchTransactions, fetchMoreTransactions, fetchSummary, fetchChart, fetchCategories, setFilter,
+    budgets, fetchBudgets, categories, filter, weeklyTotals, categorySummary,
   } = useTransactionStore();
 
   const [showForm, setShowForm] = useState(false);
@@ -32,6 +33,7 @@ export default function DashboardPage() {
     fetchCategories();
     fetchTransactions();
     fetchSummary(month, year);
+    fetchChart(month, year);
     fetchBudgets(month, year);
   }, []);
 
@@ -98,7 +100,9 @@ export default function DashboardPage() {
             </button>
 
             <button
-                onClick={() => exportToCSV(transactions)}
+                onClick={async () =>
+                  exportToCSV(await transactionAPI.getAll(filter))
+                }
                 className="border border-slate-200 bg-white text-slate-600 text-sm font-medium px-4 py-2 rounded-lg hover:bg-slate-50 transition"
             >
                 Export CSV
@@ -114,7 +118,7 @@ export default function DashboardPage() {
         <SummaryCards summary={summary} />
 
         {/* Charts */}
-        <TransactionChart transactions={transactions} />
+        <TransactionChart weeklyTotals={weeklyTotals} categorySummary={categorySummary} />
 
         {/* Filter Bar */}
         <form
@@ -156,6 +160,20 @@ export default function DashboardPage() {
         {/* List */}
         <TransactionList transactions={transactions} isLoading={isLoading} />
 
+        {nextCursor && (
+          <div className="flex flex-col items-center gap-1">
+            <button
+              onClick={fetchMoreTransactions}
+              className="border border-slate-200 bg-white text-slate-600 text-sm font-medium px-4 py-2 rounded-lg hover:bg-slate-50 transition"
+            >
+              Muat lagi
+            </button>
+            <p className="text-xs text-slate-400">
+              {transactions.length} dari {totalCount} transaksi
+            </p>
+          </div>
+        )}
+
         <BudgetSection
           budgets={budgets}
           categories={categories}
@@ -25,7 +25,7 @@ export default function LoginPage() {
   const onSubmit = async (data: FormData) => {
     try {
       const result = await authAPI.login(data);
-      setAuth(result.user, result.token);
+      setAuth(result.user, result.token, result.refresh_token);
       navigate("/dashboard");
     } catch (err: any) {
       alert(err.response?.data?.message || "Login gagal");
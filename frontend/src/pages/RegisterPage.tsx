@@ -60,7 +60,7 @@ export default function RegisterPage() {
   const onVerifyOTP = async (data: OTPForm) => {
     try {
       const result = await authAPI.verifyOTP(email, data.code);
-      setAuth(result.user, result.token);
+      setAuth(result.user, result.token, result.refresh_token);
       navigate("/dashboard");
     } catch (err: any) {
       otpForm.setError("root", {
@@ -1,12 +1,13 @@
 import { create } from "zustand";
 import { persist } from "zustand/middleware";
 import { User } from "../types/auth";
+import { authAPI } from "../api/auth";
 
 interface AuthState {
   user: User | null;
   token: string | null;
   isAuthenticated: boolean;
-  setAuth: (user: User, token: string) => void;
+  setAuth: (user: User, token: string, refreshToken: string) => void;
   logout: () => void;
 }
 
@@ -16,12 +17,19 @@ export const useAuthStore = create<AuthState>()(
       user: null,
       token: null,
       isAuthenticated: false,
-      setAuth: (user, token) => {
+      setAuth: (user, token, refreshToken) => {
         localStorage.setItem("token", token);
+        localStorage.setItem("refresh_token", refreshToken);
         set({ user, token, isAuthenticated: true });
       },
       logout: () => {
+        const refreshToken = localStorage.getItem("refresh_token");
+        if (refreshToken) {
+          // Revoke session di server, tidak perlu ditunggu
+          authAPI.logout(refreshToken).catch(() => {});
+        }
         localStorage.removeItem("token");
+        localStorage.removeItem("refresh_token");
         set({ user: null, token: null, isAuthenticated: false });
       },
     }),
@@ -1,10 +1,17 @@
 import { create } from "zustand";
-import { Transaction, TransactionFilter, TransactionSummary, Category, Budget } from "../types/transaction";
+import {
+  Transaction, TransactionFilter, TransactionSummary, Category, Budget,
+  PageTotals, CategorySummary,
+} from "../types/transaction";
 import { transactionAPI, categoryAPI, budgetAPI } from "../api/transaction";
 
 interface TransactionState {
   transactions: Transaction[];
+  nextCursor: string | null;
+  totalCount: number;
   summary: TransactionSummary | null;
+  weeklyTotals: PageTotals[];
+  categorySummary: CategorySummary[];
   categories: Category[];
   isLoading: boolean;
   filter: TransactionFilter;
@@ -13,7 +20,9 @@ interface TransactionState {
   budgets: Budget[];
 
   fetchTransactions: () => Promise<void>;
+  fetchMoreTransactions: () => Promise<void>;
   fetchSummary: (month: number, year: number) => Promise<void>;
+  fetchChart: (month: number, year: number) => Promise<void>;
   fetchCategories: () => Promise<void>;
   setFilter: (filter: TransactionFilter) => void;
   deleteTransaction: (id: string) => Promise<void>;
@@ -23,9 +32,38 @@ interface TransactionState {
   refreshAll: () => Promise<void>;
 }
 
+const isoDate = (year: number, month: number, day: number) =>
+  `${year}-${String(month).padStart(2, "0")}-${String(day).padStart(2, "0")}`;
+
+// Minggu ke-4 sampai akhir bulan supaya tanggal 29-31 ikut terhitung
+const weekRanges = (month: number, year: number) => {
+  const lastDay = new Date(year, month, 0).getDate();
+  return [[1, 7], [8, 14], [15, 21], [22, lastDay]].map(([from, to]) => ({
+    start_date: isoDate(year, month, from),
+    end_date: isoDate(year, month, to),
+  }));
+};
+
+// Total dihitung server dari semua transaksi di rentang tersebut (bukan
+// hanya halaman yang sudah dimuat), limit 1 karena hanya butuh page.totals
+const fetchChartData = (month: number, year: number) =>
+  Promise.all([
+    Promise.all(
+      weekRanges(month, year).map(async (range) => {
+        const { page } = await transactionAPI.getPage({ ...range, limit: 1 });
+        return page.totals ?? { income: 0, expense: 0, net: 0, currency: "" };
+      })
+    ),
+    transactionAPI.getCategorySummary(month, year),
+  ]);
+
 export const useTransactionStore = create<TransactionState>((set, get) => ({
   transactions: [],
+  nextCursor: null,
+  totalCount: 0,
   summary: null,
+  weeklyTotals: [],
+  categorySummary: [],
   categories: [],
   isLoading: false,
   filter: {},
@@ -36,13 +74,26 @@ export const useTransactionStore = create<TransactionState>((set, get) => ({
   fetchTransactions: async () => {
     set({ isLoading: true });
     try {
-      const data = await transactionAPI.getAll(get().filter);
-      set({ transactions: data });
+      const { transactions, page } = await transactionAPI.getPage(get().filter);
+      set({ transactions, nextCursor: page.next_cursor, totalCount: page.total_count });
     } finally {
       set({ isLoading: false });
     }
   },
 
+  // Ambil halaman berikutnya dan tambahkan ke list
+  fetchMoreTransactions: async () => {
+    const { nextCursor, filter } = get();
+    if (!nextCursor) return;
+
+    const { transactions, page } = await transactionAPI.getPage({ ...filter, cursor: nextCursor });
+    set((state) => ({
+      transactions: [...state.transactions, ...transactions],
+      nextCursor: page.next_cursor,
+      totalCount: page.total_count,
+    }));
+  },
+
   fetchSummary: async (month, year) => {
     // Simpan month & year agar bisa dipakai refreshAll
     set({ currentMonth: month, currentYear: year });
@@ -50,6 +101,11 @@ export const useTransactionStore = create<TransactionState>((set, get) => ({
     set({ summary: data });
   },
 
+  fetchChart: async (month, year) => {
+    const [weeklyTotals, categorySummary] = await fetchChartData(month, year);
+    set({ weeklyTotals, categorySummary });
+  },
+
   fetchCategories: async () => {
     const data = await categoryAPI.getAll();
     set({ categories: data });
@@ -77,12 +133,21 @@ export const useTransactionStore = create<TransactionState>((set, get) => ({
 
     set({ isLoading: true });
     try {
-      const [transactions, summary, budgets] = await Promise.all([
-        transactionAPI.getAll(filter),
+      const [list, summary, budgets, [weeklyTotals, categorySummary]] = await Promise.all([
+        transactionAPI.getPage(filter),
         transactionAPI.getSummary(currentMonth, currentYear),
         budgetAPI.getByMonth(currentMonth, currentYear),
+        fetchChartData(currentMonth, currentYear),
       ]);
-      set({ transactions, summary, budgets });
+      set({
+        transactions: list.transactions,
+        nextCursor: list.page.next_cursor,
+        totalCount: list.page.total_count,
+        summary,
+        budgets,
+        weeklyTotals,
+        categorySummary,
+      });
     } finally {
       set({ isLoading: false });
     }
@@ -7,6 +7,8 @@ export interface User {
 
 export interface AuthResponse {
   token: string;
+  refresh_token: string;
+  expires_in: number;
   user: User;
 }
 
@@ -1,15 +1,54 @@
 export type TransactionType = "income" | "expense";
 
+// Leg transfer antar akun, tidak dihitung sebagai pemasukan/pengeluaran
+export type TransferLegType = "transfer_in" | "transfer_out";
+
+export type CategoryKind = "income" | "expense" | "both";
+
 export interface Category {
   id: string;
   name: string;
   icon: string;
+  kind?: CategoryKind;
+  parent_id?: string | null;
+  is_archived?: boolean;
 }
 
-export interface Category {
+export type AccountType = "bank" | "cash" | "ewallet" | "credit_card";
+
+export interface Account {
   id: string;
   name: string;
-  icon: string;
+  type: AccountType;
+  currency: string;
+  opening_balance: number;
+  balance: number;
+  is_default: boolean;
+  is_archived: boolean;
+}
+
+export interface Tag {
+  id: string;
+  user_id: string;
+  name: string;
+}
+
+export interface Attachment {
+  id: string;
+  transaction_id: string;
+  file_name: string;
+  content_type: string;
+  size: number;
+  created_at: string;
+}
+
+export interface TransactionSplit {
+  id: string;
+  transaction_id: string;
+  category_id: string;
+  category: Category;
+  amount: number;
+  note: string;
 }
 
 export interface Transaction {
@@ -17,28 +56,42 @@ export interface Transaction {
   user_id: string;
   category_id: string;
   category: Category;
-  type: TransactionType;
+  account_id?: string;
+  account?: Account;
+  transfer_id?: string | null;
+  recurring_rule_id?: string | null;
+  type: TransactionType | TransferLegType;
   amount: number;
+  currency?: string;
   description: string;
+  payee: string;
+  external_id?: string;
   date: string | null;
+  splits?: TransactionSplit[] | null;
+  tags?: Tag[] | null;
+  attachments?: Attachment[] | null;
   created_at: string | null;
   updated_at: string | null;
 }
 
 
 export interface CreateTransactionInput {
-  category_id: string;
+  account_id?: string;
+  category_id?: string; // kosong = dari rule
   type: TransactionType;
   amount: number;
   description?: string;
+  payee?: string;
   date: string;
 }
 
 export interface UpdateTransactionInput {
+  account_id?: string;
   category_id?: string;
   type?: TransactionType;
   amount?: number;
   description?: string;
+  payee?: string;
   date?: string;
 }
 
@@ -46,16 +99,76 @@ export interface TransactionSummary {
   income: number;
   expense: number;
   balance: number;
+  currency?: string;
   month: number;
   year: number;
 }
 
+// Metadata halaman GET /transactions; totals dihitung dari semua baris
+// yang lolos filter, dalam base currency
+export interface PageTotals {
+  income: number;
+  expense: number;
+  net: number;
+  currency: string;
+}
+
+export interface TransactionPage {
+  next_cursor: string | null;
+  total_count: number;
+  totals?: PageTotals;
+}
+
+export interface TransactionListResult {
+  transactions: Transaction[];
+  page: TransactionPage;
+}
+
+// Hasil /transactions/summary/categories; total termasuk sub-kategori
+export interface CategorySummary {
+  category: Category;
+  amount: number;
+  total: number;
+  currency: string;
+}
+
+// Hasil /transactions/search; snippet berisi <mark> di kata yang cocok
+export interface TransactionSearchHit {
+  transaction: Transaction;
+  rank: number;
+  snippet: string;
+}
+
+// Hasil /transactions/duplicates, transaksi urut tanggal
+export interface DuplicateGroup {
+  type: TransactionType;
+  amount: number;
+  currency: string;
+  transactions: Transaction[];
+}
+
 export interface TransactionFilter {
   type?: string;
-  category_id?: string;
+  category_id?: string; // boleh beberapa, dipisah koma
+  exclude_category_id?: string;
+  account_id?: string;
+  tags?: string;
+  tag_match?: "any" | "all";
+  min_amount?: number;
+  max_amount?: number;
+  has_attachment?: boolean;
   search?: string;
   start_date?: string;
   end_date?: string;
+  created_from?: string;
+  created_to?: string;
+  updated_from?: string;
+  updated_to?: string;
+  limit?: number;
+  cursor?: string;
+  sort?: "date" | "amount" | "created_at";
+  order?: "asc" | "desc";
+  all?: boolean; // tanpa paging, untuk export
 }
 
 export interface Budget {
@@ -68,6 +181,7 @@ export interface Budget {
   spent: number;
   remaining: number;
   is_over: boolean;
+  currency?: string;
 }
 
 export interface UpsertBudgetInput {
@@ -76,3 +190,62 @@ export interface UpsertBudgetInput {
   month: number;
   year: number;
 }
+
+// POST /imports: preview (dry_run) atau hasil commit
+// Rule kategorisasi otomatis, dievaluasi urut priority (kecil dulu)
+export interface TransactionRule {
+  id: string;
+  name: string;
+  priority: number;
+  is_active: boolean;
+  description_contains: string;
+  description_regex: string;
+  min_amount: number | null;
+  max_amount: number | null;
+  account_id: string | null;
+  type: TransactionType | "";
+  category_id: string | null;
+  category?: Category;
+  tags: Tag[];
+  set_payee: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface ImportSource {
+  name: string;
+  label: string;
+  needs_mapping: boolean;
+  account_type?: string;
+  account_hint?: string;
+  currency?: string;
+}
+
+export interface ImportRow {
+  row: number;
+  date: string;
+  type: "income" | "expense";
+  amount: number;
+  description: string;
+  payee: string;
+  category_name: string;
+  category_id: string | null;
+  external_id?: string;
+  tags?: string[];
+  rules?: string[];
+  skipped?: boolean;
+  errors?: string[];
+  warnings?: string[];
+}
+
+export interface ImportResult {
+  source: string;
+  account_id: string;
+  currency: string;
+  dry_run: boolean;
+  valid: number;
+  invalid: number;
+  skipped: number;
+  imported: number;
+  rows: ImportRow[];
+}
@@ -7,7 +7,7 @@ export const exportToCSV = (transactions: Transaction[]) => {
     formatDate(tx.date),
     tx.description || "-",
     tx.category?.name || "-",
-    tx.type === "income" ? "Pemasukan" : "Pengeluaran",
+    tx.transfer_id ? "Transfer" : tx.type === "income" ? "Pemasukan" : "Pengeluaran",
     tx.amount.toString(),
   ]);
 